@@ -0,0 +1,156 @@
+package application
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/network"
+)
+
+const bootstrapLogInterval = 1000
+
+func ExportChainUseCase(store domain.ChainStore, from, to int64, out io.Writer) error {
+	bc, err := domain.ContinueBlockchainReadOnly(store)
+	if err != nil {
+		store.Close()
+		return err
+	}
+	defer bc.Close()
+
+	if to < 0 {
+		to = bc.GetBestHeight()
+	}
+	if from < 0 || from > to || to > bc.GetBestHeight() {
+		return fmt.Errorf("khoảng chiều cao %d-%d không hợp lệ (chain hiện có 0-%d)", from, to, bc.GetBestHeight())
+	}
+
+	hashes, err := bc.BlockHashes()
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(out)
+	if err := domain.WriteBootstrapHeader(w, domain.NewBootstrapHeader(from, to, bc.Emission)); err != nil {
+		return err
+	}
+	total := to - from + 1
+	for height := from; height <= to; height++ {
+		block, err := bc.GetBlock(hashes[height])
+		if err != nil {
+			return err
+		}
+		if err := domain.WriteBootstrapBlock(w, block); err != nil {
+			return err
+		}
+		if done := height - from + 1; done%bootstrapLogInterval == 0 {
+			log.Printf("Đã xuất %d/%d block...", done, total)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	log.Printf("Đã xuất %d block (chiều cao %d-%d).", total, from, to)
+	return nil
+}
+
+func ImportChainUseCase(ctx context.Context, store domain.ChainStore, path string, txIndex bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		store.Close()
+		return err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+
+	header, err := domain.ReadBootstrapHeader(r)
+	if err != nil {
+		store.Close()
+		return err
+	}
+	if err := header.Check(); err != nil {
+		store.Close()
+		return err
+	}
+
+	bc, created, err := openImportTarget(store, r, header)
+	if err != nil {
+		store.Close()
+		return err
+	}
+	defer bc.Close()
+
+	if bc.Emission != header.Emission {
+		return fmt.Errorf("lịch phát hành trong file (%+v) khác với chain hiện tại (%+v)", header.Emission, bc.Emission)
+	}
+	if txIndex {
+		if err := bc.EnableIndex(); err != nil {
+			return err
+		}
+	}
+	if header.From > bc.GetBestHeight()+1 {
+		return fmt.Errorf("file bắt đầu từ chiều cao %d nhưng tip hiện tại là %d (cần export từ --from %d)", header.From, bc.GetBestHeight(), bc.GetBestHeight()+1)
+	}
+
+	log.Printf("Đang nhập block %d-%d vào chain đang ở chiều cao %d...", header.From, header.To, bc.GetBestHeight())
+	total := header.To - header.From + 1
+	var imported, skipped int64
+	if created {
+		imported++
+	}
+	for {
+		if ctx.Err() != nil {
+			return fmt.Errorf("bị dừng sau khi nhập %d block (tip %d); chạy lại lệnh import để tiếp tục", imported, bc.GetBestHeight())
+		}
+
+		block, err := domain.ReadBootstrapBlock(r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("sau chiều cao %d: %w", bc.GetBestHeight(), err)
+		}
+
+		if block.Height <= bc.GetBestHeight() {
+			if !bc.HasBlock(block.Hash) {
+				return fmt.Errorf("block %x (chiều cao %d) trong file rẽ nhánh khỏi chain hiện tại", block.Hash, block.Height)
+			}
+			skipped++
+		} else {
+			if err := network.ConnectBlock(bc, block); err != nil {
+				return fmt.Errorf("block %x (chiều cao %d) không hợp lệ: %w", block.Hash, block.Height, err)
+			}
+			imported++
+		}
+
+		if done := imported + skipped; done%bootstrapLogInterval == 0 {
+			log.Printf("Đã xử lý %d/%d block (tip %d)...", done, total, bc.GetBestHeight())
+		}
+	}
+
+	fmt.Printf("Đã nhập %d block, bỏ qua %d block đã có. Tip hiện tại: %d (%x)\n", imported, skipped, bc.GetBestHeight(), bc.LastHash)
+	return nil
+}
+
+func openImportTarget(store domain.ChainStore, r io.Reader, header domain.BootstrapHeader) (*domain.Blockchain, bool, error) {
+	bc, err := domain.ContinueBlockchain(store)
+	if !errors.Is(err, domain.ErrNoBlockchain) {
+		return bc, false, err
+	}
+	if header.From != 0 {
+		return nil, false, fmt.Errorf("CSDL chưa có blockchain nhưng file bắt đầu từ chiều cao %d (cần export từ --from 0)", header.From)
+	}
+
+	genesis, err := domain.ReadBootstrapBlock(r)
+	if err != nil {
+		return nil, false, err
+	}
+	log.Printf("Khởi tạo chain từ block genesis %x trong file", genesis.Hash)
+	bc, err = domain.InitBlockchainWithGenesis(store, genesis, header.Emission)
+	return bc, err == nil, err
+}
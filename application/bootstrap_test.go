@@ -0,0 +1,168 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/storage"
+)
+
+func newSourceChain(t *testing.T, blocks int) domain.ChainStore {
+	t.Helper()
+	domain.SelectParams(&domain.RegTestParams)
+
+	alice, err := domain.NewWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := domain.NewWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := storage.NewMemory()
+	emission := domain.EmissionSchedule{InitialReward: 100, HalvingInterval: 150, MaxSupply: 1000000}
+	bc, err := domain.InitBlockchain(store, alice.GetAddress(), emission)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= blocks; i++ {
+		prev, err := bc.GetBlock(bc.LastHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spent := prev.Transactions[0]
+
+		out := domain.TxOutput{Value: spent.Vout[0].Value - 1}
+		if err := out.Lock(bob.GetAddress()); err != nil {
+			t.Fatal(err)
+		}
+		tx := &domain.Transaction{
+			Vin:  []domain.TxInput{{TxID: spent.ID, VoutIndex: 0, PublicKey: alice.PublicKey, Sequence: domain.SequenceFinal}},
+			Vout: []domain.TxOutput{out},
+		}
+		tx.SetID()
+		prevTxs, err := bc.ReferencedOutputs(tx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Sign(alice.PrivateKey, prevTxs); err != nil {
+			t.Fatal(err)
+		}
+
+		coinbase, err := domain.NewCoinbaseTransaction(alice.GetAddress(), emission.BlockReward(int64(i))+1, int64(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := bc.AddBlock([]*domain.Transaction{coinbase, tx}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store
+}
+
+func exportChain(t *testing.T, store domain.ChainStore, from, to int64) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := ExportChainUseCase(store, from, to, &buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func writeFile(t *testing.T, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "chain.dat")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func tipOf(t *testing.T, store domain.ChainStore) (int64, []byte) {
+	t.Helper()
+	bc, err := domain.ContinueBlockchainReadOnly(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bc.GetBestHeight(), bc.LastHash
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	source := newSourceChain(t, 5)
+	data := exportChain(t, source, 0, -1)
+
+	target := storage.NewMemory()
+	if err := ImportChainUseCase(context.Background(), target, writeFile(t, data), true); err != nil {
+		t.Fatal(err)
+	}
+
+	wantHeight, wantHash := tipOf(t, source)
+	gotHeight, gotHash := tipOf(t, target)
+	if gotHeight != wantHeight || !bytes.Equal(gotHash, wantHash) {
+		t.Fatalf("tip sau khi nhập = %d %x, muốn %d %x", gotHeight, gotHash, wantHeight, wantHash)
+	}
+
+	bc, err := domain.ContinueBlockchainReadOnly(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := bc.Verify(domain.VerifyOptions{Full: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() {
+		t.Fatalf("chain nhập vào không khớp: %v %v", report.Invalid, report.Mismatches)
+	}
+	if !bytes.Equal(exportChain(t, target, 0, -1), data) {
+		t.Fatal("export lại chain đã nhập không ra cùng một file")
+	}
+}
+
+func TestImportTruncatedStreamResumes(t *testing.T) {
+	source := newSourceChain(t, 5)
+	data := exportChain(t, source, 0, -1)
+
+	target := storage.NewMemory()
+	err := ImportChainUseCase(context.Background(), target, writeFile(t, data[:len(data)-10]), false)
+	if !errors.Is(err, domain.ErrInvalidBootstrap) {
+		t.Fatalf("file bị cắt cụt: lỗi %v, muốn ErrInvalidBootstrap", err)
+	}
+	if height, _ := tipOf(t, target); height != 4 {
+		t.Fatalf("sau khi nhập file bị cắt cụt tip = %d, muốn 4", height)
+	}
+
+	if err := ImportChainUseCase(context.Background(), target, writeFile(t, data), false); err != nil {
+		t.Fatalf("nhập lại file đầy đủ: %v", err)
+	}
+	_, wantHash := tipOf(t, source)
+	if _, gotHash := tipOf(t, target); !bytes.Equal(gotHash, wantHash) {
+		t.Fatalf("tip sau khi nhập tiếp = %x, muốn %x", gotHash, wantHash)
+	}
+}
+
+func TestImportRejectsTamperedBlock(t *testing.T) {
+	source := newSourceChain(t, 2)
+	head := exportChain(t, source, 0, 1)
+	tail := exportChain(t, source, 2, 2)
+
+	target := storage.NewMemory()
+	if err := ImportChainUseCase(context.Background(), target, writeFile(t, head), false); err != nil {
+		t.Fatal(err)
+	}
+
+	idx := bytes.LastIndex(tail, []byte{0x01})
+	tampered := append([]byte{}, tail...)
+	tampered[idx] ^= 0x01
+	if err := ImportChainUseCase(context.Background(), target, writeFile(t, tampered), false); err == nil {
+		t.Fatal("block bị sửa vẫn được nhập")
+	}
+	if height, _ := tipOf(t, target); height != 1 {
+		t.Fatalf("tip sau khi từ chối block bị sửa = %d, muốn 1", height)
+	}
+}
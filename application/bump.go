@@ -0,0 +1,82 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+func BumpFeeUseCase(txID []byte, newFee int64, wallet *domain.Wallet, targetNodeAddr string) ([]byte, error) {
+	conn, client, err := connectNode(targetNodeAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := client.GetTransaction(context.Background(), &proto.GetTransactionRequest{TxId: txID})
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy giao dịch: %w", err)
+	}
+	if !res.Pending {
+		return nil, fmt.Errorf("giao dịch %x đã được xác nhận ở block %d, không thể thay thế", txID, res.BlockHeight)
+	}
+	if newFee <= res.Fee {
+		return nil, fmt.Errorf("phí mới (%d) phải lớn hơn phí hiện tại (%d)", newFee, res.Fee)
+	}
+
+	tx, err := domain.DecodeRawTransaction(res.RawHex)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeyHash := domain.HashPubKey(wallet.PublicKey)
+	changeIndex := -1
+	for i, out := range tx.Vout {
+		if len(out.Script) == 0 && bytes.Equal(out.PubKeyHash, pubKeyHash) {
+			changeIndex = i
+		}
+	}
+	if changeIndex < 0 {
+		return nil, errors.New("giao dịch không có output tiền thừa về ví này để trừ phí")
+	}
+
+	delta := newFee - res.Fee
+	change := tx.Vout[changeIndex].Value
+	if change < delta {
+		return nil, fmt.Errorf("%w: output tiền thừa (%d) không đủ để tăng phí thêm %d", domain.ErrInsufficientFunds, change, delta)
+	}
+	if change == delta {
+		tx.Vout = append(tx.Vout[:changeIndex], tx.Vout[changeIndex+1:]...)
+	} else {
+		tx.Vout[changeIndex].Value = change - delta
+	}
+
+	prevTxs, err := fetchPrevTxs(client, tx)
+	if err != nil {
+		return nil, err
+	}
+	tx.SetID()
+	signed, err := tx.SignOwnedInputs(wallet.PrivateKey, prevTxs, domain.SigHashAll)
+	if err != nil {
+		return nil, err
+	}
+	if signed != len(tx.Vin) {
+		return nil, fmt.Errorf("ví này chỉ ký được %d/%d input, không thể thay thế giao dịch", signed, len(tx.Vin))
+	}
+	log.Printf("Đã tạo giao dịch thay thế %x (phí %d -> %d)", tx.ID, res.Fee, newFee)
+
+	ack, err := client.SendRawTransaction(context.Background(), &proto.RawTransaction{Hex: tx.RawHex()})
+	if err != nil {
+		return nil, fmt.Errorf("gọi gRPC SendRawTransaction thất bại: %w", err)
+	}
+	if !ack.Success {
+		return nil, fmt.Errorf("node từ chối giao dịch thay thế: %s", ack.Message)
+	}
+	fmt.Printf("Đã thay thế giao dịch %x bằng %x\n", txID, tx.ID)
+	return tx.ID, nil
+}
@@ -0,0 +1,243 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+const (
+	bnbMaxTries         = 100000
+	consolidationInputs = 500
+)
+
+var ErrInsufficientFunds = errors.New("không đủ tiền")
+
+type CoinSelector interface {
+	Name() string
+	Select(utxos []*proto.SpendableUTXO, target int64) ([]*proto.SpendableUTXO, error)
+}
+
+type LargestFirstSelector struct{}
+
+type BranchAndBoundSelector struct {
+	Tolerance int64
+	Fallback  CoinSelector
+}
+
+type PrivacySelector struct{}
+
+type ConsolidationSelector struct {
+	MaxInputs int
+}
+
+func NewCoinSelector(name string, tolerance int64) (CoinSelector, error) {
+	switch strings.ToLower(name) {
+	case "", "largest", "largest-first":
+		return LargestFirstSelector{}, nil
+	case "bnb", "branch-and-bound":
+		return BranchAndBoundSelector{Tolerance: tolerance, Fallback: LargestFirstSelector{}}, nil
+	case "privacy":
+		return PrivacySelector{}, nil
+	case "consolidate", "consolidation":
+		return ConsolidationSelector{MaxInputs: consolidationInputs}, nil
+	}
+	return nil, fmt.Errorf("chiến lược chọn coin không hỗ trợ: %q (largest, bnb, privacy, consolidate)", name)
+}
+
+func sumUTXOs(utxos []*proto.SpendableUTXO) int64 {
+	var total int64
+	for _, utxo := range utxos {
+		total += utxo.Amount
+	}
+	return total
+}
+
+func sortedByAmount(utxos []*proto.SpendableUTXO, descending bool) []*proto.SpendableUTXO {
+	sorted := append([]*proto.SpendableUTXO{}, utxos...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Unconfirmed != sorted[j].Unconfirmed {
+			return !sorted[i].Unconfirmed
+		}
+		if descending {
+			return sorted[i].Amount > sorted[j].Amount
+		}
+		return sorted[i].Amount < sorted[j].Amount
+	})
+	return sorted
+}
+
+func accumulate(utxos []*proto.SpendableUTXO, target int64, maxInputs int) ([]*proto.SpendableUTXO, error) {
+	var selected []*proto.SpendableUTXO
+	var total int64
+	for _, utxo := range utxos {
+		if total >= target || (maxInputs > 0 && len(selected) >= maxInputs) {
+			break
+		}
+		selected = append(selected, utxo)
+		total += utxo.Amount
+	}
+	if total < target {
+		return nil, fmt.Errorf("%w (có %d, cần %d)", ErrInsufficientFunds, total, target)
+	}
+	return selected, nil
+}
+
+func (LargestFirstSelector) Name() string {
+	return "largest-first"
+}
+
+func (LargestFirstSelector) Select(utxos []*proto.SpendableUTXO, target int64) ([]*proto.SpendableUTXO, error) {
+	return accumulate(sortedByAmount(utxos, true), target, 0)
+}
+
+func (s BranchAndBoundSelector) Name() string {
+	return "branch-and-bound"
+}
+
+func (s BranchAndBoundSelector) Select(utxos []*proto.SpendableUTXO, target int64) ([]*proto.SpendableUTXO, error) {
+	sorted := sortedByAmount(utxos, true)
+
+	remaining := make([]int64, len(sorted)+1)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + sorted[i].Amount
+	}
+
+	var best []int
+	var current []int
+	bestWaste := int64(-1)
+	tries := 0
+
+	var search func(depth int, total int64)
+	search = func(depth int, total int64) {
+		tries++
+		if tries > bnbMaxTries || bestWaste == 0 {
+			return
+		}
+		if total > target+s.Tolerance || total+remaining[depth] < target {
+			return
+		}
+		if total >= target {
+			if waste := total - target; bestWaste < 0 || waste < bestWaste {
+				bestWaste = waste
+				best = append(best[:0], current...)
+			}
+			return
+		}
+		if depth == len(sorted) {
+			return
+		}
+
+		current = append(current, depth)
+		search(depth+1, total+sorted[depth].Amount)
+		current = current[:len(current)-1]
+
+		search(depth+1, total)
+	}
+	search(0, 0)
+
+	if bestWaste < 0 {
+		if s.Fallback != nil {
+			return s.Fallback.Select(utxos, target)
+		}
+		return nil, fmt.Errorf("%w: không có tổ hợp UTXO nào khớp %d (sai lệch tối đa %d)", ErrInsufficientFunds, target, s.Tolerance)
+	}
+
+	selected := make([]*proto.SpendableUTXO, 0, len(best))
+	for _, idx := range best {
+		selected = append(selected, sorted[idx])
+	}
+	return selected, nil
+}
+
+func (PrivacySelector) Name() string {
+	return "privacy"
+}
+
+func (PrivacySelector) Select(utxos []*proto.SpendableUTXO, target int64) ([]*proto.SpendableUTXO, error) {
+	groups := make(map[string][]*proto.SpendableUTXO)
+	var order []string
+	for _, utxo := range sortedByAmount(utxos, false) {
+		key := string(utxo.TxId)
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], utxo)
+	}
+
+	var bestGroup []*proto.SpendableUTXO
+	for _, key := range order {
+		group := groups[key]
+		total := sumUTXOs(group)
+		if total >= target && (bestGroup == nil || total < sumUTXOs(bestGroup)) {
+			bestGroup = group
+		}
+	}
+	if bestGroup != nil {
+		return bestGroup, nil
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return sumUTXOs(groups[order[i]]) > sumUTXOs(groups[order[j]])
+	})
+	var selected []*proto.SpendableUTXO
+	var total int64
+	for _, key := range order {
+		if total >= target {
+			break
+		}
+		selected = append(selected, groups[key]...)
+		total += sumUTXOs(groups[key])
+	}
+	if total < target {
+		return nil, fmt.Errorf("%w (có %d, cần %d)", ErrInsufficientFunds, total, target)
+	}
+	return selected, nil
+}
+
+func (ConsolidationSelector) Name() string {
+	return "consolidation"
+}
+
+func (s ConsolidationSelector) Select(utxos []*proto.SpendableUTXO, target int64) ([]*proto.SpendableUTXO, error) {
+	sorted := sortedByAmount(utxos, false)
+	var confirmed []*proto.SpendableUTXO
+	for _, utxo := range sorted {
+		if !utxo.Unconfirmed {
+			confirmed = append(confirmed, utxo)
+		}
+	}
+
+	limit := s.MaxInputs
+	if limit <= 0 || limit > len(confirmed) {
+		limit = len(confirmed)
+	}
+	selected := append([]*proto.SpendableUTXO{}, confirmed[:limit]...)
+	total := sumUTXOs(selected)
+	if total >= target {
+		return selected, nil
+	}
+
+	rest, err := accumulate(sortedByAmount(excludeUTXOs(utxos, selected), true), target-total, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w (cần %d)", ErrInsufficientFunds, target)
+	}
+	return append(selected, rest...), nil
+}
+
+func excludeUTXOs(utxos []*proto.SpendableUTXO, selected []*proto.SpendableUTXO) []*proto.SpendableUTXO {
+	used := make(map[*proto.SpendableUTXO]bool, len(selected))
+	for _, utxo := range selected {
+		used[utxo] = true
+	}
+	var rest []*proto.SpendableUTXO
+	for _, utxo := range utxos {
+		if !used[utxo] {
+			rest = append(rest, utxo)
+		}
+	}
+	return rest
+}
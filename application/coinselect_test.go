@@ -0,0 +1,146 @@
+package application
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+func coin(txID string, vout int32, amount int64, unconfirmed bool) *proto.SpendableUTXO {
+	return &proto.SpendableUTXO{TxId: []byte(txID), VoutIndex: vout, Amount: amount, Unconfirmed: unconfirmed}
+}
+
+func amounts(utxos []*proto.SpendableUTXO) []int64 {
+	out := make([]int64, 0, len(utxos))
+	for _, utxo := range utxos {
+		out = append(out, utxo.Amount)
+	}
+	return out
+}
+
+func expectAmounts(t *testing.T, name string, got []*proto.SpendableUTXO, want ...int64) {
+	t.Helper()
+	amts := amounts(got)
+	if len(amts) != len(want) {
+		t.Fatalf("%s chọn %v, muốn %v", name, amts, want)
+	}
+	for i := range want {
+		if amts[i] != want[i] {
+			t.Fatalf("%s chọn %v, muốn %v", name, amts, want)
+		}
+	}
+}
+
+func TestLargestFirstPrefersConfirmed(t *testing.T) {
+	utxos := []*proto.SpendableUTXO{coin("a", 0, 5, false), coin("b", 0, 50, true), coin("c", 0, 20, false), coin("d", 0, 10, false)}
+	sel := LargestFirstSelector{}
+
+	got, err := sel.Select(utxos, 28)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectAmounts(t, "largest-first 28", got, 20, 10)
+
+	got, err = sel.Select(utxos, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectAmounts(t, "largest-first 40", got, 20, 10, 5, 50)
+
+	if _, err := sel.Select(utxos, 100); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("thiếu tiền: lỗi %v, muốn ErrInsufficientFunds", err)
+	}
+}
+
+func TestBranchAndBoundFindsExactMatch(t *testing.T) {
+	utxos := []*proto.SpendableUTXO{coin("a", 0, 7, false), coin("b", 0, 5, false), coin("c", 0, 4, false), coin("d", 0, 3, false)}
+
+	got, err := BranchAndBoundSelector{}.Select(utxos, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectAmounts(t, "bnb 9", got, 5, 4)
+
+	got, err = BranchAndBoundSelector{Tolerance: 1}.Select(utxos, 13)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sumUTXOs(got) < 13 || sumUTXOs(got) > 14 {
+		t.Fatalf("bnb 13±1 chọn %v", amounts(got))
+	}
+
+	single := []*proto.SpendableUTXO{coin("a", 0, 5, false)}
+	if _, err := (BranchAndBoundSelector{}).Select(single, 1); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("không có tổ hợp khớp và không fallback: lỗi %v", err)
+	}
+	got, err = BranchAndBoundSelector{Fallback: LargestFirstSelector{}}.Select(single, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectAmounts(t, "bnb fallback", got, 5)
+}
+
+func TestPrivacySelectorKeepsTransactionsTogether(t *testing.T) {
+	utxos := []*proto.SpendableUTXO{
+		coin("a", 0, 10, false), coin("b", 0, 25, false), coin("a", 1, 10, false), coin("c", 0, 3, false),
+	}
+	sel := PrivacySelector{}
+
+	got, err := sel.Select(utxos, 18)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectAmounts(t, "privacy 18 (nhóm nhỏ nhất đủ tiền)", got, 10, 10)
+
+	got, err = sel.Select(utxos, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectAmounts(t, "privacy 40 (gộp nhóm lớn trước)", got, 25, 10, 10)
+
+	if _, err := sel.Select(utxos, 100); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("thiếu tiền: lỗi %v", err)
+	}
+}
+
+func TestConsolidationSpendsSmallestConfirmed(t *testing.T) {
+	utxos := []*proto.SpendableUTXO{
+		coin("a", 0, 50, false), coin("b", 0, 2, false), coin("c", 0, 1, false), coin("d", 0, 4, true), coin("e", 0, 3, false),
+	}
+	sel := ConsolidationSelector{MaxInputs: 3}
+
+	got, err := sel.Select(utxos, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectAmounts(t, "consolidate 5", got, 1, 2, 3)
+
+	got, err = sel.Select(utxos, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectAmounts(t, "consolidate 20", got, 1, 2, 3, 50)
+
+	if _, err := sel.Select(utxos, 100); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("thiếu tiền: lỗi %v", err)
+	}
+}
+
+func TestNewCoinSelector(t *testing.T) {
+	for name, want := range map[string]string{
+		"":            "largest-first",
+		"largest":     "largest-first",
+		"BnB":         "branch-and-bound",
+		"privacy":     "privacy",
+		"consolidate": "consolidation",
+	} {
+		sel, err := NewCoinSelector(name, 0)
+		if err != nil || sel.Name() != want {
+			t.Errorf("NewCoinSelector(%q) = %v, %v; muốn %s", name, sel, err, want)
+		}
+	}
+	if _, err := NewCoinSelector("random", 0); err == nil {
+		t.Fatal("chiến lược lạ phải lỗi")
+	}
+}
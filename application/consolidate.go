@@ -0,0 +1,146 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/network"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+const listUnspentPageSize = 200
+
+func ListUnspentUseCase(address string, includeUnconfirmed bool, targetNodeAddr string) ([]*proto.UnspentOutput, int64, error) {
+	if !domain.ValidateAddress(address) {
+		return nil, 0, fmt.Errorf("%w: %s", domain.ErrInvalidAddress, address)
+	}
+
+	conn, client, err := connectNode(targetNodeAddr)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer conn.Close()
+
+	utxos, res, err := listAllUnspent(client, address, includeUnconfirmed)
+	if err != nil {
+		return nil, 0, err
+	}
+	return utxos, res.DustThreshold, nil
+}
+
+func listAllUnspent(client proto.NodeServiceClient, address string, includeUnconfirmed bool) ([]*proto.UnspentOutput, *proto.ListUnspentResponse, error) {
+	var utxos []*proto.UnspentOutput
+	req := &proto.ListUnspentRequest{Address: address, Limit: listUnspentPageSize, IncludeUnconfirmed: includeUnconfirmed}
+	for {
+		res, err := client.ListUnspent(context.Background(), req)
+		if err != nil {
+			return nil, nil, fmt.Errorf("gọi gRPC ListUnspent thất bại: %w", err)
+		}
+		utxos = append(utxos, res.Utxos...)
+		if res.NextOffset == 0 {
+			return utxos, res, nil
+		}
+		req.Offset = res.NextOffset
+	}
+}
+
+func ConsolidateUseCase(fromAddress string, maxValue int64, outputCount int, maxSize int, fee int64, wallet *domain.Wallet, targetNodeAddr string) ([]byte, error) {
+	pubKeyHash, err := domain.DecodeAddress(fromAddress)
+	if err != nil {
+		return nil, err
+	}
+	if outputCount < 1 {
+		return nil, errors.New("cần ít nhất một output")
+	}
+
+	conn, client, err := connectNode(targetNodeAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	all, res, err := listAllUnspent(client, fromAddress, false)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []*proto.SpendableUTXO
+	for _, utxo := range all {
+		if !utxo.Spendable || (maxValue > 0 && utxo.Amount > maxValue) {
+			continue
+		}
+		candidates = append(candidates, &proto.SpendableUTXO{
+			TxId:       utxo.TxId,
+			VoutIndex:  utxo.VoutIndex,
+			Amount:     utxo.Amount,
+			PubKeyHash: pubKeyHash,
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Amount < candidates[j].Amount
+	})
+	if len(candidates) <= outputCount {
+		return nil, fmt.Errorf("chỉ có %d UTXO phù hợp, không cần gộp thành %d output", len(candidates), outputCount)
+	}
+
+	n := len(candidates)
+	for {
+		tx, err := newConsolidationTx(candidates[:n], outputCount, fee, res.DustThreshold, wallet)
+		if err != nil {
+			return nil, err
+		}
+
+		size := len(tx.Serialize())
+		if size <= maxSize {
+			log.Printf("Gộp %d UTXO (%d) thành %d output, kích thước %d byte", n, sumUTXOs(candidates[:n]), len(tx.Vout), size)
+			if err := network.SendTransactionToNode(targetNodeAddr, tx); err != nil {
+				return nil, err
+			}
+			fmt.Printf("Đã gửi giao dịch gộp UTXO: %x\n", tx.ID)
+			return tx.ID, nil
+		}
+
+		next := n * maxSize / size
+		if next >= n {
+			next = n - 1
+		}
+		if next <= outputCount {
+			return nil, fmt.Errorf("giới hạn kích thước %d byte quá nhỏ để gộp UTXO", maxSize)
+		}
+		n = next
+	}
+}
+
+func newConsolidationTx(utxos []*proto.SpendableUTXO, outputCount int, fee int64, dustThreshold int64, wallet *domain.Wallet) (*domain.Transaction, error) {
+	total := sumUTXOs(utxos) - fee
+	for outputCount > 1 && total/int64(outputCount) < dustThreshold {
+		outputCount--
+	}
+	if total < dustThreshold || total <= 0 {
+		return nil, fmt.Errorf("tổng %d sau khi trừ phí %d nhỏ hơn ngưỡng dust %d", total+fee, fee, dustThreshold)
+	}
+
+	pubKeyHash := domain.HashPubKey(wallet.PublicKey)
+	inputs, fakePrevTxs := inputsFromUTXOs(utxos, wallet.PublicKey)
+
+	outputs := make([]domain.TxOutput, outputCount)
+	share := total / int64(outputCount)
+	for i := range outputs {
+		outputs[i] = domain.TxOutput{Value: share, PubKeyHash: pubKeyHash}
+	}
+	outputs[0].Value += total - share*int64(outputCount)
+
+	tx := &domain.Transaction{
+		Vin:  inputs,
+		Vout: outputs,
+		Type: domain.TxTypeTransfer,
+	}
+	tx.SetID()
+	if err := tx.Sign(wallet.PrivateKey, fakePrevTxs); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
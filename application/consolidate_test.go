@@ -0,0 +1,56 @@
+package application
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+func TestNewConsolidationTx(t *testing.T) {
+	w, err := domain.NewWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKeyHash := domain.HashPubKey(w.PublicKey)
+	var utxos []*proto.SpendableUTXO
+	for i, amount := range []int64{3, 4, 5, 6, 7} {
+		utxos = append(utxos, &proto.SpendableUTXO{TxId: []byte{byte(i + 1)}, Amount: amount, PubKeyHash: pubKeyHash})
+	}
+
+	tx, err := newConsolidationTx(utxos, 3, 2, 1, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tx.Vin) != 5 || len(tx.Vout) != 3 {
+		t.Fatalf("gộp 5 UTXO thành 3 output, nhận %d input, %d output", len(tx.Vin), len(tx.Vout))
+	}
+	var total int64
+	for _, out := range tx.Vout {
+		total += out.Value
+		if !bytes.Equal(out.PubKeyHash, pubKeyHash) {
+			t.Fatal("output gộp phải trả về chính ví")
+		}
+	}
+	if total != 23 {
+		t.Fatalf("tổng output %d, muốn 25 - phí 2 = 23", total)
+	}
+	_, prevTxs := inputsFromUTXOs(utxos, w.PublicKey)
+	if err := tx.Verify(prevTxs, domain.VerifyContext{}); err != nil {
+		t.Fatalf("giao dịch gộp phải được ký hợp lệ: %v", err)
+	}
+
+	// Ngưỡng dust 10 làm giảm số output để không tạo output dust.
+	tx, err = newConsolidationTx(utxos, 3, 2, 10, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tx.Vout) != 2 || len(tx.DustOutputs(10)) != 0 {
+		t.Fatalf("với ngưỡng dust 10 phải còn 2 output không dust, nhận %d", len(tx.Vout))
+	}
+
+	if _, err := newConsolidationTx(utxos[:2], 1, 2, 10, w); err == nil {
+		t.Fatal("tổng sau phí dưới ngưỡng dust phải lỗi")
+	}
+}
@@ -0,0 +1,212 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/network"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+func NewHTLCPreimage() ([]byte, error) {
+	preimage := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, preimage); err != nil {
+		return nil, fmt.Errorf("không thể tạo preimage: %w", err)
+	}
+	return preimage, nil
+}
+
+func CreateHTLCUseCase(fromAddress, recipientAddress string, amount int64, hash []byte, timeout int64, wallet *domain.Wallet, targetNodeAddr string) ([]byte, error) {
+	if !domain.ValidateAddress(fromAddress) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidAddress, fromAddress)
+	}
+	recipientPubKeyHash, err := domain.DecodeAddress(recipientAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(hash) != 32 {
+		return nil, errors.New("hash của HTLC phải là SHA-256 (32 byte)")
+	}
+
+	conn, client, err := connectNode(targetNodeAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := client.FindSpendableUTXOs(context.Background(), &proto.FindSpendableUTXOsRequest{
+		Address: fromAddress,
+		Amount:  amount,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi tìm UTXO: %w", err)
+	}
+
+	pubKeyHash := domain.HashPubKey(wallet.PublicKey)
+	inputs, fakePrevTxs := inputsFromUTXOs(res.Utxos, wallet.PublicKey)
+
+	htlcScript := domain.NewHTLCScript(domain.HTLCParams{
+		Hash:                hash,
+		RecipientPubKeyHash: recipientPubKeyHash,
+		RefundPubKeyHash:    pubKeyHash,
+		Timeout:             timeout,
+	})
+
+	outputs := []domain.TxOutput{{Value: amount, Script: htlcScript}}
+	if res.AccumulatedAmount > amount {
+		outputs = append(outputs, domain.TxOutput{Value: res.AccumulatedAmount - amount, PubKeyHash: pubKeyHash})
+	}
+
+	tx := domain.Transaction{
+		ID:      nil,
+		Vin:     inputs,
+		Vout:    outputs,
+		Type:    domain.TxTypeTransfer,
+		Payload: nil,
+	}
+	tx.SetID()
+	if err := tx.Sign(wallet.PrivateKey, fakePrevTxs); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Đã tạo và ký TX HTLC: %x", tx.ID)
+
+	if err := network.SendTransactionToNode(targetNodeAddr, &tx); err != nil {
+		return nil, err
+	}
+	fmt.Println("Gửi TX HTLC thành công (đã vào Mempool)!")
+
+	return tx.ID, nil
+}
+
+func ClaimHTLCUseCase(htlcTxID []byte, voutIndex int, preimage []byte, wallet *domain.Wallet, targetNodeAddr string) error {
+	htlcTx, params, err := fetchHTLC(htlcTxID, voutIndex, targetNodeAddr)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(domain.HashPreimage(preimage), params.Hash) {
+		return errors.New("preimage không khớp với hash của HTLC")
+	}
+
+	pubKeyHash := domain.HashPubKey(wallet.PublicKey)
+	if !bytes.Equal(pubKeyHash, params.RecipientPubKeyHash) {
+		return errors.New("ví này không phải người nhận của HTLC")
+	}
+
+	tx := newHTLCSpendTx(htlcTx, voutIndex, pubKeyHash, 0)
+	sig, err := tx.SignatureFor(0, wallet.PrivateKey, map[string]domain.Transaction{string(htlcTx.ID): *htlcTx}, domain.SigHashAll)
+	if err != nil {
+		return err
+	}
+	tx.Vin[0].Witness = domain.NewHTLCClaimWitness(sig, wallet.PublicKey, preimage)
+
+	log.Printf("Đã tạo và ký TX Claim HTLC: %x", tx.ID)
+
+	if err := network.SendTransactionToNode(targetNodeAddr, tx); err != nil {
+		return err
+	}
+	fmt.Println("Gửi TX Claim HTLC thành công (đã vào Mempool)!")
+	return nil
+}
+
+func RefundHTLCUseCase(htlcTxID []byte, voutIndex int, wallet *domain.Wallet, targetNodeAddr string) error {
+	htlcTx, params, err := fetchHTLC(htlcTxID, voutIndex, targetNodeAddr)
+	if err != nil {
+		return err
+	}
+
+	pubKeyHash := domain.HashPubKey(wallet.PublicKey)
+	if !bytes.Equal(pubKeyHash, params.RefundPubKeyHash) {
+		return errors.New("ví này không phải người gửi (refund) của HTLC")
+	}
+
+	tx := newHTLCSpendTx(htlcTx, voutIndex, pubKeyHash, params.Timeout)
+	sig, err := tx.SignatureFor(0, wallet.PrivateKey, map[string]domain.Transaction{string(htlcTx.ID): *htlcTx}, domain.SigHashAll)
+	if err != nil {
+		return err
+	}
+	tx.Vin[0].Witness = domain.NewHTLCRefundWitness(sig, wallet.PublicKey)
+
+	log.Printf("Đã tạo và ký TX Refund HTLC: %x", tx.ID)
+
+	if err := network.SendTransactionToNode(targetNodeAddr, tx); err != nil {
+		return err
+	}
+	fmt.Println("Gửi TX Refund HTLC thành công (đã vào Mempool)!")
+	return nil
+}
+
+func ExtractHTLCPreimageUseCase(htlcTxID []byte, voutIndex int, claimTxID []byte, targetNodeAddr string) ([]byte, error) {
+	_, params, err := fetchHTLC(htlcTxID, voutIndex, targetNodeAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, client, err := connectNode(targetNodeAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := client.GetTransaction(context.Background(), &proto.GetTransactionRequest{TxId: claimTxID})
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy TX claim: %w", err)
+	}
+
+	claimTx := network.MapProtoTransactionToDomain(res.Transaction)
+	preimage, err := domain.ExtractHTLCPreimage(claimTx, htlcTxID, voutIndex, params)
+	if err != nil {
+		return nil, fmt.Errorf("không lấy được preimage: %w", err)
+	}
+	return preimage, nil
+}
+
+func fetchHTLC(htlcTxID []byte, voutIndex int, targetNodeAddr string) (*domain.Transaction, *domain.HTLCParams, error) {
+	conn, client, err := connectNode(targetNodeAddr)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer conn.Close()
+
+	res, err := client.GetTransaction(context.Background(), &proto.GetTransactionRequest{TxId: htlcTxID})
+	if err != nil {
+		return nil, nil, fmt.Errorf("lỗi khi lấy TX HTLC: %w", err)
+	}
+	if res.Pending {
+		return nil, nil, errors.New("TX HTLC chưa được xác nhận trong block")
+	}
+
+	htlcTx := network.MapProtoTransactionToDomain(res.Transaction)
+	if voutIndex < 0 || voutIndex >= len(htlcTx.Vout) {
+		return nil, nil, fmt.Errorf("output %d không tồn tại trong TX %x", voutIndex, htlcTxID)
+	}
+
+	params, err := domain.ParseHTLCScript(htlcTx.Vout[voutIndex].Script)
+	if err != nil {
+		return nil, nil, fmt.Errorf("output %x:%d không phải HTLC: %w", htlcTxID, voutIndex, err)
+	}
+	return htlcTx, params, nil
+}
+
+func newHTLCSpendTx(htlcTx *domain.Transaction, voutIndex int, toPubKeyHash []byte, lockTime int64) *domain.Transaction {
+	tx := &domain.Transaction{
+		ID: nil,
+		Vin: []domain.TxInput{{
+			TxID:      htlcTx.ID,
+			VoutIndex: voutIndex,
+		}},
+		Vout:     []domain.TxOutput{{Value: htlcTx.Vout[voutIndex].Value, PubKeyHash: toPubKeyHash}},
+		Type:     domain.TxTypeTransfer,
+		Payload:  nil,
+		LockTime: lockTime,
+	}
+	tx.SetID()
+	return tx
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+)
+
+type Payment struct {
+	Address string
+	Amount  int64
+}
+
+func ParsePayment(spec string) (Payment, error) {
+	idx := strings.LastIndex(spec, ":")
+	if idx <= 0 {
+		return Payment{}, fmt.Errorf("người nhận %q phải có dạng địa_chỉ:số_tiền", spec)
+	}
+	return newPayment(spec[:idx], spec[idx+1:])
+}
+
+func LoadPaymentsFile(path string) ([]Payment, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var payments []Payment
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("%s dòng %d: cần 2 cột địa_chỉ,số_tiền", path, line)
+		}
+		if line == 1 && !domain.ValidateAddress(strings.TrimSpace(record[0])) {
+			if _, err := strconv.ParseInt(strings.TrimSpace(record[1]), 10, 64); err != nil {
+				continue
+			}
+		}
+
+		payment, err := newPayment(record[0], record[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s dòng %d: %v", path, line, err)
+		}
+		payments = append(payments, payment)
+	}
+
+	if len(payments) == 0 {
+		return nil, fmt.Errorf("%s không có người nhận nào", path)
+	}
+	return payments, nil
+}
+
+func newPayment(address, amount string) (Payment, error) {
+	address = strings.TrimSpace(address)
+	if !domain.ValidateAddress(address) {
+		return Payment{}, fmt.Errorf("địa chỉ không hợp lệ: %q", address)
+	}
+	value, err := strconv.ParseInt(strings.TrimSpace(amount), 10, 64)
+	if err != nil || value <= 0 {
+		return Payment{}, fmt.Errorf("số tiền không hợp lệ cho %s: %q", address, amount)
+	}
+	return Payment{Address: address, Amount: value}, nil
+}
+
+func totalPayments(payments []Payment) (int64, error) {
+	var total int64
+	for _, payment := range payments {
+		if total > total+payment.Amount {
+			return 0, fmt.Errorf("tổng số tiền bị tràn số")
+		}
+		total += payment.Amount
+	}
+	return total, nil
+}
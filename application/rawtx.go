@@ -0,0 +1,209 @@
+package application
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/network"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+type rawTxInputView struct {
+	TxID      string   `json:"txId"`
+	VoutIndex int      `json:"voutIndex"`
+	Signature string   `json:"signature,omitempty"`
+	PublicKey string   `json:"publicKey,omitempty"`
+	Witness   []string `json:"witness,omitempty"`
+	Sequence  uint32   `json:"sequence"`
+}
+
+type rawTxOutputView struct {
+	Value      int64  `json:"value"`
+	PubKeyHash string `json:"pubKeyHash,omitempty"`
+	Script     string `json:"script,omitempty"`
+}
+
+type rawTxView struct {
+	ID       string            `json:"id"`
+	Type     domain.TxType     `json:"type"`
+	Vin      []rawTxInputView  `json:"vin"`
+	Vout     []rawTxOutputView `json:"vout"`
+	Payload  string            `json:"payload,omitempty"`
+	LockTime int64             `json:"lockTime"`
+}
+
+func DecodeRawTransactionUseCase(rawHex string) (string, error) {
+	tx, err := domain.DecodeRawTransaction(rawHex)
+	if err != nil {
+		return "", err
+	}
+
+	view := rawTxView{
+		ID:       hex.EncodeToString(tx.ID),
+		Type:     tx.Type,
+		Payload:  hex.EncodeToString(tx.Payload),
+		LockTime: tx.LockTime,
+	}
+	for _, vin := range tx.Vin {
+		in := rawTxInputView{
+			TxID:      hex.EncodeToString(vin.TxID),
+			VoutIndex: vin.VoutIndex,
+			Signature: hex.EncodeToString(vin.Signature),
+			PublicKey: hex.EncodeToString(vin.PublicKey),
+			Sequence:  vin.Sequence,
+		}
+		for _, item := range vin.Witness {
+			in.Witness = append(in.Witness, hex.EncodeToString(item))
+		}
+		view.Vin = append(view.Vin, in)
+	}
+	for _, out := range tx.Vout {
+		view.Vout = append(view.Vout, rawTxOutputView{
+			Value:      out.Value,
+			PubKeyHash: hex.EncodeToString(out.PubKeyHash),
+			Script:     hex.EncodeToString(out.Script),
+		})
+	}
+
+	data, err := json.MarshalIndent(view, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("không thể hiển thị giao dịch: %w", err)
+	}
+	return string(data), nil
+}
+
+func GetRawTransactionUseCase(txID []byte, targetNodeAddr string) (string, error) {
+	conn, client, err := connectNode(targetNodeAddr)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	res, err := client.GetTransaction(context.Background(), &proto.GetTransactionRequest{TxId: txID})
+	if err != nil {
+		return "", fmt.Errorf("lỗi khi lấy giao dịch: %w", err)
+	}
+	return res.RawHex, nil
+}
+
+func SendRawTransactionUseCase(rawHex string, targetNodeAddr string) error {
+	conn, client, err := connectNode(targetNodeAddr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ack, err := client.SendRawTransaction(context.Background(), &proto.RawTransaction{Hex: rawHex})
+	if err != nil {
+		return fmt.Errorf("gọi gRPC SendRawTransaction thất bại: %w", err)
+	}
+	if !ack.Success {
+		return fmt.Errorf("node từ chối giao dịch: %s", ack.Message)
+	}
+	fmt.Println("Gửi raw TX thành công (đã vào Mempool)!")
+	return nil
+}
+
+func CreateRawTransactionUseCase(toAddress string, amount int64) (string, error) {
+	pubKeyHash, err := domain.DecodeAddress(toAddress)
+	if err != nil {
+		return "", err
+	}
+
+	tx := domain.Transaction{
+		Vout: []domain.TxOutput{{Value: amount, PubKeyHash: pubKeyHash}},
+		Type: domain.TxTypeTransfer,
+	}
+	tx.SetID()
+	return tx.RawHex(), nil
+}
+
+func FundRawTransactionUseCase(rawHex string, fromAddress string, amount int64, hashType domain.SigHashType, withChange bool, wallet *domain.Wallet, targetNodeAddr string) (string, error) {
+	if !domain.ValidateAddress(fromAddress) {
+		return "", fmt.Errorf("%w: %s", domain.ErrInvalidAddress, fromAddress)
+	}
+
+	tx, err := domain.DecodeRawTransaction(rawHex)
+	if err != nil {
+		return "", err
+	}
+
+	conn, client, err := connectNode(targetNodeAddr)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	res, err := client.FindSpendableUTXOs(context.Background(), &proto.FindSpendableUTXOsRequest{
+		Address: fromAddress,
+		Amount:  amount,
+	})
+	if err != nil {
+		return "", fmt.Errorf("lỗi khi tìm UTXO: %w", err)
+	}
+
+	inputs, fakePrevTxs := inputsFromUTXOs(res.Utxos, wallet.PublicKey)
+	tx.Vin = append(tx.Vin, inputs...)
+	if withChange && res.AccumulatedAmount > amount {
+		tx.Vout = append(tx.Vout, domain.TxOutput{Value: res.AccumulatedAmount - amount, PubKeyHash: domain.HashPubKey(wallet.PublicKey)})
+	} else if res.AccumulatedAmount > amount {
+		log.Printf("CẢNH BÁO: %d coin thừa sẽ trở thành phí giao dịch", res.AccumulatedAmount-amount)
+	}
+	tx.SetID()
+
+	signed, err := tx.SignOwnedInputs(wallet.PrivateKey, fakePrevTxs, hashType)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Đã thêm %d input và ký với sighash %s. TX mới: %x", signed, hashType, tx.ID)
+
+	return tx.RawHex(), nil
+}
+
+func SignRawTransactionUseCase(rawHex string, hashType domain.SigHashType, wallet *domain.Wallet, targetNodeAddr string) (string, error) {
+	tx, err := domain.DecodeRawTransaction(rawHex)
+	if err != nil {
+		return "", err
+	}
+
+	conn, client, err := connectNode(targetNodeAddr)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	prevTxs, err := fetchPrevTxs(client, tx)
+	if err != nil {
+		return "", err
+	}
+	signed, err := tx.SignOwnedInputs(wallet.PrivateKey, prevTxs, hashType)
+	if err != nil {
+		return "", err
+	}
+	if signed == 0 {
+		return "", errors.New("ví này không sở hữu input nào trong giao dịch")
+	}
+	log.Printf("Đã ký %d input với sighash %s", signed, hashType)
+
+	return tx.RawHex(), nil
+}
+
+func fetchPrevTxs(client proto.NodeServiceClient, tx *domain.Transaction) (map[string]domain.Transaction, error) {
+	prevTxs := make(map[string]domain.Transaction)
+	for _, vin := range tx.Vin {
+		if _, ok := prevTxs[string(vin.TxID)]; ok {
+			continue
+		}
+		res, err := client.GetTransaction(context.Background(), &proto.GetTransactionRequest{TxId: vin.TxID})
+		if err != nil {
+			return nil, fmt.Errorf("lỗi khi lấy TX %x: %w", vin.TxID, err)
+		}
+		prevTxs[string(vin.TxID)] = *network.MapProtoTransactionToDomain(res.Transaction)
+	}
+	return prevTxs, nil
+}
@@ -11,103 +11,114 @@ import (
 	"github.com/khoahotran/gochain-ledger/proto"
 	"github.com/khoahotran/gochain-ledger/vm"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/khoahotran/gochain-ledger/domain"
 	"github.com/khoahotran/gochain-ledger/wallet"
 )
 
-func CreateWalletUseCase(password string) string {
+func CreateWalletUseCase(password string, keyType domain.KeyType) (string, error) {
 
-	w := domain.NewWallet()
+	w, err := domain.NewWalletWithKeyType(keyType)
+	if err != nil {
+		return "", fmt.Errorf("không thể tạo ví: %w", err)
+	}
 	address := w.GetAddress()
 
-	encryptedKey, salt, err := wallet.EncryptKey(&w.PrivateKey, password)
+	encryptedKey, salt, err := wallet.EncryptKey(w.PrivateKey, password)
 	if err != nil {
-		log.Panicf("Không thể mã hóa key: %v", err)
+		return "", fmt.Errorf("không thể mã hóa key: %w", err)
 	}
 
 	wf := &wallet.WalletFile{
 		Address:      address,
+		KeyType:      keyType.String(),
 		PublicKey:    w.PublicKey,
 		EncryptedKey: encryptedKey,
 		Salt:         salt,
 	}
 
 	if err := wf.Save(); err != nil {
-		log.Panicf("Không thể lưu file ví: %v", err)
+		return "", fmt.Errorf("không thể lưu file ví: %w", err)
 	}
 
 	fmt.Printf("Tạo ví mới thành công!\n")
 	fmt.Printf("Đã lưu vào: wallets/%s.json\n", address)
 	fmt.Printf("Address: %s\n", address)
+	fmt.Printf("Loại khóa: %s\n", keyType)
 
-	return address
+	return address, nil
 }
 
-func InitChainUseCase(address string) {
-	if !domain.ValidateAddress(address) {
-		log.Panic("LỖI: Địa chỉ ví không hợp lệ")
+func InitChainUseCase(store domain.ChainStore, address string, emission domain.EmissionSchedule) error {
+	if address != "" && !domain.ValidateAddress(address) {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidAddress, address)
+	}
+	if err := emission.Validate(); err != nil {
+		return fmt.Errorf("lịch phát hành không hợp lệ: %w", err)
+	}
+	bc, err := domain.InitBlockchain(store, address, emission)
+	if err != nil {
+		return err
 	}
-	bc := domain.InitBlockchain(address)
 	defer bc.Close()
 	fmt.Println("Khởi tạo blockchain thành công!")
+	return nil
 }
 
-func SendUseCase(fromAddress, toAddress string, amount int64, wallet *domain.Wallet, targetNodeAddr string) {
-	if !domain.ValidateAddress(fromAddress) || !domain.ValidateAddress(toAddress) {
-		log.Panic("LỖI: Địa chỉ ví không hợp lệ")
+func SendUseCase(fromAddress string, payments []Payment, fee int64, lockTime int64, includeUnconfirmed bool, selector CoinSelector, wallet *domain.Wallet, targetNodeAddr string) error {
+	if !domain.ValidateAddress(fromAddress) {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidAddress, fromAddress)
+	}
+	if len(payments) == 0 {
+		return errors.New("cần ít nhất một người nhận")
+	}
+	amount, err := totalPayments(payments)
+	if err != nil {
+		return err
 	}
 
-	conn, err := grpc.Dial(targetNodeAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, client, err := connectNode(targetNodeAddr)
 	if err != nil {
-		log.Fatalf("Không thể kết nối node: %v", err)
+		return err
 	}
 	defer conn.Close()
-	client := proto.NewNodeServiceClient(conn)
 
 	req := &proto.FindSpendableUTXOsRequest{
-		Address: fromAddress,
-		Amount:  amount,
+		Address:            fromAddress,
+		Amount:             amount + fee,
+		IncludeUnconfirmed: includeUnconfirmed,
+		ListAll:            true,
 	}
 	res, err := client.FindSpendableUTXOs(context.Background(), req)
 	if err != nil {
+		return fmt.Errorf("lỗi khi tìm UTXO: %w", err)
+	}
 
-		log.Panicf("Lỗi khi tìm UTXO: %v", err)
+	selected, err := selector.Select(res.Utxos, amount+fee)
+	if err != nil {
+		return fmt.Errorf("%w (gồm phí %d)", err, fee)
 	}
+	accumulated := sumUTXOs(selected)
+	log.Printf("Chọn %d/%d UTXO (%d) theo chiến lược %s", len(selected), len(res.Utxos), accumulated, selector.Name())
 
-	var inputs []domain.TxInput
 	var outputs []domain.TxOutput
 
-	fakePrevTxs := make(map[string]domain.Transaction)
-
 	pubKeyHash := domain.HashPubKey(wallet.PublicKey)
+	inputs, fakePrevTxs := inputsFromUTXOs(selected, wallet.PublicKey)
 
-	for _, utxo := range res.Utxos {
-		input := domain.TxInput{
-			TxID:      utxo.TxId,
-			VoutIndex: int(utxo.VoutIndex),
-			Signature: nil,
-			PublicKey: wallet.PublicKey,
-		}
-		inputs = append(inputs, input)
-
-		txIDStr := string(utxo.TxId)
-		if _, ok := fakePrevTxs[txIDStr]; !ok {
-
-			voutSlice := make([]domain.TxOutput, utxo.VoutIndex+1)
-			voutSlice[utxo.VoutIndex] = domain.TxOutput{
-				Value:      utxo.Amount,
-				PubKeyHash: utxo.PubKeyHash,
-			}
-			fakePrevTxs[txIDStr] = domain.Transaction{ID: utxo.TxId, Vout: voutSlice}
+	for _, payment := range payments {
+		toPubKeyHash, err := domain.DecodeAddress(payment.Address)
+		if err != nil {
+			return err
 		}
+		outputs = append(outputs, domain.TxOutput{Value: payment.Amount, PubKeyHash: toPubKeyHash})
 	}
-
-	outputs = append(outputs, domain.TxOutput{Value: amount, PubKeyHash: domain.DecodeAddress(toAddress)})
-	if res.AccumulatedAmount > amount {
-
-		outputs = append(outputs, domain.TxOutput{Value: res.AccumulatedAmount - amount, PubKeyHash: pubKeyHash})
+	if change := accumulated - amount - fee; change > 0 {
+		if bnb, ok := selector.(BranchAndBoundSelector); ok && change <= bnb.Tolerance {
+			log.Printf("Không tạo output tiền thừa: %d được cộng vào phí", change)
+		} else {
+			outputs = append(outputs, domain.TxOutput{Value: change, PubKeyHash: pubKeyHash})
+		}
 	}
 
 	tx := domain.Transaction{
@@ -115,25 +126,38 @@ func SendUseCase(fromAddress, toAddress string, amount int64, wallet *domain.Wal
 		Vin:  inputs,
 		Vout: outputs,
 
-		Type:    domain.TxTypeTransfer,
-		Payload: nil,
+		Type:     domain.TxTypeTransfer,
+		Payload:  nil,
+		LockTime: lockTime,
 	}
 	tx.SetID()
-	tx.Sign(wallet.PrivateKey, fakePrevTxs)
+	if err := tx.Sign(wallet.PrivateKey, fakePrevTxs); err != nil {
+		return err
+	}
 
-	log.Printf("Đã tạo và ký giao dịch: %x", tx.ID)
+	log.Printf("Đã tạo và ký giao dịch: %x (%d người nhận, tổng %d)", tx.ID, len(payments), amount)
 
-	network.SendTransactionToNode(targetNodeAddr, &tx)
+	if err := network.SendTransactionToNode(targetNodeAddr, &tx); err != nil {
+		return err
+	}
 
 	fmt.Println("Gửi giao dịch thành công (đã vào Mempool)!")
+	return nil
 }
 
 func NewUTXOTransaction(wallet *domain.Wallet, toAddress string, amount int64, u *domain.UTXOSet) (*domain.Transaction, error) {
 	pubKeyHash := domain.HashPubKey(wallet.PublicKey)
 
-	acc, spendableOutputs := u.FindSpendableOutputs(pubKeyHash, amount)
+	acc, spendableOutputs, err := u.FindSpendableOutputs(pubKeyHash, amount, u.Blockchain.GetBestHeight()+1)
+	if err != nil {
+		return nil, err
+	}
 	if acc < amount {
-		return nil, errors.New("hông đủ tiền")
+		return nil, fmt.Errorf("%w: có %d, cần %d", domain.ErrInsufficientFunds, acc, amount)
+	}
+	toPubKeyHash, err := domain.DecodeAddress(toAddress)
+	if err != nil {
+		return nil, err
 	}
 
 	var inputs []domain.TxInput
@@ -157,7 +181,7 @@ func NewUTXOTransaction(wallet *domain.Wallet, toAddress string, amount int64, u
 		}
 	}
 
-	outputs = append(outputs, domain.TxOutput{Value: amount, PubKeyHash: domain.DecodeAddress(toAddress)})
+	outputs = append(outputs, domain.TxOutput{Value: amount, PubKeyHash: toPubKeyHash})
 	if acc > amount {
 
 		outputs = append(outputs, domain.TxOutput{Value: acc - amount, PubKeyHash: pubKeyHash})
@@ -166,23 +190,27 @@ func NewUTXOTransaction(wallet *domain.Wallet, toAddress string, amount int64, u
 	tx := domain.Transaction{ID: nil, Vin: inputs, Vout: outputs}
 	tx.SetID()
 
-	prevTxs := u.Blockchain.FindReferencedTxs(&tx)
-	tx.Sign(wallet.PrivateKey, prevTxs)
+	prevTxs, err := u.Blockchain.FindReferencedTxs(&tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Sign(wallet.PrivateKey, prevTxs); err != nil {
+		return nil, err
+	}
 
 	return &tx, nil
 }
 
-func DeployContractUseCase(fromAddress string, code []byte, wallet *domain.Wallet, targetNodeAddr string) {
+func DeployContractUseCase(fromAddress string, code []byte, wallet *domain.Wallet, targetNodeAddr string) error {
 	if !domain.ValidateAddress(fromAddress) {
-		log.Panic("LỖI: Địa chỉ ví không hợp lệ")
+		return fmt.Errorf("%w: %s", domain.ErrInvalidAddress, fromAddress)
 	}
 
-	conn, err := grpc.Dial(targetNodeAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, client, err := connectNode(targetNodeAddr)
 	if err != nil {
-		log.Fatalf("Không thể kết nối node: %v", err)
+		return err
 	}
 	defer conn.Close()
-	client := proto.NewNodeServiceClient(conn)
 
 	req := &proto.FindSpendableUTXOsRequest{
 		Address: fromAddress,
@@ -190,23 +218,11 @@ func DeployContractUseCase(fromAddress string, code []byte, wallet *domain.Walle
 	}
 	res, err := client.FindSpendableUTXOs(context.Background(), req)
 	if err != nil {
-		log.Panicf("Lỗi khi tìm UTXO: %v", err)
+		return fmt.Errorf("lỗi khi tìm UTXO: %w", err)
 	}
 
-	var inputs []domain.TxInput
-	fakePrevTxs := make(map[string]domain.Transaction)
 	pubKeyHash := domain.HashPubKey(wallet.PublicKey)
-	for _, utxo := range res.Utxos {
-		inputs = append(inputs, domain.TxInput{
-			TxID: utxo.TxId, VoutIndex: int(utxo.VoutIndex), Signature: nil, PublicKey: wallet.PublicKey,
-		})
-		txIDStr := string(utxo.TxId)
-		if _, ok := fakePrevTxs[txIDStr]; !ok {
-			voutSlice := make([]domain.TxOutput, utxo.VoutIndex+1)
-			voutSlice[utxo.VoutIndex] = domain.TxOutput{Value: utxo.Amount, PubKeyHash: utxo.PubKeyHash}
-			fakePrevTxs[txIDStr] = domain.Transaction{ID: utxo.TxId, Vout: voutSlice}
-		}
-	}
+	inputs, fakePrevTxs := inputsFromUTXOs(res.Utxos, wallet.PublicKey)
 
 	var outputs []domain.TxOutput
 	outputs = append(outputs, domain.TxOutput{Value: res.AccumulatedAmount, PubKeyHash: pubKeyHash})
@@ -219,52 +235,44 @@ func DeployContractUseCase(fromAddress string, code []byte, wallet *domain.Walle
 		Payload: code,
 	}
 	tx.SetID()
-	tx.Sign(wallet.PrivateKey, fakePrevTxs)
+	if err := tx.Sign(wallet.PrivateKey, fakePrevTxs); err != nil {
+		return err
+	}
 
 	log.Printf("Đã tạo và ký TX Deploy: %x", tx.ID)
 	log.Printf("Địa chỉ Contract sẽ là: %x", tx.ID)
 
-	network.SendTransactionToNode(targetNodeAddr, &tx)
+	if err := network.SendTransactionToNode(targetNodeAddr, &tx); err != nil {
+		return err
+	}
 	fmt.Println("Gửi TX Deploy thành công (đã vào Mempool)!")
+	return nil
 }
 
-func CallContractUseCase(fromAddress string, contractAddress string, functionName string, args []interface{}, wallet *domain.Wallet, targetNodeAddr string) {
+func CallContractUseCase(fromAddress string, contractAddress string, functionName string, args []interface{}, wallet *domain.Wallet, targetNodeAddr string) error {
 	if !domain.ValidateAddress(fromAddress) {
-		log.Panic("LỖI: Địa chỉ ví không hợp lệ")
+		return fmt.Errorf("%w: %s", domain.ErrInvalidAddress, fromAddress)
 	}
 
 	callPayload, err := vm.NewCallPayload(contractAddress, functionName, args)
 	if err != nil {
-		log.Panicf("Lỗi tạo payload: %v", err)
+		return fmt.Errorf("lỗi tạo payload: %w", err)
 	}
 
-	conn, err := grpc.Dial(targetNodeAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, client, err := connectNode(targetNodeAddr)
 	if err != nil {
-		log.Fatalf("Không thể kết nối node: %v", err)
+		return err
 	}
 	defer conn.Close()
-	client := proto.NewNodeServiceClient(conn)
 
 	req := &proto.FindSpendableUTXOsRequest{Address: fromAddress, Amount: 1}
 	res, err := client.FindSpendableUTXOs(context.Background(), req)
 	if err != nil {
-		log.Panicf("Lỗi khi tìm UTXO: %v", err)
+		return fmt.Errorf("lỗi khi tìm UTXO: %w", err)
 	}
 
-	var inputs []domain.TxInput
-	fakePrevTxs := make(map[string]domain.Transaction)
 	pubKeyHash := domain.HashPubKey(wallet.PublicKey)
-	for _, utxo := range res.Utxos {
-		inputs = append(inputs, domain.TxInput{
-			TxID: utxo.TxId, VoutIndex: int(utxo.VoutIndex), Signature: nil, PublicKey: wallet.PublicKey,
-		})
-		txIDStr := string(utxo.TxId)
-		if _, ok := fakePrevTxs[txIDStr]; !ok {
-			voutSlice := make([]domain.TxOutput, utxo.VoutIndex+1)
-			voutSlice[utxo.VoutIndex] = domain.TxOutput{Value: utxo.Amount, PubKeyHash: utxo.PubKeyHash}
-			fakePrevTxs[txIDStr] = domain.Transaction{ID: utxo.TxId, Vout: voutSlice}
-		}
-	}
+	inputs, fakePrevTxs := inputsFromUTXOs(res.Utxos, wallet.PublicKey)
 
 	var outputs []domain.TxOutput
 	outputs = append(outputs, domain.TxOutput{Value: res.AccumulatedAmount, PubKeyHash: pubKeyHash})
@@ -277,10 +285,49 @@ func CallContractUseCase(fromAddress string, contractAddress string, functionNam
 		Payload: callPayload,
 	}
 	tx.SetID()
-	tx.Sign(wallet.PrivateKey, fakePrevTxs)
+	if err := tx.Sign(wallet.PrivateKey, fakePrevTxs); err != nil {
+		return err
+	}
 
 	log.Printf("Đã tạo và ký TX Call: %x", tx.ID)
 
-	network.SendTransactionToNode(targetNodeAddr, &tx)
+	if err := network.SendTransactionToNode(targetNodeAddr, &tx); err != nil {
+		return err
+	}
 	fmt.Println("Gửi TX Call thành công (đã vào Mempool)!")
+	return nil
+}
+
+func inputsFromUTXOs(utxos []*proto.SpendableUTXO, publicKey []byte) ([]domain.TxInput, map[string]domain.Transaction) {
+	var inputs []domain.TxInput
+	fakePrevTxs := make(map[string]domain.Transaction)
+
+	for _, utxo := range utxos {
+		inputs = append(inputs, domain.TxInput{
+			TxID:      utxo.TxId,
+			VoutIndex: int(utxo.VoutIndex),
+			Signature: nil,
+			PublicKey: publicKey,
+		})
+
+		txIDStr := string(utxo.TxId)
+		prevTx, ok := fakePrevTxs[txIDStr]
+		if !ok {
+			prevTx = domain.Transaction{ID: utxo.TxId}
+		}
+		for len(prevTx.Vout) <= int(utxo.VoutIndex) {
+			prevTx.Vout = append(prevTx.Vout, domain.TxOutput{})
+		}
+		prevTx.Vout[utxo.VoutIndex] = domain.TxOutput{Value: utxo.Amount, PubKeyHash: utxo.PubKeyHash}
+		fakePrevTxs[txIDStr] = prevTx
+	}
+	return inputs, fakePrevTxs
+}
+
+func connectNode(targetNodeAddr string) (*grpc.ClientConn, proto.NodeServiceClient, error) {
+	conn, err := network.Dial(targetNodeAddr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("không thể kết nối node: %w", err)
+	}
+	return conn, proto.NewNodeServiceClient(conn), nil
 }
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/network"
+)
+
+const maxReportedProblems = 20
+
+func VerifyChainUseCase(store domain.ChainStore, full bool, repair bool) error {
+	bc, err := domain.ContinueBlockchainReadOnly(store)
+	if err != nil {
+		store.Close()
+		return err
+	}
+	defer bc.Close()
+
+	mode := "nhanh"
+	if full {
+		mode = "đầy đủ"
+	}
+	log.Printf("Đang kiểm tra chain đến chiều cao %d (chế độ %s)...", bc.GetBestHeight(), mode)
+
+	report, err := bc.Verify(domain.VerifyOptions{Full: full, Contracts: network.ApplyContract})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Đã kiểm tra %d block, %d giao dịch (%d giao dịch contract được chạy lại).\n", report.Blocks, report.Transactions, report.ContractTxs)
+	printProblems("Block/giao dịch không hợp lệ", report.Invalid)
+	printProblems("Dữ liệu dẫn xuất không khớp", report.Mismatches)
+	if report.OK() {
+		fmt.Println("Chain hợp lệ, UTXO Set, state contract và chỉ mục khớp với các block.")
+		return nil
+	}
+
+	if !repair {
+		return fmt.Errorf("phát hiện %d lỗi block/giao dịch và %d sai lệch dữ liệu dẫn xuất (chạy lại với --repair để dựng lại dữ liệu dẫn xuất)", len(report.Invalid), len(report.Mismatches))
+	}
+	if len(report.Invalid) > 0 {
+		return errors.New("chain chứa block không hợp lệ; --repair chỉ dựng lại dữ liệu dẫn xuất từ block nên không thể sửa lỗi này")
+	}
+
+	log.Println("Đang dựng lại UTXO Set, state contract và chỉ mục từ các block...")
+	if err := bc.Repair(report); err != nil {
+		return fmt.Errorf("sửa chữa thất bại: %w", err)
+	}
+	fmt.Printf("Đã sửa %d sai lệch.\n", len(report.Mismatches))
+	return nil
+}
+
+func printProblems(title string, problems []string) {
+	if len(problems) == 0 {
+		return
+	}
+	fmt.Printf("%s (%d):\n", title, len(problems))
+	for i, problem := range problems {
+		if i == maxReportedProblems {
+			fmt.Printf("  ... và %d mục khác\n", len(problems)-i)
+			break
+		}
+		fmt.Printf("  - %s\n", problem)
+	}
+}
@@ -6,10 +6,9 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/khoahotran/gochain-ledger/network"
 	"github.com/khoahotran/gochain-ledger/proto"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 var getBalanceCmd = &cobra.Command{
@@ -23,7 +22,7 @@ var getBalanceCmd = &cobra.Command{
 			Handle(errors.New("Cần cung cấp flag --address và --node"))
 		}
 
-		conn, err := grpc.Dial(nodeAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		conn, err := network.Dial(nodeAddr)
 		if err != nil {
 			log.Fatalf("Không thể kết nối: %v", err)
 		}
@@ -39,6 +38,9 @@ var getBalanceCmd = &cobra.Command{
 		}
 
 		fmt.Printf("Số dư của '%s': %d\n", address, res.Balance)
+		fmt.Printf(" - Đã xác nhận: %d\n", res.Confirmed)
+		fmt.Printf(" - Chờ xác nhận: %d\n", res.Unconfirmed)
+		fmt.Printf(" - Đang khóa (coinbase chưa đủ maturity): %d\n", res.Locked)
 	},
 }
 
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/khoahotran/gochain-ledger/application"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Xuất các block trong CSDL cục bộ ra stdout theo định dạng bootstrap (node phải đang dừng)",
+	Run: func(cmd *cobra.Command, args []string) {
+		from, _ := cmd.Flags().GetInt64("from")
+		to, _ := cmd.Flags().GetInt64("to")
+
+		if term.IsTerminal(int(os.Stdout.Fd())) {
+			Handle(errors.New("stdout là terminal; chuyển hướng sang file, ví dụ: export > chain.dat"))
+		}
+		Handle(application.ExportChainUseCase(openChainStore(), from, to, os.Stdout))
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Nhập và kiểm tra từng block từ file bootstrap vào CSDL cục bộ (node phải đang dừng)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		txIndex, _ := cmd.Flags().GetBool("txindex")
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		Handle(application.ImportChainUseCase(ctx, openChainStore(), args[0], txIndex))
+	},
+}
+
+func init() {
+	exportCmd.Flags().Int64("from", 0, "Chiều cao block đầu tiên")
+	exportCmd.Flags().Int64("to", -1, "Chiều cao block cuối cùng (mặc định: tip)")
+	importCmd.Flags().Bool("txindex", false, "Dựng chỉ mục giao dịch và địa chỉ trong lúc nhập (để node chạy với --txindex không phải dựng lại)")
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+}
@@ -48,7 +48,7 @@ var callCmd = &cobra.Command{
 			Handle(err)
 		}
 
-		application.CallContractUseCase(from, contractAddr, funcName, parsedArgs, loadedWallet, nodeAddr)
+		Handle(application.CallContractUseCase(from, contractAddr, funcName, parsedArgs, loadedWallet, nodeAddr))
 	},
 }
 
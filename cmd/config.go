@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/khoahotran/gochain-ledger/config"
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/storage"
+	"github.com/khoahotran/gochain-ledger/wallet"
+	"github.com/spf13/cobra"
+)
+
+var nodeConfig = config.Default()
+
+func loadConfig(cmd *cobra.Command) error {
+	configFile, _ := cmd.Flags().GetString("config")
+	dataDir, _ := cmd.Flags().GetString("datadir")
+	networkName, _ := cmd.Flags().GetString("network")
+
+	cfg, err := config.Load(configFile, dataDir, networkName)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range config.Settings {
+		if s.Flag == "" {
+			continue
+		}
+		flag := cmd.Flags().Lookup(s.Flag)
+		if flag == nil {
+			continue
+		}
+		if flag.Changed {
+			if err := s.Set(cfg, flag.Value.String()); err != nil {
+				return fmt.Errorf("flag --%s: %v", s.Flag, err)
+			}
+			continue
+		}
+		if err := cmd.Flags().Set(s.Flag, s.Get(cfg)); err != nil {
+			return fmt.Errorf("cấu hình %s: %v", s.Key, err)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	domain.SelectParams(cfg.Params())
+	wallet.SetWalletDir(cfg.WalletDir())
+	nodeConfig = cfg
+	return nil
+}
+
+func openChainStore() domain.ChainStore {
+	store, err := storage.OpenBadger(nodeConfig.DBPath(), false)
+	if err != nil {
+		Handle(fmt.Errorf("không thể mở CSDL %s: %v", nodeConfig.DBPath(), err))
+	}
+	return store
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Xem cấu hình node đang có hiệu lực",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "In cấu hình sau khi gộp file, biến môi trường và flag",
+	Run: func(cmd *cobra.Command, args []string) {
+		if nodeConfig.File != "" {
+			fmt.Printf("# file cấu hình: %s\n", nodeConfig.File)
+		}
+		fmt.Printf("# CSDL: %s\n# ví: %s\n", nodeConfig.DBPath(), nodeConfig.WalletDir())
+		fmt.Print(nodeConfig.String())
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -43,7 +43,7 @@ var deployCmd = &cobra.Command{
 			Handle(err)
 		}
 
-		application.DeployContractUseCase(from, code, loadedWallet, nodeAddr)
+		Handle(application.DeployContractUseCase(from, code, loadedWallet, nodeAddr))
 	},
 }
 
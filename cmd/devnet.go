@@ -0,0 +1,405 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/khoahotran/gochain-ledger/application"
+	"github.com/khoahotran/gochain-ledger/config"
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/network"
+	"github.com/khoahotran/gochain-ledger/proto"
+	"github.com/khoahotran/gochain-ledger/wallet"
+	"github.com/spf13/cobra"
+)
+
+const (
+	devnetReadyTimeout = 30 * time.Second
+	devnetStopTimeout  = 15 * time.Second
+)
+
+type devnetNode struct {
+	name     string
+	dir      string
+	port     int
+	grpcPort int
+	address  string
+	wallet   *domain.Wallet
+	miner    bool
+
+	cmd     *exec.Cmd
+	logFile *os.File
+	done    chan struct{}
+}
+
+func (n *devnetNode) grpcAddr() string {
+	return fmt.Sprintf("localhost:%d", n.grpcPort)
+}
+
+type devnetOutput struct {
+	mu sync.Mutex
+}
+
+func (o *devnetOutput) pipe(node *devnetNode, r io.Reader, wg *sync.WaitGroup) {
+	defer wg.Done()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		o.mu.Lock()
+		fmt.Fprintf(os.Stdout, "[%s] %s\n", node.name, line)
+		fmt.Fprintln(node.logFile, line)
+		o.mu.Unlock()
+	}
+}
+
+var devnetCmd = &cobra.Command{
+	Use:   "devnet",
+	Short: "Chạy một mạng regtest nhiều node trên máy local",
+}
+
+var devnetUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Tạo và khởi động N node regtest nối với nhau, cấp vốn từ genesis chung (Ctrl+C để dừng)",
+	Run: func(cmd *cobra.Command, args []string) {
+		nodes, _ := cmd.Flags().GetInt("nodes")
+		miners, _ := cmd.Flags().GetInt("miners")
+		dir, _ := cmd.Flags().GetString("dir")
+		basePort, _ := cmd.Flags().GetInt("base-port")
+		baseGRPCPort, _ := cmd.Flags().GetInt("base-grpc-port")
+		fund, _ := cmd.Flags().GetInt64("fund")
+		password, _ := cmd.Flags().GetString("password")
+		interval, _ := cmd.Flags().GetDuration("mining-interval")
+		keep, _ := cmd.Flags().GetBool("keep")
+
+		if nodes < 1 {
+			Handle(errors.New("--nodes phải lớn hơn 0"))
+		}
+		if miners < 0 || miners > nodes {
+			Handle(fmt.Errorf("--miners phải nằm trong khoảng 0-%d", nodes))
+		}
+		if fund < 0 {
+			Handle(errors.New("--fund không được âm"))
+		}
+		if _, err := os.Stat(dir); err == nil {
+			Handle(fmt.Errorf("thư mục %s đã tồn tại, hãy xoá hoặc dùng --dir khác", dir))
+		}
+		self, err := os.Executable()
+		Handle(err)
+		dir, err = filepath.Abs(dir)
+		Handle(err)
+
+		domain.SelectParams(&domain.RegTestParams)
+
+		devnet := make([]*devnetNode, nodes)
+		for i := range devnet {
+			node := &devnetNode{
+				name:     fmt.Sprintf("node%d", i),
+				port:     basePort + i,
+				grpcPort: baseGRPCPort + i,
+				miner:    i < miners,
+				done:     make(chan struct{}),
+			}
+			node.dir = filepath.Join(dir, node.name)
+			devnet[i] = node
+		}
+
+		stopped := false
+		teardown := func() {
+			if stopped {
+				return
+			}
+			stopped = true
+			stopDevnet(devnet)
+			if keep {
+				log.Printf("Devnet: Giữ lại dữ liệu tại %s", dir)
+				return
+			}
+			if err := os.RemoveAll(dir); err != nil {
+				log.Printf("Devnet: Không thể xoá %s: %v", dir, err)
+				return
+			}
+			log.Printf("Devnet: Đã xoá %s", dir)
+		}
+		fail := func(err error) {
+			teardown()
+			Handle(err)
+		}
+
+		for _, node := range devnet {
+			if err := setupDevnetNode(self, node, devnet, password, interval, devnet[0].address); err != nil {
+				fail(err)
+			}
+		}
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		output := &devnetOutput{}
+
+		faucet := devnet[0]
+		if err := startDevnetNode(self, faucet, output); err != nil {
+			fail(err)
+		}
+		if err := waitDevnetNode(faucet); err != nil {
+			fail(err)
+		}
+		if err := fundDevnet(devnet, fund); err != nil {
+			fail(err)
+		}
+		for _, node := range devnet[1:] {
+			if err := startDevnetNode(self, node, output); err != nil {
+				fail(err)
+			}
+			if err := waitDevnetNode(node); err != nil {
+				fail(err)
+			}
+		}
+		printDevnet(devnet, password)
+
+		exited := make(chan *devnetNode, len(devnet))
+		for _, node := range devnet {
+			go func(node *devnetNode) {
+				<-node.done
+				exited <- node
+			}(node)
+		}
+
+		select {
+		case sig := <-signals:
+			log.Printf("Devnet: Nhận tín hiệu %v, đang dừng các node...", sig)
+		case node := <-exited:
+			log.Printf("Devnet: %s đã dừng bất ngờ (xem %s), đang dừng các node còn lại...", node.name, node.logFile.Name())
+		}
+		teardown()
+	},
+}
+
+func setupDevnetNode(self string, node *devnetNode, devnet []*devnetNode, password string, interval time.Duration, genesisAddress string) error {
+	walletDir := filepath.Join(node.dir, "wallets")
+	wallet.SetWalletDir(walletDir)
+	w, err := domain.NewWalletWithKeyType(domain.KeyTypeP256)
+	if err != nil {
+		return err
+	}
+	encryptedKey, salt, err := wallet.EncryptKey(w.PrivateKey, password)
+	if err != nil {
+		return err
+	}
+	node.address = w.GetAddress()
+	node.wallet = w
+	wf := &wallet.WalletFile{
+		Address:      node.address,
+		KeyType:      domain.KeyTypeP256.String(),
+		PublicKey:    w.PublicKey,
+		EncryptedKey: encryptedKey,
+		Salt:         salt,
+	}
+	if err := wf.Save(); err != nil {
+		return fmt.Errorf("%s: không thể lưu ví: %v", node.name, err)
+	}
+	if genesisAddress == "" {
+		genesisAddress = node.address
+	}
+
+	var peers []string
+	for _, peer := range devnet {
+		if peer != node {
+			peers = append(peers, peer.grpcAddr())
+		}
+	}
+
+	cfg := config.Default()
+	cfg.DataDir = node.dir
+	cfg.Storage.DBPath = "blocks"
+	cfg.Storage.WalletDir = "wallets"
+	cfg.Network.Port = fmt.Sprint(node.port)
+	cfg.Network.GRPCPort = fmt.Sprint(node.grpcPort)
+	cfg.Network.Node = node.grpcAddr()
+	cfg.Network.Peers = strings.Join(peers, ",")
+	cfg.Mempool.Backend = config.MempoolBackendMemory
+	cfg.Miner.Interval = interval
+	cfg.Chain.Network = domain.RegTestParams.Name
+	if node.miner {
+		cfg.Miner.Address = node.address
+	}
+	if err := cfg.WriteFile(filepath.Join(node.dir, config.FileName)); err != nil {
+		return fmt.Errorf("%s: %v", node.name, err)
+	}
+
+	out, err := exec.Command(self, "init", "--datadir", node.dir, "--address", genesisAddress).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: init thất bại: %v\n%s", node.name, err, out)
+	}
+	return nil
+}
+
+func startDevnetNode(self string, node *devnetNode, output *devnetOutput) error {
+	logFile, err := os.Create(filepath.Join(node.dir, "node.log"))
+	if err != nil {
+		return err
+	}
+	node.logFile = logFile
+
+	node.cmd = exec.Command(self, "start", "--datadir", node.dir)
+	node.cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	stdout, err := node.cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := node.cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := node.cmd.Start(); err != nil {
+		return fmt.Errorf("%s: không thể khởi động: %v", node.name, err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go output.pipe(node, stdout, &wg)
+	go output.pipe(node, stderr, &wg)
+	go func() {
+		wg.Wait()
+		node.cmd.Wait()
+		close(node.done)
+	}()
+	return nil
+}
+
+func waitDevnetNode(node *devnetNode) error {
+	conn, err := network.Dial(node.grpcAddr())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := proto.NewNodeServiceClient(conn)
+
+	deadline := time.Now().Add(devnetReadyTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case <-node.done:
+			return fmt.Errorf("%s đã dừng khi khởi động (xem %s)", node.name, node.logFile.Name())
+		case <-time.After(200 * time.Millisecond):
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := client.GetSupply(ctx, &proto.EmptyRequest{})
+		cancel()
+		if err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s không sẵn sàng sau %s", node.name, devnetReadyTimeout)
+}
+
+func fundDevnet(devnet []*devnetNode, fund int64) error {
+	faucet := devnet[0]
+	conn, err := network.Dial(faucet.grpcAddr())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := proto.NewNodeServiceClient(conn)
+	ctx := context.Background()
+
+	var payments []application.Payment
+	for _, node := range devnet[1:] {
+		if fund > 0 {
+			payments = append(payments, application.Payment{Address: node.address, Amount: fund})
+		}
+	}
+	need := fund * int64(len(payments))
+
+	for {
+		balance, err := client.GetBalance(ctx, &proto.GetBalanceRequest{Address: faucet.address})
+		if err != nil {
+			return err
+		}
+		if balance.Confirmed >= need {
+			break
+		}
+		if _, err := client.Generate(ctx, &proto.GenerateRequest{Count: 1, Address: faucet.address}); err != nil {
+			return fmt.Errorf("không thể đào block cấp vốn: %v", err)
+		}
+	}
+	if len(payments) == 0 {
+		return nil
+	}
+
+	log.Printf("Devnet: Cấp %d cho mỗi ví từ %s", fund, faucet.address)
+	if err := application.SendUseCase(faucet.address, payments, 0, 0, false, application.LargestFirstSelector{}, faucet.wallet, faucet.grpcAddr()); err != nil {
+		return err
+	}
+
+	_, err = client.Generate(ctx, &proto.GenerateRequest{Count: 1, Address: faucet.address})
+	return err
+}
+
+func printDevnet(devnet []*devnetNode, password string) {
+	fmt.Println()
+	fmt.Println("=== Devnet regtest đã sẵn sàng ===")
+	for _, node := range devnet {
+		role := ""
+		if node.miner {
+			role = " (miner)"
+		}
+		fmt.Printf("%s%s: gRPC %s, HTTP :%d, datadir %s\n  ví %s\n", node.name, role, node.grpcAddr(), node.port, node.dir, node.address)
+	}
+	fmt.Printf("Mật khẩu ví: %s\n", password)
+	fmt.Printf("Ví dụ: gochain-ledger balance --datadir %s --address %s\n", devnet[0].dir, devnet[0].address)
+	fmt.Println("Nhấn Ctrl+C để dừng devnet.")
+	fmt.Println()
+}
+
+func stopDevnet(devnet []*devnetNode) {
+	for _, node := range devnet {
+		if node.cmd == nil || node.cmd.Process == nil {
+			continue
+		}
+		select {
+		case <-node.done:
+			continue
+		default:
+		}
+		node.cmd.Process.Signal(os.Interrupt)
+	}
+	for _, node := range devnet {
+		if node.cmd == nil || node.cmd.Process == nil {
+			continue
+		}
+		select {
+		case <-node.done:
+		case <-time.After(devnetStopTimeout):
+			log.Printf("Devnet: %s không dừng sau %s, buộc dừng", node.name, devnetStopTimeout)
+			node.cmd.Process.Kill()
+			<-node.done
+		}
+		node.logFile.Close()
+		log.Printf("Devnet: %s đã dừng", node.name)
+	}
+}
+
+func init() {
+	devnetUpCmd.Flags().Int("nodes", 4, "Số node trong devnet")
+	devnetUpCmd.Flags().Int("miners", 1, "Số node chạy miner (node0, node1, ...)")
+	devnetUpCmd.Flags().String("dir", "devnet", "Thư mục chứa dữ liệu các node")
+	devnetUpCmd.Flags().Int("base-port", 3200, "Cổng HTTP (gRPC-Web/REST) của node0, các node sau tăng dần")
+	devnetUpCmd.Flags().Int("base-grpc-port", 50251, "Cổng gRPC của node0, các node sau tăng dần")
+	devnetUpCmd.Flags().Int64("fund", 50, "Số coin cấp cho ví của mỗi node từ ví genesis của node0")
+	devnetUpCmd.Flags().String("password", "devnet", "Mật khẩu cho các ví được tạo")
+	devnetUpCmd.Flags().Duration("mining-interval", 2*time.Second, "Chu kỳ đào của các miner")
+	devnetUpCmd.Flags().Bool("keep", false, "Giữ lại thư mục dữ liệu khi dừng")
+	devnetCmd.AddCommand(devnetUpCmd)
+	rootCmd.AddCommand(devnetCmd)
+}
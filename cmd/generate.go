@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/khoahotran/gochain-ledger/network"
+	"github.com/khoahotran/gochain-ledger/proto"
+	"github.com/spf13/cobra"
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate [số block]",
+	Short: "Đào ngay N block từ mempool hiện tại (chỉ trên regtest)",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		to, _ := cmd.Flags().GetString("to")
+		nodeAddr, _ := cmd.Flags().GetString("node")
+
+		count := 1
+		if len(args) == 1 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n <= 0 {
+				Handle(fmt.Errorf("số block không hợp lệ: %s", args[0]))
+			}
+			count = n
+		}
+		if to == "" {
+			Handle(errors.New("Cần cung cấp flag --to (địa chỉ nhận phần thưởng)"))
+		}
+
+		conn, err := network.Dial(nodeAddr)
+		if err != nil {
+			log.Fatalf("Không thể kết nối: %v", err)
+		}
+		defer conn.Close()
+
+		client := proto.NewNodeServiceClient(conn)
+
+		res, err := client.Generate(context.Background(), &proto.GenerateRequest{
+			Count:   int32(count),
+			Address: to,
+		})
+		if err != nil {
+			log.Fatalf("Gọi gRPC Generate thất bại: %v", err)
+		}
+
+		for _, hash := range res.BlockHashes {
+			fmt.Printf("%x\n", hash)
+		}
+		fmt.Printf("Đã đào %d block, chiều cao hiện tại: %d\n", len(res.BlockHashes), res.Height)
+	},
+}
+
+func init() {
+	generateCmd.Flags().String("to", "", "Địa chỉ ví nhận phần thưởng coinbase")
+	generateCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+	rootCmd.AddCommand(generateCmd)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/khoahotran/gochain-ledger/network"
+	"github.com/khoahotran/gochain-ledger/proto"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Xem lịch sử giao dịch của một địa chỉ (node cần chạy với --txindex)",
+	Run: func(cmd *cobra.Command, args []string) {
+		address, _ := cmd.Flags().GetString("address")
+		offset, _ := cmd.Flags().GetInt32("offset")
+		limit, _ := cmd.Flags().GetInt32("limit")
+		nodeAddr, _ := cmd.Flags().GetString("node")
+
+		if address == "" || nodeAddr == "" {
+			Handle(errors.New("Cần cung cấp flag --address và --node"))
+		}
+
+		conn, err := network.Dial(nodeAddr)
+		if err != nil {
+			log.Fatalf("Không thể kết nối: %v", err)
+		}
+		defer conn.Close()
+
+		client := proto.NewNodeServiceClient(conn)
+
+		res, err := client.GetAddressHistory(context.Background(), &proto.GetAddressHistoryRequest{
+			Address: address,
+			Offset:  offset,
+			Limit:   limit,
+		})
+		if err != nil {
+			log.Fatalf("Gọi gRPC GetAddressHistory thất bại: %v", err)
+		}
+
+		for _, entry := range res.Entries {
+			fmt.Printf("%x  block %d  %-8s  %+d  (%d xác nhận)\n", entry.TxId, entry.BlockHeight, entry.Direction, entry.Amount, entry.Confirmations)
+		}
+		fmt.Printf("Hiển thị %d/%d giao dịch\n", len(res.Entries), res.Total)
+		if res.NextOffset > 0 {
+			fmt.Printf("Trang tiếp theo: --offset %d\n", res.NextOffset)
+		}
+	},
+}
+
+func init() {
+	historyCmd.Flags().String("address", "", "Địa chỉ ví cần xem")
+	historyCmd.Flags().Int32("offset", 0, "Bỏ qua N giao dịch mới nhất")
+	historyCmd.Flags().Int32("limit", 20, "Số giao dịch tối đa mỗi trang")
+	historyCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+	rootCmd.AddCommand(historyCmd)
+}
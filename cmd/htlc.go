@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/khoahotran/gochain-ledger/application"
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/spf13/cobra"
+)
+
+var htlcCmd = &cobra.Command{
+	Use:   "htlc",
+	Short: "Hash Time-Locked Contract (HTLC) cho atomic swap giữa các mạng",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var htlcCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Khóa tiền vào một HTLC (người nhận claim bằng preimage, người gửi refund sau timeout)",
+	Run: func(cmd *cobra.Command, args []string) {
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		amount, _ := cmd.Flags().GetInt64("amount")
+		hashHex, _ := cmd.Flags().GetString("hash")
+		timeout, _ := cmd.Flags().GetInt64("timeout")
+		nodeAddr, _ := cmd.Flags().GetString("node")
+
+		if from == "" || to == "" || amount <= 0 || timeout <= 0 || nodeAddr == "" {
+			Handle(errors.New("Flag --from, --to, --amount, --timeout, --node là bắt buộc"))
+		}
+
+		var preimage []byte
+		var hash []byte
+		var err error
+		if hashHex == "" {
+			preimage, err = application.NewHTLCPreimage()
+			Handle(err)
+			hash = domain.HashPreimage(preimage)
+		} else {
+			hash, err = hex.DecodeString(hashHex)
+			if err != nil {
+				Handle(fmt.Errorf("--hash không phải hex hợp lệ: %v", err))
+			}
+		}
+
+		loadedWallet := promptWallet(from)
+
+		txID, err := application.CreateHTLCUseCase(from, to, amount, hash, timeout, loadedWallet, nodeAddr)
+		Handle(err)
+
+		fmt.Printf("HTLC TX: %x (output 0)\n", txID)
+		fmt.Printf("Hash: %x\n", hash)
+		if preimage != nil {
+			fmt.Printf("Preimage (GIỮ BÍ MẬT đến khi swap): %x\n", preimage)
+		}
+	},
+}
+
+var htlcClaimCmd = &cobra.Command{
+	Use:   "claim",
+	Short: "Nhận tiền từ HTLC bằng cách tiết lộ preimage",
+	Run: func(cmd *cobra.Command, args []string) {
+		from, _ := cmd.Flags().GetString("from")
+		txHex, _ := cmd.Flags().GetString("tx")
+		vout, _ := cmd.Flags().GetInt("vout")
+		preimageHex, _ := cmd.Flags().GetString("preimage")
+		nodeAddr, _ := cmd.Flags().GetString("node")
+
+		if from == "" || txHex == "" || preimageHex == "" || nodeAddr == "" {
+			Handle(errors.New("Flag --from, --tx, --preimage, --node là bắt buộc"))
+		}
+
+		htlcTxID, err := hex.DecodeString(txHex)
+		if err != nil {
+			Handle(fmt.Errorf("--tx không phải hex hợp lệ: %v", err))
+		}
+		preimage, err := hex.DecodeString(preimageHex)
+		if err != nil {
+			Handle(fmt.Errorf("--preimage không phải hex hợp lệ: %v", err))
+		}
+
+		loadedWallet := promptWallet(from)
+
+		Handle(application.ClaimHTLCUseCase(htlcTxID, vout, preimage, loadedWallet, nodeAddr))
+	},
+}
+
+var htlcRefundCmd = &cobra.Command{
+	Use:   "refund",
+	Short: "Lấy lại tiền từ HTLC sau khi hết timeout",
+	Run: func(cmd *cobra.Command, args []string) {
+		from, _ := cmd.Flags().GetString("from")
+		txHex, _ := cmd.Flags().GetString("tx")
+		vout, _ := cmd.Flags().GetInt("vout")
+		nodeAddr, _ := cmd.Flags().GetString("node")
+
+		if from == "" || txHex == "" || nodeAddr == "" {
+			Handle(errors.New("Flag --from, --tx, --node là bắt buộc"))
+		}
+
+		htlcTxID, err := hex.DecodeString(txHex)
+		if err != nil {
+			Handle(fmt.Errorf("--tx không phải hex hợp lệ: %v", err))
+		}
+
+		loadedWallet := promptWallet(from)
+
+		Handle(application.RefundHTLCUseCase(htlcTxID, vout, loadedWallet, nodeAddr))
+	},
+}
+
+var htlcPreimageCmd = &cobra.Command{
+	Use:   "preimage",
+	Short: "Lấy preimage đã được tiết lộ từ giao dịch claim HTLC",
+	Run: func(cmd *cobra.Command, args []string) {
+		txHex, _ := cmd.Flags().GetString("tx")
+		vout, _ := cmd.Flags().GetInt("vout")
+		claimHex, _ := cmd.Flags().GetString("claim")
+		nodeAddr, _ := cmd.Flags().GetString("node")
+
+		if txHex == "" || claimHex == "" || nodeAddr == "" {
+			Handle(errors.New("Flag --tx, --claim, --node là bắt buộc"))
+		}
+
+		htlcTxID, err := hex.DecodeString(txHex)
+		if err != nil {
+			Handle(fmt.Errorf("--tx không phải hex hợp lệ: %v", err))
+		}
+		claimTxID, err := hex.DecodeString(claimHex)
+		if err != nil {
+			Handle(fmt.Errorf("--claim không phải hex hợp lệ: %v", err))
+		}
+
+		preimage, err := application.ExtractHTLCPreimageUseCase(htlcTxID, vout, claimTxID, nodeAddr)
+		Handle(err)
+		fmt.Printf("Preimage: %x\n", preimage)
+	},
+}
+
+func init() {
+	htlcCreateCmd.Flags().String("from", "", "Địa chỉ ví gửi (được refund sau timeout)")
+	htlcCreateCmd.Flags().String("to", "", "Địa chỉ ví nhận (claim bằng preimage)")
+	htlcCreateCmd.Flags().Int64("amount", 0, "Số tiền khóa vào HTLC")
+	htlcCreateCmd.Flags().String("hash", "", "SHA-256 của preimage (hex). Bỏ trống để tự sinh preimage")
+	htlcCreateCmd.Flags().Int64("timeout", 0, "Block (< 500000000) hoặc thời điểm Unix mà người gửi được refund")
+	htlcCreateCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+
+	htlcClaimCmd.Flags().String("from", "", "Địa chỉ ví nhận của HTLC")
+	htlcClaimCmd.Flags().String("tx", "", "ID của TX tạo HTLC (hex)")
+	htlcClaimCmd.Flags().Int("vout", 0, "Vị trí output HTLC trong TX")
+	htlcClaimCmd.Flags().String("preimage", "", "Preimage (hex)")
+	htlcClaimCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+
+	htlcRefundCmd.Flags().String("from", "", "Địa chỉ ví gửi của HTLC")
+	htlcRefundCmd.Flags().String("tx", "", "ID của TX tạo HTLC (hex)")
+	htlcRefundCmd.Flags().Int("vout", 0, "Vị trí output HTLC trong TX")
+	htlcRefundCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+
+	htlcPreimageCmd.Flags().String("tx", "", "ID của TX tạo HTLC (hex)")
+	htlcPreimageCmd.Flags().Int("vout", 0, "Vị trí output HTLC trong TX")
+	htlcPreimageCmd.Flags().String("claim", "", "ID của TX claim HTLC (hex)")
+	htlcPreimageCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+
+	htlcCmd.AddCommand(htlcCreateCmd, htlcClaimCmd, htlcRefundCmd, htlcPreimageCmd)
+	rootCmd.AddCommand(htlcCmd)
+}
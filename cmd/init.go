@@ -1,25 +1,36 @@
 package cmd
 
 import (
-	"errors"
+	"fmt"
 
 	"github.com/khoahotran/gochain-ledger/application"
+	"github.com/khoahotran/gochain-ledger/domain"
 	"github.com/spf13/cobra"
 )
 
 var initChainCmd = &cobra.Command{
 	Use:   "init",
-	Short: "Khởi tạo blockchain mới và đào block Genesis",
+	Short: "Khởi tạo blockchain mới từ block Genesis của mạng",
 	Run: func(cmd *cobra.Command, args []string) {
 		address, _ := cmd.Flags().GetString("address")
-		if address == "" {
-			Handle(errors.New("Cần cung cấp địa chỉ ví nhận thưởng (flag --address)"))
+		if address != "" && !domain.ActiveParams().AllowCustomGenesis {
+			Handle(fmt.Errorf("mạng %s dùng block genesis cố định, không nhận --address (chỉ regtest cho phép)", domain.ActiveParams().Name))
 		}
-		application.InitChainUseCase(address)
+		emission := domain.DefaultEmissionSchedule
+		emission.InitialReward, _ = cmd.Flags().GetInt64("reward")
+		emission.HalvingInterval, _ = cmd.Flags().GetInt64("halving")
+		emission.MaxSupply, _ = cmd.Flags().GetInt64("max-supply")
+		emission.CoinbaseMaturity, _ = cmd.Flags().GetInt64("maturity")
+
+		Handle(application.InitChainUseCase(openChainStore(), address, emission))
 	},
 }
 
 func init() {
-	initChainCmd.Flags().String("address", "", "Địa chỉ ví nhận thưởng")
+	initChainCmd.Flags().String("address", "", "Địa chỉ nhận thưởng block genesis (chỉ regtest; mặc định genesis của mạng)")
+	initChainCmd.Flags().Int64("reward", domain.DefaultEmissionSchedule.InitialReward, "Phần thưởng ban đầu cho mỗi block")
+	initChainCmd.Flags().Int64("halving", domain.DefaultEmissionSchedule.HalvingInterval, "Số block giữa hai lần halving")
+	initChainCmd.Flags().Int64("max-supply", domain.DefaultEmissionSchedule.MaxSupply, "Tổng cung tối đa")
+	initChainCmd.Flags().Int64("maturity", domain.DefaultEmissionSchedule.CoinbaseMaturity, "Số block trước khi coinbase được tiêu")
 	rootCmd.AddCommand(initChainCmd)
 }
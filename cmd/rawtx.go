@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/khoahotran/gochain-ledger/application"
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/spf13/cobra"
+)
+
+var rawTxCmd = &cobra.Command{
+	Use:   "rawtx",
+	Short: "Làm việc với giao dịch ở dạng raw hex (mã hóa nhị phân chuẩn)",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var rawTxDecodeCmd = &cobra.Command{
+	Use:   "decode [hex]",
+	Short: "Giải mã raw hex thành giao dịch (JSON)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		view, err := application.DecodeRawTransactionUseCase(args[0])
+		Handle(err)
+		fmt.Println(view)
+	},
+}
+
+var rawTxGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Lấy raw hex của một giao dịch (qua một node)",
+	Run: func(cmd *cobra.Command, args []string) {
+		txHex, _ := cmd.Flags().GetString("tx")
+		nodeAddr, _ := cmd.Flags().GetString("node")
+
+		txID, err := hex.DecodeString(txHex)
+		if err != nil || len(txID) == 0 {
+			Handle(errors.New("Flag --tx phải là ID giao dịch dạng hex"))
+		}
+
+		rawHex, err := application.GetRawTransactionUseCase(txID, nodeAddr)
+		Handle(err)
+		fmt.Println(rawHex)
+	},
+}
+
+var rawTxSendCmd = &cobra.Command{
+	Use:   "send [hex]",
+	Short: "Gửi một giao dịch đã ký ở dạng raw hex đến node",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		nodeAddr, _ := cmd.Flags().GetString("node")
+		Handle(application.SendRawTransactionUseCase(args[0], nodeAddr))
+	},
+}
+
+var rawTxCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Tạo giao dịch chưa có input (ví dụ: mục tiêu gây quỹ)",
+	Run: func(cmd *cobra.Command, args []string) {
+		to, _ := cmd.Flags().GetString("to")
+		amount, _ := cmd.Flags().GetInt64("amount")
+
+		if to == "" || amount <= 0 {
+			Handle(errors.New("Flag --to và --amount là bắt buộc"))
+		}
+
+		rawHex, err := application.CreateRawTransactionUseCase(to, amount)
+		Handle(err)
+		fmt.Println(rawHex)
+	},
+}
+
+var rawTxFundCmd = &cobra.Command{
+	Use:   "fund [hex]",
+	Short: "Thêm input từ ví vào giao dịch và ký chúng (mặc định ALL|ANYONECANPAY)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		from, _ := cmd.Flags().GetString("from")
+		amount, _ := cmd.Flags().GetInt64("amount")
+		sighash, _ := cmd.Flags().GetString("sighash")
+		change, _ := cmd.Flags().GetBool("change")
+		nodeAddr, _ := cmd.Flags().GetString("node")
+
+		if from == "" || amount <= 0 {
+			Handle(errors.New("Flag --from và --amount là bắt buộc"))
+		}
+		hashType, err := domain.ParseSigHashType(sighash)
+		Handle(err)
+
+		loadedWallet := promptWallet(from)
+		rawHex, err := application.FundRawTransactionUseCase(args[0], from, amount, hashType, change, loadedWallet, nodeAddr)
+		Handle(err)
+		fmt.Println(rawHex)
+	},
+}
+
+var rawTxSignCmd = &cobra.Command{
+	Use:   "sign [hex]",
+	Short: "Ký các input thuộc về ví trong một giao dịch raw",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		from, _ := cmd.Flags().GetString("from")
+		sighash, _ := cmd.Flags().GetString("sighash")
+		nodeAddr, _ := cmd.Flags().GetString("node")
+
+		if from == "" {
+			Handle(errors.New("Flag --from là bắt buộc"))
+		}
+		hashType, err := domain.ParseSigHashType(sighash)
+		Handle(err)
+
+		loadedWallet := promptWallet(from)
+		rawHex, err := application.SignRawTransactionUseCase(args[0], hashType, loadedWallet, nodeAddr)
+		Handle(err)
+		fmt.Println(rawHex)
+	},
+}
+
+func init() {
+	rawTxCreateCmd.Flags().String("to", "", "Địa chỉ ví nhận")
+	rawTxCreateCmd.Flags().Int64("amount", 0, "Số tiền")
+
+	rawTxFundCmd.Flags().String("from", "", "Địa chỉ ví góp tiền")
+	rawTxFundCmd.Flags().Int64("amount", 0, "Số tiền góp")
+	rawTxFundCmd.Flags().String("sighash", "ALL|ANYONECANPAY", "Kiểu sighash: ALL, NONE, SINGLE, kết hợp |ANYONECANPAY")
+	rawTxFundCmd.Flags().Bool("change", false, "Thêm output tiền thừa về ví (chỉ khi các chữ ký có sẵn cho phép thay đổi output)")
+	rawTxFundCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+
+	rawTxSignCmd.Flags().String("from", "", "Địa chỉ ví ký")
+	rawTxSignCmd.Flags().String("sighash", "ALL", "Kiểu sighash: ALL, NONE, SINGLE, kết hợp |ANYONECANPAY")
+	rawTxSignCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+
+	rawTxGetCmd.Flags().String("tx", "", "ID giao dịch (hex)")
+	rawTxGetCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+
+	rawTxSendCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+
+	rawTxCmd.AddCommand(rawTxDecodeCmd, rawTxGetCmd, rawTxSendCmd, rawTxCreateCmd, rawTxFundCmd, rawTxSignCmd)
+	rootCmd.AddCommand(rawTxCmd)
+}
@@ -6,10 +6,9 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/khoahotran/gochain-ledger/network"
 	"github.com/khoahotran/gochain-ledger/proto"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 var readCmd = &cobra.Command{
@@ -24,7 +23,7 @@ var readCmd = &cobra.Command{
 			Handle(errors.New("Flag --contract, --key, --node là bắt buộc"))
 		}
 
-		conn, err := grpc.Dial(nodeAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		conn, err := network.Dial(nodeAddr)
 		if err != nil {
 			log.Fatalf("Không thể kết nối: %v", err)
 		}
@@ -2,14 +2,22 @@ package cmd
 
 import (
 	"fmt"
+	"log"
 	"os"
+	"syscall"
 
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/wallet"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "gochain-ledger",
 	Short: "GoChain Ledger là một blockchain demo viết bằng Go",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		Handle(loadConfig(cmd))
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 
 		cmd.Help()
@@ -21,12 +29,29 @@ func Execute() error {
 }
 
 func init() {
-
+	rootCmd.PersistentFlags().String("datadir", "", "Thư mục dữ liệu của node (CSDL, ví, node.toml). Mặc định: thư mục hiện tại")
+	rootCmd.PersistentFlags().String("network", "", "Mạng blockchain: mainnet, testnet hoặc regtest (mặc định mainnet)")
+	rootCmd.PersistentFlags().String("config", "", "Đường dẫn file cấu hình TOML. Mặc định: <datadir>/node.toml nếu có")
 }
 
 func Handle(err error) {
 	if err != nil {
-		fmt.Printf("Lỗi: %s\n", err.Error())
+		fmt.Fprintf(os.Stderr, "Lỗi: %s\n", err.Error())
 		os.Exit(1)
 	}
 }
+
+func promptWallet(address string) *domain.Wallet {
+	fmt.Printf("Nhập mật khẩu cho ví '%s': ", address)
+	bytePassword, err := term.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		log.Fatalf("Lỗi khi nhập mật khẩu: %v", err)
+	}
+	fmt.Println()
+
+	loadedWallet, err := wallet.LoadAndDecrypt(address, string(bytePassword))
+	if err != nil {
+		Handle(err)
+	}
+	return loadedWallet
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"syscall"
 
 	"github.com/khoahotran/gochain-ledger/application"
@@ -17,12 +18,33 @@ var sendCmd = &cobra.Command{
 	Short: "Gửi tiền từ ví A đến ví B",
 	Run: func(cmd *cobra.Command, args []string) {
 		from, _ := cmd.Flags().GetString("from")
-		to, _ := cmd.Flags().GetString("to")
+		to, _ := cmd.Flags().GetStringArray("to")
+		toFile, _ := cmd.Flags().GetString("to-file")
 		amount, _ := cmd.Flags().GetInt64("amount")
+		fee, _ := cmd.Flags().GetInt64("fee")
+		lockTime, _ := cmd.Flags().GetInt64("locktime")
+		unconfirmed, _ := cmd.Flags().GetBool("unconfirmed")
+		strategy, _ := cmd.Flags().GetString("strategy")
+		tolerance, _ := cmd.Flags().GetInt64("tolerance")
 		nodeAddr, _ := cmd.Flags().GetString("node")
 
-		if from == "" || to == "" || amount <= 0 || nodeAddr == "" {
-			Handle(errors.New("Flag --from, --to, --amount, --key, --node là bắt buộc"))
+		if from == "" || (len(to) == 0 && toFile == "") || nodeAddr == "" {
+			Handle(errors.New("Flag --from, --to (hoặc --to-file), --node là bắt buộc"))
+		}
+		if fee < 0 {
+			Handle(errors.New("--fee không được âm"))
+		}
+		if lockTime < 0 {
+			Handle(errors.New("--locktime không được âm"))
+		}
+
+		payments, err := parsePayments(to, toFile, amount)
+		if err != nil {
+			Handle(err)
+		}
+		selector, err := application.NewCoinSelector(strategy, tolerance)
+		if err != nil {
+			Handle(err)
 		}
 
 		fmt.Printf("Nhập mật khẩu cho ví '%s': ", from)
@@ -39,14 +61,49 @@ var sendCmd = &cobra.Command{
 			Handle(err)
 		}
 
-		application.SendUseCase(from, to, amount, loadedWallet, nodeAddr)
+		Handle(application.SendUseCase(from, payments, fee, lockTime, unconfirmed, selector, loadedWallet, nodeAddr))
 	},
 }
 
+func parsePayments(to []string, toFile string, amount int64) ([]application.Payment, error) {
+	if len(to) == 1 && !strings.Contains(to[0], ":") {
+		if amount <= 0 || toFile != "" {
+			return nil, errors.New("--to không kèm số tiền cần --amount > 0 (hoặc dùng --to địa_chỉ:số_tiền)")
+		}
+		return []application.Payment{{Address: to[0], Amount: amount}}, nil
+	}
+	if amount != 0 {
+		return nil, errors.New("--amount chỉ dùng với một --to không kèm số tiền")
+	}
+
+	var payments []application.Payment
+	for _, spec := range to {
+		payment, err := application.ParsePayment(spec)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+	if toFile != "" {
+		filePayments, err := application.LoadPaymentsFile(toFile)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, filePayments...)
+	}
+	return payments, nil
+}
+
 func init() {
 	sendCmd.Flags().String("from", "", "Địa chỉ ví gửi (tên file wallet)")
-	sendCmd.Flags().String("to", "", "Địa chỉ ví nhận")
-	sendCmd.Flags().Int64("amount", 0, "Số tiền")
+	sendCmd.Flags().StringArray("to", nil, "Người nhận: địa_chỉ:số_tiền (lặp lại cho nhiều người nhận), hoặc địa chỉ kèm --amount")
+	sendCmd.Flags().String("to-file", "", "File CSV danh sách người nhận (địa_chỉ,số_tiền mỗi dòng)")
+	sendCmd.Flags().Int64("amount", 0, "Số tiền (khi chỉ có một --to)")
+	sendCmd.Flags().Int64("fee", 0, "Phí giao dịch trả cho miner")
+	sendCmd.Flags().String("strategy", "largest", "Chiến lược chọn UTXO: largest, bnb, privacy, consolidate")
+	sendCmd.Flags().Int64("tolerance", 0, "Với --strategy bnb: phần dư tối đa được cộng vào phí thay vì tạo output tiền thừa")
+	sendCmd.Flags().Int64("locktime", 0, "Giao dịch chỉ hợp lệ từ block này (< 500000000) hoặc từ thời điểm Unix này")
+	sendCmd.Flags().Bool("unconfirmed", false, "Cho phép dùng output chưa xác nhận (tiền thừa đang chờ trong Mempool)")
 	sendCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
 
 	rootCmd.AddCommand(sendCmd)
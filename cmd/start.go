@@ -2,22 +2,30 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"google.golang.org/grpc"
 
+	"github.com/khoahotran/gochain-ledger/config"
 	"github.com/khoahotran/gochain-ledger/domain"
 	"github.com/khoahotran/gochain-ledger/network"
 	"github.com/khoahotran/gochain-ledger/proto"
 	"github.com/spf13/cobra"
 )
 
+const shutdownTimeout = 10 * time.Second
+
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Khởi động node GoChain Ledger và bắt đầu lắng nghe",
@@ -25,55 +33,97 @@ var startCmd = &cobra.Command{
 		port, _ := cmd.Flags().GetString("port")
 		grpcPort, _ := cmd.Flags().GetString("grpcport")
 		minerAddress, _ := cmd.Flags().GetString("miner")
+		dustThreshold, _ := cmd.Flags().GetInt64("dust-threshold")
+		txIndex, _ := cmd.Flags().GetBool("txindex")
+		miningInterval, _ := cmd.Flags().GetDuration("mining-interval")
+		mempoolBackend, _ := cmd.Flags().GetString("mempool")
+		peers := network.NewPeerSet(network.ParsePeers(nodeConfig.Network.Peers))
 
 		if port == "" {
 			Handle(fmt.Errorf("cần cung cấp cổng (flag --port)"))
 		}
-		log.Printf("Khởi động node...\n - Cổng gRPC-Web (DApp): %s\n - Cổng gRPC (P2P/CLI): %s", port, grpcPort)
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
 
-		bc := domain.ContinueBlockchain()
+		log.Printf("Khởi động node...\n - Mạng: %s\n - Cổng gRPC-Web (DApp): %s\n - Cổng gRPC (P2P/CLI): %s\n - Thư mục dữ liệu: %s", domain.ActiveParams().Name, port, grpcPort, nodeConfig.DataDir)
 
-		rdb := redis.NewClient(&redis.Options{
-			Addr:     "localhost:6379",
-			Password: "",
-			DB:       0,
-		})
-		_, err := rdb.Ping(context.Background()).Result()
-		if err != nil {
-			log.Fatalf("Không thể kết nối đến Redis: %v", err)
+		bc, err := domain.ContinueBlockchain(openChainStore())
+		Handle(err)
+		bc.Events = domain.NewEventBus()
+		if txIndex {
+			Handle(bc.EnableIndex())
 		}
-		log.Println("Đã kết nối đến Redis (Mempool).")
 
+		var mempool *network.Mempool
+		switch mempoolBackend {
+		case config.MempoolBackendMemory:
+			log.Println("Mempool lưu trong bộ nhớ (mất khi node dừng).")
+			mempool = network.NewMemoryMempool()
+		case config.MempoolBackendRedis:
+			rdb := redis.NewClient(&redis.Options{
+				Addr:     nodeConfig.Mempool.RedisAddr,
+				Password: nodeConfig.Mempool.RedisPassword,
+				DB:       nodeConfig.Mempool.RedisDB,
+			})
+			_, err := rdb.Ping(context.Background()).Result()
+			if err != nil {
+				log.Fatalf("Không thể kết nối đến Redis: %v", err)
+			}
+			log.Printf("Đã kết nối đến Redis (Mempool) tại %s.", nodeConfig.Mempool.RedisAddr)
+			mempool = network.NewMempool(rdb)
+		default:
+			var err error
+			mempool, err = network.NewFileMempool(nodeConfig.MempoolFile())
+			if err != nil {
+				log.Fatalf("Không thể mở mempool: %v", err)
+			}
+		}
+		mempool.DustThreshold = dustThreshold
+		mempool.Events = bc.Events
+
+		minerDone := make(chan struct{})
 		if minerAddress != "" {
 			if !domain.ValidateAddress(minerAddress) {
-				log.Panic("LỖI: Địa chỉ ví miner không hợp lệ")
+				Handle(fmt.Errorf("%w: ví miner %s", domain.ErrInvalidAddress, minerAddress))
 			}
 			log.Printf("Node đang khởi động ở chế độ MINER. Phần thưởng sẽ về: %s", minerAddress)
 
-			go network.StartMiningLoop(bc, rdb, minerAddress)
+			go func() {
+				network.StartMiningLoop(ctx, bc, mempool, minerAddress, miningInterval)
+				close(minerDone)
+			}()
+		} else {
+			close(minerDone)
 		}
 
-		grpcServer := grpc.NewServer()
+		grpcServer := grpc.NewServer(network.ServerOptions()...)
 
-		nodeService := &network.Server{Blockchain: bc, RedisClient: rdb}
-		publicService := &network.PublicServer{Blockchain: bc, RedisClient: rdb}
+		nodeService := &network.Server{Blockchain: bc, Mempool: mempool, Peers: peers}
+		publicService := &network.PublicServer{Blockchain: bc, Mempool: mempool}
 
 		proto.RegisterNodeServiceServer(grpcServer, nodeService)
 
 		proto.RegisterPublicServiceServer(grpcServer, publicService)
 
+		serveErr := make(chan error, 3)
 		go func() {
 			lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
 			if err != nil {
-				log.Fatalf("Không thể lắng nghe gRPC trên cổng %s: %v", grpcPort, err)
+				serveErr <- fmt.Errorf("không thể lắng nghe gRPC trên cổng %s: %w", grpcPort, err)
+				return
 			}
 			log.Printf("gRPC Server thuần túy đang lắng nghe tại %v", lis.Addr())
-			if err := grpcServer.Serve(lis); err != nil {
-
-				log.Printf("gRPC Server thuần túy thất bại: %v", err)
+			if err := grpcServer.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+				serveErr <- fmt.Errorf("gRPC Server thuần túy thất bại: %w", err)
 			}
 		}()
 
+		if addrs := peers.Addresses(); len(addrs) > 0 {
+			log.Printf("Kết nối tới %d peer: %s", len(addrs), strings.Join(addrs, ", "))
+			go peers.Relay(bc.Events)
+			go nodeService.Sync(ctx)
+		}
+
 		wrappedGrpc := grpcweb.WrapServer(grpcServer,
 
 			grpcweb.WithOriginFunc(func(origin string) bool {
@@ -81,6 +131,15 @@ var startCmd = &cobra.Command{
 			}),
 		)
 
+		var gateway http.Handler = http.NotFoundHandler()
+		gatewayConn, err := network.Dial(fmt.Sprintf("localhost:%s", grpcPort))
+		if err != nil {
+			serveErr <- fmt.Errorf("không thể tạo kết nối cho REST gateway: %w", err)
+		} else {
+			defer gatewayConn.Close()
+			gateway = network.NewGateway(gatewayConn)
+		}
+
 		httpServer := &http.Server{
 			Addr: fmt.Sprintf(":%s", port),
 			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -90,26 +149,74 @@ var startCmd = &cobra.Command{
 					return
 				}
 
+				if strings.HasPrefix(r.URL.Path, network.GatewayPrefix) {
+					gateway.ServeHTTP(w, r)
+					return
+				}
+
 				http.NotFound(w, r)
 			}),
 			ReadHeaderTimeout: 5 * time.Second,
 		}
 
-		log.Printf("gRPC & gRPC-Web server đang lắng nghe tại [::]:%s", port)
-		if err := httpServer.ListenAndServe(); err != nil {
-			log.Fatalf("Server thất bại: %v", err)
+		log.Printf("gRPC & gRPC-Web server đang lắng nghe tại [::]:%s (REST: %s, OpenAPI: %sopenapi.json)", port, network.GatewayPrefix, network.GatewayPrefix)
+		go func() {
+			serveErr <- httpServer.ListenAndServe()
+		}()
+
+		var failure error
+		select {
+		case <-ctx.Done():
+			log.Println("Nhận tín hiệu dừng, đang tắt node...")
+		case failure = <-serveErr:
+			log.Printf("Server thất bại: %v", failure)
 		}
+		stop()
 
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Hết thời gian chờ các kết nối HTTP, đóng cưỡng bức: %v", err)
+			httpServer.Close()
+		}
+		stopGRPC(shutdownCtx, grpcServer)
+		<-minerDone
+
+		if err := mempool.Close(); err != nil {
+			log.Printf("Lỗi đóng mempool: %v", err)
+		}
 		log.Println("Đang đóng CSDL...")
-		bc.Close()
+		if err := network.CloseBlockchain(bc); err != nil {
+			log.Printf("Lỗi đóng CSDL: %v", err)
+		}
 		log.Println("CSDL đã đóng.")
+		Handle(failure)
 	},
 }
 
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Println("Hết thời gian chờ các RPC đang chạy, dừng gRPC server cưỡng bức.")
+		server.Stop()
+	}
+}
+
 func init() {
 	startCmd.Flags().String("port", "", "Cổng để node lắng nghe (ví dụ: 3000)")
 
 	startCmd.Flags().String("miner", "", "Bật chế độ Miner, gửi thưởng về địa chỉ ví này")
 	startCmd.Flags().String("grpcport", "50051", "Cổng gRPC thuần túy (cho P2P/CLI)")
+	startCmd.Flags().String("peers", "", "Danh sách địa chỉ gRPC của các peer, cách nhau bởi dấu phẩy")
+	startCmd.Flags().String("mempool", config.MempoolBackendFile, "Nơi lưu mempool: file, memory hoặc redis")
+	startCmd.Flags().Bool("txindex", false, "Bật chỉ mục giao dịch và lịch sử địa chỉ (GetAddressHistory)")
+	startCmd.Flags().Duration("mining-interval", network.DefaultMiningInterval, "Chu kỳ miner kiểm tra mempool và đào block")
+	startCmd.Flags().Int64("dust-threshold", network.DefaultDustThreshold, "Mempool từ chối giao dịch có output nhỏ hơn ngưỡng này")
 	rootCmd.AddCommand(startCmd)
 }
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/khoahotran/gochain-ledger/network"
+	"github.com/khoahotran/gochain-ledger/proto"
+	"github.com/spf13/cobra"
+)
+
+var getSupplyCmd = &cobra.Command{
+	Use:   "supply",
+	Short: "Xem tổng lượng coin đã phát hành (qua một node)",
+	Run: func(cmd *cobra.Command, args []string) {
+		nodeAddr, _ := cmd.Flags().GetString("node")
+
+		conn, err := network.Dial(nodeAddr)
+		if err != nil {
+			log.Fatalf("Không thể kết nối: %v", err)
+		}
+		defer conn.Close()
+
+		client := proto.NewNodeServiceClient(conn)
+
+		res, err := client.GetSupply(context.Background(), &proto.EmptyRequest{})
+		if err != nil {
+			log.Fatalf("Gọi gRPC GetSupply thất bại: %v", err)
+		}
+
+		fmt.Printf("Đã phát hành: %d / %d\n", res.Issued, res.MaxSupply)
+		fmt.Printf("Chiều cao hiện tại: %d\n", res.Height)
+		fmt.Printf("Phần thưởng block kế tiếp: %d\n", res.NextBlockReward)
+		fmt.Printf("Chu kỳ halving: %d block, coinbase maturity: %d block\n", res.HalvingInterval, res.CoinbaseMaturity)
+	},
+}
+
+func init() {
+	getSupplyCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+	rootCmd.AddCommand(getSupplyCmd)
+}
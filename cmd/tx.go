@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/khoahotran/gochain-ledger/application"
+	"github.com/spf13/cobra"
+)
+
+var txCmd = &cobra.Command{
+	Use:   "tx",
+	Short: "Quản lý giao dịch đang chờ trong Mempool",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var txBumpCmd = &cobra.Command{
+	Use:   "bump",
+	Short: "Thay thế giao dịch đang chờ bằng bản có phí cao hơn (RBF)",
+	Run: func(cmd *cobra.Command, args []string) {
+		from, _ := cmd.Flags().GetString("from")
+		txHex, _ := cmd.Flags().GetString("tx")
+		fee, _ := cmd.Flags().GetInt64("fee")
+		nodeAddr, _ := cmd.Flags().GetString("node")
+
+		if from == "" || txHex == "" || fee <= 0 || nodeAddr == "" {
+			Handle(errors.New("Flag --from, --tx, --fee, --node là bắt buộc"))
+		}
+
+		txID, err := hex.DecodeString(txHex)
+		if err != nil || len(txID) == 0 {
+			Handle(errors.New("Flag --tx phải là ID giao dịch dạng hex"))
+		}
+
+		loadedWallet := promptWallet(from)
+
+		_, err = application.BumpFeeUseCase(txID, fee, loadedWallet, nodeAddr)
+		Handle(err)
+	},
+}
+
+func init() {
+	txBumpCmd.Flags().String("from", "", "Địa chỉ ví đã tạo giao dịch")
+	txBumpCmd.Flags().String("tx", "", "ID giao dịch đang chờ (hex)")
+	txBumpCmd.Flags().Int64("fee", 0, "Tổng phí mới (phải lớn hơn phí hiện tại)")
+	txBumpCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+
+	txCmd.AddCommand(txBumpCmd)
+	rootCmd.AddCommand(txCmd)
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/khoahotran/gochain-ledger/application"
+	"github.com/spf13/cobra"
+)
+
+var verifyChainCmd = &cobra.Command{
+	Use:   "verifychain",
+	Short: "Kiểm tra toàn bộ chain trong CSDL cục bộ và so khớp dữ liệu dẫn xuất (node phải đang dừng)",
+	Run: func(cmd *cobra.Command, args []string) {
+		full, _ := cmd.Flags().GetBool("full")
+		repair, _ := cmd.Flags().GetBool("repair")
+
+		Handle(application.VerifyChainUseCase(openChainStore(), full, repair))
+	},
+}
+
+func init() {
+	verifyChainCmd.Flags().Bool("full", false, "Kiểm tra thêm input, khóa thời gian, chữ ký và phần thưởng coinbase của từng giao dịch")
+	verifyChainCmd.Flags().Bool("repair", false, "Dựng lại UTXO Set, state contract và chỉ mục từ các block nếu phát hiện sai lệch")
+	rootCmd.AddCommand(verifyChainCmd)
+}
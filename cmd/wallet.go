@@ -7,6 +7,7 @@ import (
 	"syscall"
 
 	"github.com/khoahotran/gochain-ledger/application"
+	"github.com/khoahotran/gochain-ledger/domain"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
@@ -15,6 +16,9 @@ var createWalletCmd = &cobra.Command{
 	Use:   "createwallet",
 	Short: "Tạo một cặp ví (Keypair) mới (đã mã hóa)",
 	Run: func(cmd *cobra.Command, args []string) {
+		keyTypeName, _ := cmd.Flags().GetString("type")
+		keyType, err := domain.ParseKeyType(keyTypeName)
+		Handle(err)
 
 		fmt.Print("Nhập mật khẩu (để mã hóa ví): ")
 		bytePassword, err := term.ReadPassword(int(syscall.Stdin))
@@ -40,10 +44,12 @@ var createWalletCmd = &cobra.Command{
 			Handle(errors.New("mật khẩu không khớp"))
 		}
 
-		application.CreateWalletUseCase(password)
+		_, err = application.CreateWalletUseCase(password, keyType)
+		Handle(err)
 	},
 }
 
 func init() {
+	createWalletCmd.Flags().String("type", "p256", "Loại khóa: p256, secp256k1 hoặc ed25519")
 	rootCmd.AddCommand(createWalletCmd)
 }
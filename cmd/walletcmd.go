@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/khoahotran/gochain-ledger/application"
+	"github.com/spf13/cobra"
+)
+
+var walletCmd = &cobra.Command{
+	Use:   "wallet",
+	Short: "Quản lý UTXO của ví",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var walletListUnspentCmd = &cobra.Command{
+	Use:   "listunspent",
+	Short: "Liệt kê UTXO của một địa chỉ (qua một node)",
+	Run: func(cmd *cobra.Command, args []string) {
+		address, _ := cmd.Flags().GetString("address")
+		unconfirmed, _ := cmd.Flags().GetBool("unconfirmed")
+		nodeAddr, _ := cmd.Flags().GetString("node")
+
+		if address == "" || nodeAddr == "" {
+			Handle(errors.New("Flag --address, --node là bắt buộc"))
+		}
+
+		utxos, dustThreshold, err := application.ListUnspentUseCase(address, unconfirmed, nodeAddr)
+		Handle(err)
+
+		var total int64
+		for _, utxo := range utxos {
+			status := ""
+			if !utxo.Spendable {
+				status = " (coinbase chưa đủ maturity)"
+			}
+			fmt.Printf("%x:%d  %d  %d xác nhận%s\n", utxo.TxId, utxo.VoutIndex, utxo.Amount, utxo.Confirmations, status)
+			total += utxo.Amount
+		}
+		fmt.Printf("Tổng: %d UTXO, %d (ngưỡng dust của node: %d)\n", len(utxos), total, dustThreshold)
+	},
+}
+
+var walletConsolidateCmd = &cobra.Command{
+	Use:   "consolidate",
+	Short: "Gộp các UTXO nhỏ thành ít output hơn",
+	Run: func(cmd *cobra.Command, args []string) {
+		from, _ := cmd.Flags().GetString("from")
+		maxValue, _ := cmd.Flags().GetInt64("max-value")
+		outputs, _ := cmd.Flags().GetInt("outputs")
+		maxSize, _ := cmd.Flags().GetInt("max-size")
+		fee, _ := cmd.Flags().GetInt64("fee")
+		nodeAddr, _ := cmd.Flags().GetString("node")
+
+		if from == "" || nodeAddr == "" {
+			Handle(errors.New("Flag --from, --node là bắt buộc"))
+		}
+		if outputs < 1 || maxSize <= 0 || fee < 0 || maxValue < 0 {
+			Handle(errors.New("--outputs, --max-size phải dương; --fee, --max-value không được âm"))
+		}
+
+		loadedWallet := promptWallet(from)
+
+		_, err := application.ConsolidateUseCase(from, maxValue, outputs, maxSize, fee, loadedWallet, nodeAddr)
+		Handle(err)
+	},
+}
+
+func init() {
+	walletListUnspentCmd.Flags().String("address", "", "Địa chỉ ví cần liệt kê")
+	walletListUnspentCmd.Flags().Bool("unconfirmed", false, "Bao gồm output đang chờ trong Mempool")
+	walletListUnspentCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+
+	walletConsolidateCmd.Flags().String("from", "", "Địa chỉ ví cần gộp UTXO")
+	walletConsolidateCmd.Flags().Int64("max-value", 0, "Chỉ gộp UTXO có giá trị không vượt quá mức này (0 = tất cả)")
+	walletConsolidateCmd.Flags().Int("outputs", 1, "Số output sau khi gộp")
+	walletConsolidateCmd.Flags().Int("max-size", 50000, "Kích thước tối đa của giao dịch (byte)")
+	walletConsolidateCmd.Flags().Int64("fee", 0, "Phí giao dịch trả cho miner")
+	walletConsolidateCmd.Flags().String("node", "localhost:50051", "Địa chỉ node đang chạy")
+
+	walletCmd.AddCommand(walletListUnspentCmd, walletConsolidateCmd)
+	rootCmd.AddCommand(walletCmd)
+}
@@ -0,0 +1,455 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/network"
+)
+
+const (
+	FileName  = "node.toml"
+	envPrefix = "GOCHAIN_"
+
+	MempoolBackendFile   = "file"
+	MempoolBackendRedis  = "redis"
+	MempoolBackendMemory = "memory"
+)
+
+type Config struct {
+	DataDir string
+	File    string
+
+	Storage StorageConfig
+	Network NetworkConfig
+	Mempool MempoolConfig
+	Miner   MinerConfig
+	Chain   ChainConfig
+}
+
+type StorageConfig struct {
+	DBPath    string
+	WalletDir string
+	TxIndex   bool
+}
+
+type NetworkConfig struct {
+	Port     string
+	GRPCPort string
+	Node     string
+	Peers    string
+}
+
+type MempoolConfig struct {
+	Backend       string
+	File          string
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+	DustThreshold int64
+}
+
+type MinerConfig struct {
+	Address  string
+	Interval time.Duration
+}
+
+type ChainConfig struct {
+	Network          string
+	Difficulty       int
+	InitialReward    int64
+	HalvingInterval  int64
+	MaxSupply        int64
+	CoinbaseMaturity int64
+}
+
+func (c ChainConfig) Emission() domain.EmissionSchedule {
+	return domain.EmissionSchedule{
+		InitialReward:    c.InitialReward,
+		HalvingInterval:  c.HalvingInterval,
+		MaxSupply:        c.MaxSupply,
+		CoinbaseMaturity: c.CoinbaseMaturity,
+	}
+}
+
+type Setting struct {
+	Key    string
+	Flag   string
+	quoted bool
+	get    func(c *Config) string
+	set    func(c *Config, value string) error
+}
+
+func (s Setting) EnvName() string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(s.Key, ".", "_"))
+}
+
+func (s Setting) Get(c *Config) string {
+	return s.get(c)
+}
+
+func (s Setting) Set(c *Config, value string) error {
+	return s.set(c, value)
+}
+
+func Default() *Config {
+	return &Config{
+		DataDir: ".",
+		Mempool: MempoolConfig{
+			Backend:       MempoolBackendFile,
+			RedisAddr:     "localhost:6379",
+			DustThreshold: network.DefaultDustThreshold,
+		},
+		Miner: MinerConfig{
+			Interval: network.DefaultMiningInterval,
+		},
+		Chain: ChainConfig{
+			Network: domain.MainNetParams.Name,
+		},
+	}
+}
+
+func (c *Config) Params() *domain.ChainParams {
+	params, err := domain.ParamsForNetwork(c.Chain.Network)
+	if err != nil {
+		return &domain.MainNetParams
+	}
+	if !params.AllowParamOverrides {
+		return params
+	}
+
+	custom := *params
+	if c.Chain.Difficulty != 0 {
+		custom.Difficulty = c.Chain.Difficulty
+	}
+	if emission := c.Chain.Emission(); emission.Validate() == nil {
+		custom.Emission = emission
+	}
+	return &custom
+}
+
+func (c *Config) checkChainOverrides() error {
+	params, err := domain.ParamsForNetwork(c.Chain.Network)
+	if err != nil {
+		return err
+	}
+	if params.AllowParamOverrides {
+		return nil
+	}
+
+	overrides := []struct {
+		key         string
+		value, want int64
+	}{
+		{"chain.difficulty", int64(c.Chain.Difficulty), int64(params.Difficulty)},
+		{"chain.initial_reward", c.Chain.InitialReward, params.Emission.InitialReward},
+		{"chain.halving_interval", c.Chain.HalvingInterval, params.Emission.HalvingInterval},
+		{"chain.max_supply", c.Chain.MaxSupply, params.Emission.MaxSupply},
+		{"chain.coinbase_maturity", c.Chain.CoinbaseMaturity, params.Emission.CoinbaseMaturity},
+	}
+	for _, o := range overrides {
+		if o.value != o.want {
+			return fmt.Errorf("mạng %s dùng tham số cố định, không cho phép ghi đè %s = %d (mạng dùng %d; chỉ regtest cho phép)", params.Name, o.key, o.value, o.want)
+		}
+	}
+	return nil
+}
+
+func (c *Config) applyNetworkDefaults() error {
+	params, err := domain.ParamsForNetwork(c.Chain.Network)
+	if err != nil {
+		return err
+	}
+	c.Chain.Network = params.Name
+
+	if c.Storage.DBPath == "" {
+		c.Storage.DBPath = filepath.Join("tmp", params.DataSubdir, "blocks")
+	}
+	if c.Mempool.File == "" {
+		c.Mempool.File = filepath.Join("tmp", params.DataSubdir, "mempool.dat")
+	}
+	if c.Storage.WalletDir == "" {
+		c.Storage.WalletDir = filepath.Join("wallets", params.DataSubdir)
+	}
+	if c.Network.Port == "" {
+		c.Network.Port = params.DefaultPort
+	}
+	if c.Network.GRPCPort == "" {
+		c.Network.GRPCPort = params.DefaultGRPCPort
+	}
+	if c.Network.Node == "" {
+		c.Network.Node = "localhost:" + c.Network.GRPCPort
+	}
+	if c.Chain.Difficulty == 0 {
+		c.Chain.Difficulty = params.Difficulty
+	}
+	if c.Chain.InitialReward == 0 {
+		c.Chain.InitialReward = params.Emission.InitialReward
+	}
+	if c.Chain.HalvingInterval == 0 {
+		c.Chain.HalvingInterval = params.Emission.HalvingInterval
+	}
+	if c.Chain.MaxSupply == 0 {
+		c.Chain.MaxSupply = params.Emission.MaxSupply
+	}
+	if c.Chain.CoinbaseMaturity == 0 {
+		c.Chain.CoinbaseMaturity = params.Emission.CoinbaseMaturity
+	}
+	return nil
+}
+
+func Load(configFile, dataDir, networkName string) (*Config, error) {
+	cfg := Default()
+
+	if dataDir == "" {
+		dataDir = os.Getenv(envPrefix + "DATADIR")
+	}
+	if configFile == "" {
+		configFile = os.Getenv(envPrefix + "CONFIG")
+	}
+	if configFile == "" {
+		candidate := filepath.Join(cfg.DataDir, FileName)
+		if dataDir != "" {
+			candidate = filepath.Join(dataDir, FileName)
+		}
+		if _, err := os.Stat(candidate); err == nil {
+			configFile = candidate
+		}
+	}
+
+	if configFile != "" {
+		f, err := os.Open(configFile)
+		if err != nil {
+			return nil, fmt.Errorf("không thể mở file cấu hình: %v", err)
+		}
+		values, err := parseTOML(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("file cấu hình %s: %v", configFile, err)
+		}
+		if err := cfg.apply(values); err != nil {
+			return nil, fmt.Errorf("file cấu hình %s: %v", configFile, err)
+		}
+		cfg.File = configFile
+	}
+
+	for _, s := range Settings {
+		if value, ok := os.LookupEnv(s.EnvName()); ok {
+			if err := s.set(cfg, value); err != nil {
+				return nil, fmt.Errorf("biến môi trường %s: %v", s.EnvName(), err)
+			}
+		}
+	}
+
+	if dataDir != "" {
+		cfg.DataDir = dataDir
+	}
+	if networkName != "" {
+		cfg.Chain.Network = networkName
+	}
+	if err := cfg.applyNetworkDefaults(); err != nil {
+		return nil, err
+	}
+	return cfg, cfg.Validate()
+}
+
+func (c *Config) apply(values map[string]string) error {
+	if dataDir, ok := values["datadir"]; ok {
+		c.DataDir = dataDir
+		delete(values, "datadir")
+	}
+
+	for key, value := range values {
+		s, ok := lookupSetting(key)
+		if !ok {
+			return fmt.Errorf("key không được hỗ trợ: %s", key)
+		}
+		if err := s.set(c, value); err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+func (c *Config) Validate() error {
+	switch c.Mempool.Backend {
+	case MempoolBackendFile, MempoolBackendRedis, MempoolBackendMemory:
+	default:
+		return fmt.Errorf("mempool backend không được hỗ trợ: %s", c.Mempool.Backend)
+	}
+	if c.Miner.Interval <= 0 {
+		return fmt.Errorf("miner.interval phải lớn hơn 0")
+	}
+	if c.Chain.Difficulty <= 0 || c.Chain.Difficulty >= 256 {
+		return fmt.Errorf("chain.difficulty phải nằm trong khoảng 1-255")
+	}
+	return c.checkChainOverrides()
+}
+
+func (c *Config) resolve(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(c.DataDir, path)
+}
+
+func (c *Config) DBPath() string {
+	return c.resolve(c.Storage.DBPath)
+}
+
+func (c *Config) WalletDir() string {
+	return c.resolve(c.Storage.WalletDir)
+}
+
+func (c *Config) MempoolFile() string {
+	return c.resolve(c.Mempool.File)
+}
+
+func (c *Config) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "datadir = %q\n", c.DataDir)
+	section := ""
+	for _, s := range Settings {
+		name, key, _ := strings.Cut(s.Key, ".")
+		if name != section {
+			fmt.Fprintf(&b, "\n[%s]\n", name)
+			section = name
+		}
+		fmt.Fprintf(&b, "%s = %s\n", key, s.format(c))
+	}
+	return b.String()
+}
+
+func (c *Config) WriteFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(c.String()), 0644)
+}
+
+func (s Setting) format(c *Config) string {
+	if s.quoted {
+		return strconv.Quote(s.get(c))
+	}
+	return s.get(c)
+}
+
+func lookupSetting(key string) (Setting, bool) {
+	for _, s := range Settings {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+func stringSetting(key, flag string, field func(c *Config) *string) Setting {
+	return Setting{
+		Key:    key,
+		Flag:   flag,
+		quoted: true,
+		get:    func(c *Config) string { return *field(c) },
+		set: func(c *Config, value string) error {
+			*field(c) = value
+			return nil
+		},
+	}
+}
+
+func int64Setting(key, flag string, field func(c *Config) *int64) Setting {
+	return Setting{
+		Key:  key,
+		Flag: flag,
+		get:  func(c *Config) string { return strconv.FormatInt(*field(c), 10) },
+		set: func(c *Config, value string) error {
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("số nguyên không hợp lệ: %s", value)
+			}
+			*field(c) = n
+			return nil
+		},
+	}
+}
+
+func intSetting(key, flag string, field func(c *Config) *int) Setting {
+	return Setting{
+		Key:  key,
+		Flag: flag,
+		get:  func(c *Config) string { return strconv.Itoa(*field(c)) },
+		set: func(c *Config, value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("số nguyên không hợp lệ: %s", value)
+			}
+			*field(c) = n
+			return nil
+		},
+	}
+}
+
+func boolSetting(key, flag string, field func(c *Config) *bool) Setting {
+	return Setting{
+		Key:  key,
+		Flag: flag,
+		get:  func(c *Config) string { return strconv.FormatBool(*field(c)) },
+		set: func(c *Config, value string) error {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("giá trị bool không hợp lệ: %s", value)
+			}
+			*field(c) = b
+			return nil
+		},
+	}
+}
+
+func durationSetting(key, flag string, field func(c *Config) *time.Duration) Setting {
+	return Setting{
+		Key:    key,
+		Flag:   flag,
+		quoted: true,
+		get:    func(c *Config) string { return field(c).String() },
+		set: func(c *Config, value string) error {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("khoảng thời gian không hợp lệ: %s", value)
+			}
+			*field(c) = d
+			return nil
+		},
+	}
+}
+
+var Settings = []Setting{
+	stringSetting("storage.db_path", "", func(c *Config) *string { return &c.Storage.DBPath }),
+	stringSetting("storage.wallet_dir", "", func(c *Config) *string { return &c.Storage.WalletDir }),
+	boolSetting("storage.txindex", "txindex", func(c *Config) *bool { return &c.Storage.TxIndex }),
+
+	stringSetting("network.port", "port", func(c *Config) *string { return &c.Network.Port }),
+	stringSetting("network.grpc_port", "grpcport", func(c *Config) *string { return &c.Network.GRPCPort }),
+	stringSetting("network.node", "node", func(c *Config) *string { return &c.Network.Node }),
+	stringSetting("network.peers", "peers", func(c *Config) *string { return &c.Network.Peers }),
+
+	stringSetting("mempool.backend", "mempool", func(c *Config) *string { return &c.Mempool.Backend }),
+	stringSetting("mempool.file", "", func(c *Config) *string { return &c.Mempool.File }),
+	stringSetting("mempool.redis_addr", "", func(c *Config) *string { return &c.Mempool.RedisAddr }),
+	stringSetting("mempool.redis_password", "", func(c *Config) *string { return &c.Mempool.RedisPassword }),
+	intSetting("mempool.redis_db", "", func(c *Config) *int { return &c.Mempool.RedisDB }),
+	int64Setting("mempool.dust_threshold", "dust-threshold", func(c *Config) *int64 { return &c.Mempool.DustThreshold }),
+
+	stringSetting("miner.address", "miner", func(c *Config) *string { return &c.Miner.Address }),
+	durationSetting("miner.interval", "mining-interval", func(c *Config) *time.Duration { return &c.Miner.Interval }),
+
+	stringSetting("chain.network", "", func(c *Config) *string { return &c.Chain.Network }),
+	intSetting("chain.difficulty", "", func(c *Config) *int { return &c.Chain.Difficulty }),
+	int64Setting("chain.initial_reward", "reward", func(c *Config) *int64 { return &c.Chain.InitialReward }),
+	int64Setting("chain.halving_interval", "halving", func(c *Config) *int64 { return &c.Chain.HalvingInterval }),
+	int64Setting("chain.max_supply", "max-supply", func(c *Config) *int64 { return &c.Chain.MaxSupply }),
+	int64Setting("chain.coinbase_maturity", "maturity", func(c *Config) *int64 { return &c.Chain.CoinbaseMaturity }),
+}
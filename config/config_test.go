@@ -0,0 +1,106 @@
+package config
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestChainOverridesRejectedOutsideRegtest(t *testing.T) {
+	cases := []struct {
+		network string
+		content string
+		key     string
+	}{
+		{"mainnet", "[chain]\ndifficulty = 4\n", "chain.difficulty"},
+		{"testnet", "[chain]\ndifficulty = 4\n", "chain.difficulty"},
+		{"mainnet", "[chain]\ninitial_reward = 5000\n", "chain.initial_reward"},
+		{"testnet", "[chain]\nhalving_interval = 10\n", "chain.halving_interval"},
+		{"mainnet", "[chain]\nmax_supply = 1\n", "chain.max_supply"},
+		{"mainnet", "[chain]\ncoinbase_maturity = 0\n", ""},
+		{"mainnet", "[chain]\ncoinbase_maturity = 1\n", "chain.coinbase_maturity"},
+	}
+	for _, c := range cases {
+		dir := writeConfig(t, c.content)
+		_, err := Load("", dir, c.network)
+		if c.key == "" {
+			if err != nil {
+				t.Errorf("%s %q: giá trị 0 là mặc định, không được coi là ghi đè: %v", c.network, c.content, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.key) {
+			t.Errorf("%s %q: cần lỗi nhắc tới %s, nhận %v", c.network, c.content, c.key, err)
+		}
+	}
+}
+
+func TestChainOverridesFromEnvRejectedOutsideRegtest(t *testing.T) {
+	t.Setenv("GOCHAIN_CHAIN_DIFFICULTY", "2")
+	if _, err := Load("", t.TempDir(), "mainnet"); err == nil {
+		t.Fatal("mainnet phải từ chối GOCHAIN_CHAIN_DIFFICULTY")
+	}
+	if _, err := Load("", t.TempDir(), "regtest"); err != nil {
+		t.Fatalf("regtest phải nhận GOCHAIN_CHAIN_DIFFICULTY: %v", err)
+	}
+}
+
+func TestNetworkDefaultsAreNotOverrides(t *testing.T) {
+	for _, params := range domain.Networks {
+		cfg, err := Load("", t.TempDir(), params.Name)
+		if err != nil {
+			t.Fatalf("%s: %v", params.Name, err)
+		}
+		// config show in ra đủ mọi key; nạp lại chính nó không được bị coi là ghi đè.
+		dir := writeConfig(t, cfg.String())
+		if _, err := Load("", dir, ""); err != nil {
+			t.Errorf("%s: nạp lại cấu hình mặc định thất bại: %v", params.Name, err)
+		}
+		if cfg.Params() != params && !params.AllowParamOverrides {
+			t.Errorf("%s: Params() phải trả về tham số mạng gốc", params.Name)
+		}
+	}
+}
+
+func TestRegtestOverridesApplyToParamsCopy(t *testing.T) {
+	dir := writeConfig(t, "[chain]\nnetwork = \"regtest\"\ndifficulty = 4\ninitial_reward = 50\nhalving_interval = 20\n")
+	cfg, err := Load("", dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := cfg.Params()
+	if params == &domain.RegTestParams {
+		t.Fatal("Params() không được trả về biến toàn cục RegTestParams khi có ghi đè")
+	}
+	if params.Difficulty != 4 || params.Emission.InitialReward != 50 || params.Emission.HalvingInterval != 20 {
+		t.Fatalf("tham số sau ghi đè sai: difficulty %d, emission %+v", params.Difficulty, params.Emission)
+	}
+	if params.Emission.MaxSupply != domain.RegTestParams.Emission.MaxSupply {
+		t.Fatalf("max_supply không ghi đè phải giữ giá trị regtest, nhận %d", params.Emission.MaxSupply)
+	}
+	if domain.RegTestParams.Difficulty != 1 || domain.RegTestParams.Emission.InitialReward != 100 {
+		t.Fatal("nạp cấu hình không được sửa RegTestParams")
+	}
+
+	previous := domain.ActiveParams()
+	defer domain.SelectParams(previous)
+	domain.SelectParams(params)
+	want := new(big.Int).Lsh(big.NewInt(1), 256-4)
+	if target := domain.NewProofOfWork(&domain.Block{}).Target; target.Cmp(want) != 0 {
+		t.Fatalf("PoW phải dùng độ khó của tham số đang chọn: target %x, cần %x", target, want)
+	}
+}
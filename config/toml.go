@@ -0,0 +1,91 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func parseTOML(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	section := ""
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("dòng %d: tên bảng không hợp lệ: %s", lineNo, line)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == "" {
+				return nil, fmt.Errorf("dòng %d: tên bảng rỗng", lineNo)
+			}
+			continue
+		}
+
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("dòng %d: cần dạng key = value", lineNo)
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("dòng %d: key rỗng", lineNo)
+		}
+		value, err := parseTOMLValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("dòng %d: %v", lineNo, err)
+		}
+
+		if section != "" {
+			key = section + "." + key
+		}
+		if _, exists := values[key]; exists {
+			return nil, fmt.Errorf("dòng %d: key %s bị khai báo lại", lineNo, key)
+		}
+		values[key] = value
+	}
+	return values, scanner.Err()
+}
+
+func parseTOMLValue(raw string) (string, error) {
+	switch {
+	case raw == "":
+		return "", fmt.Errorf("thiếu giá trị")
+	case strings.HasPrefix(raw, `"`):
+		value, err := strconv.Unquote(raw)
+		if err != nil {
+			return "", fmt.Errorf("chuỗi không hợp lệ: %s", raw)
+		}
+		return value, nil
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return "", fmt.Errorf("chuỗi không hợp lệ: %s", raw)
+		}
+		return raw[1 : len(raw)-1], nil
+	}
+	return strings.ReplaceAll(raw, "_", ""), nil
+}
+
+func stripComment(line string) string {
+	inBasic, inLiteral := false, false
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && inBasic:
+			i++
+		case c == '"' && !inLiteral:
+			inBasic = !inBasic
+		case c == '\'' && !inBasic:
+			inLiteral = !inLiteral
+		case c == '#' && !inBasic && !inLiteral:
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -1,9 +1,7 @@
 package domain
 
 import (
-	"bytes"
 	"crypto/sha256"
-	"encoding/gob"
 	"log"
 	"time"
 )
@@ -14,41 +12,53 @@ type Block struct {
 	Hash          []byte
 	Transactions  []*Transaction
 	Nonce         int64
+	Height        int64
 }
 
+// CalculateHash băm header chuẩn của block với nonce và độ khó hiện tại; đây cũng là hash proof-of-work.
 func (b *Block) CalculateHash() []byte {
-
-	data := bytes.Join(
-		[][]byte{
-			b.PrevBlockHash,
-			b.HashTransactions(),
-			IntToHex(b.Timestamp),
-			IntToHex(b.Nonce),
-		},
-		[]byte{},
-	)
-
-	hash := sha256.Sum256(data)
+	hash := sha256.Sum256(b.Header(uint32(activeParams.Difficulty), b.Nonce))
 	return hash[:]
 }
 
-func (b *Block) HashTransactions() []byte {
-	var txHashes [][]byte
-	for _, tx := range b.Transactions {
-		txHashes = append(txHashes, tx.ID)
+// MerkleRoot dựng cây Merkle từ ID giao dịch: mỗi nút cha là sha256(trái || phải),
+// nút lẻ cuối mỗi tầng được đưa thẳng lên tầng trên. Block rỗng có root toàn byte 0.
+func (b *Block) MerkleRoot() []byte {
+	if len(b.Transactions) == 0 {
+		return make([]byte, sha256.Size)
 	}
 
-	txHash := sha256.Sum256(bytes.Join(txHashes, []byte{}))
-	return txHash[:]
+	level := make([][]byte, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		level[i] = tx.ID
+	}
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			node := sha256.Sum256(append(append([]byte{}, level[i]...), level[i+1]...))
+			next = append(next, node[:])
+		}
+		level = next
+	}
+	return level[0]
 }
 
-func NewBlock(prevBlockHash []byte, transactions []*Transaction) *Block {
+func NewBlock(prevBlockHash []byte, transactions []*Transaction, height int64) *Block {
+	return newBlockAt(prevBlockHash, transactions, height, time.Now().Unix())
+}
+
+func newBlockAt(prevBlockHash []byte, transactions []*Transaction, height int64, timestamp int64) *Block {
 	block := &Block{
-		Timestamp:     time.Now().Unix(),
+		Timestamp:     timestamp,
 		PrevBlockHash: prevBlockHash,
 		Hash:          []byte{},
 		Transactions:  transactions,
 		Nonce:         0,
+		Height:        height,
 	}
 
 	pow := NewProofOfWork(block)
@@ -62,37 +72,15 @@ func NewBlock(prevBlockHash []byte, transactions []*Transaction) *Block {
 	return block
 }
 
-func NewGenesisBlock(coinbaseTx *Transaction) *Block {
-
-	return NewBlock([]byte{}, []*Transaction{coinbaseTx})
-}
-
-func (b *Block) Serialize() []byte {
-	var result bytes.Buffer
-	encoder := gob.NewEncoder(&result)
-	err := encoder.Encode(b)
-	if err != nil {
-		log.Panic(err)
-	}
-	return result.Bytes()
-}
-
-func DeserializeBlock(data []byte) *Block {
-	var block Block
-	decoder := gob.NewDecoder(bytes.NewReader(data))
-	err := decoder.Decode(&block)
-	if err != nil {
-		log.Panic(err)
-	}
-	return &block
-}
-
-func IntToHex(n int64) []byte {
-	var buff bytes.Buffer
-	enc := gob.NewEncoder(&buff)
-	err := enc.Encode(n)
-	if err != nil {
-		log.Panic(err)
+func (b *Block) CoinbaseValue() int64 {
+	var value int64
+	for _, tx := range b.Transactions {
+		if !tx.IsCoinbase() {
+			continue
+		}
+		for _, out := range tx.Vout {
+			value += out.Value
+		}
 	}
-	return buff.Bytes()
+	return value
 }
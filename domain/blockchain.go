@@ -2,154 +2,370 @@ package domain
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"log"
-
-	"github.com/dgraph-io/badger/v3"
+	"time"
 )
 
 const (
-	dbPath              = "./tmp/blocks"
-	lastHashKey         = "lh"
-	utxoPrefix          = "utxo-"
-	contractStatePrefix = "contract-state-"
-	contractCodePrefix  = "contract-code-"
+	emissionKey    = "emission"
+	supplyKey      = "supply"
+	utxoVersionKey = "utxo-version"
+	utxoTipKey     = "utxo-tip"
+	networkKey     = "network"
+	utxoVersion    = 4
+
+	maxFutureBlockTime = 2 * time.Hour
+)
+
+var (
+	ErrImmatureCoinbase = errors.New("coinbase chưa đủ maturity")
+	ErrBlockNotOnTip    = errors.New("block không nối vào tip hiện tại")
 )
 
 type Blockchain struct {
 	LastHash []byte
-	Database *badger.DB
+	Store    ChainStore
+	Emission EmissionSchedule
+	Indexed  bool
+	Events   *EventBus
+
+	bestHeight int64
 }
 
-func InitBlockchain(address string) *Blockchain {
+func InitBlockchain(store ChainStore, address string, emission EmissionSchedule) (*Blockchain, error) {
+	lastHash, err := store.LastHash()
+	if errors.Is(err, ErrNotFound) {
+		log.Println("Không tìm thấy blockchain. Đang tạo mới...")
 
-	opts := badger.DefaultOptions(dbPath)
-	opts.WithValueLogFileSize(1024 * 1024)
-	opts.WithLogger(nil)
-	db, err := badger.Open(opts)
-	Handle(err)
+		genesis, err := activeParams.GenesisBlock(address, emission)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Block Genesis của mạng %s đã được tạo: %x", activeParams.Name, genesis.Hash)
 
-	var lastHash []byte
+		if err := writeGenesis(store, genesis, emission); err != nil {
+			return nil, err
+		}
+		lastHash = genesis.Hash
+	} else if err != nil {
+		return nil, err
+	}
 
-	err = db.Update(func(txn *badger.Txn) error {
-		if _, err := txn.Get([]byte(lastHashKey)); err == badger.ErrKeyNotFound {
-			log.Println("Không tìm thấy blockchain. Đang tạo mới...")
+	return loadAndReindex(store, lastHash)
+}
 
-			coinbaseTx := NewCoinbaseTransaction(address, 100)
-			genesis := NewGenesisBlock(coinbaseTx)
-			log.Println("Block Genesis đã được tạo.")
+func InitBlockchainWithGenesis(store ChainStore, genesis *Block, emission EmissionSchedule) (*Blockchain, error) {
+	if _, err := store.LastHash(); err == nil {
+		return nil, errors.New("blockchain đã tồn tại")
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
 
-			err = txn.Set(genesis.Hash, genesis.Serialize())
-			Handle(err)
-			err = txn.Set([]byte(lastHashKey), genesis.Hash)
-			Handle(err)
-			lastHash = genesis.Hash
-		} else {
+	if genesis.Height != 0 || len(genesis.PrevBlockHash) != 0 {
+		return nil, fmt.Errorf("%w: block %x không phải block genesis", ErrInvalidBlock, genesis.Hash)
+	}
+	if len(activeParams.GenesisHash) > 0 && !bytes.Equal(genesis.Hash, activeParams.GenesisHash) {
+		return nil, fmt.Errorf("%w: block genesis %x khác genesis của mạng %s", ErrInvalidBlock, genesis.Hash, activeParams.Name)
+	}
+	if err := checkBlockStructure(genesis); err != nil {
+		return nil, err
+	}
+	for _, tx := range genesis.Transactions {
+		if !bytes.Equal(tx.ID, tx.ComputeID()) {
+			return nil, fmt.Errorf("%w: TX %x: ID không khớp với nội dung", ErrInvalidTransaction, tx.ID)
+		}
+	}
+	if err := emission.Validate(); err != nil {
+		return nil, fmt.Errorf("lịch phát hành không hợp lệ: %w", err)
+	}
 
-			item, err := txn.Get([]byte(lastHashKey))
-			Handle(err)
-			err = item.Value(func(val []byte) error {
-				lastHash = val
-				return nil
-			})
-			Handle(err)
+	if err := writeGenesis(store, genesis, emission); err != nil {
+		return nil, err
+	}
+	return loadAndReindex(store, genesis.Hash)
+}
+
+func writeGenesis(store ChainStore, genesis *Block, emission EmissionSchedule) error {
+	emissionData, err := emission.Serialize()
+	if err != nil {
+		return err
+	}
+	return store.Update(func(w ChainWriter) error {
+		if err := w.PutBlock(genesis); err != nil {
+			return err
 		}
-		return nil
+		if err := w.SetMeta(emissionKey, emissionData); err != nil {
+			return err
+		}
+		if err := w.SetMeta(networkKey, []byte(activeParams.Name)); err != nil {
+			return err
+		}
+		return w.SetLastHash(genesis.Hash)
 	})
-	Handle(err)
+}
 
-	blockchain := &Blockchain{LastHash: lastHash, Database: db}
+func loadAndReindex(store ChainStore, lastHash []byte) (*Blockchain, error) {
+	blockchain, err := loadBlockchain(store, lastHash)
+	if err != nil {
+		return nil, err
+	}
 
 	utxoSet := UTXOSet{Blockchain: blockchain}
-	utxoSet.Reindex()
+	if err := utxoSet.Reindex(); err != nil {
+		return nil, err
+	}
 
-	return blockchain
+	return blockchain, nil
 }
 
-func ContinueBlockchain() *Blockchain {
-
-	opts := badger.DefaultOptions(dbPath)
-	opts.WithValueLogFileSize(1024 * 1024)
-	opts.WithLogger(nil)
-	db, err := badger.Open(opts)
-	Handle(err)
-	var lastHash []byte
-	err = db.View(func(txn *badger.Txn) error {
-		item, err := txn.Get([]byte(lastHashKey))
-		if err == badger.ErrKeyNotFound {
-			log.Panic("Blockchain chưa được khởi tạo. Hãy chạy 'gochain init' trước.")
+func ContinueBlockchain(store ChainStore) (*Blockchain, error) {
+	blockchain, err := openBlockchain(store)
+	if err != nil {
+		return nil, err
+	}
+	current, err := blockchain.hasCurrentUTXOVersion()
+	if err != nil {
+		return nil, err
+	}
+	if !current {
+		log.Println("UTXO Set dùng định dạng cũ. Đang re-index...")
+		utxoSet := UTXOSet{Blockchain: blockchain}
+		if err := utxoSet.Reindex(); err != nil {
+			return nil, err
 		}
-		Handle(err)
-		err = item.Value(func(val []byte) error {
-			lastHash = val
-			return nil
-		})
-		return err
-	})
-	Handle(err)
+	}
+	if err := blockchain.checkConsistency(); err != nil {
+		return nil, err
+	}
+
+	return blockchain, nil
+}
+
+func ContinueBlockchainReadOnly(store ChainStore) (*Blockchain, error) {
+	return openBlockchain(store)
+}
+
+func openBlockchain(store ChainStore) (*Blockchain, error) {
+	lastHash, err := store.LastHash()
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNoBlockchain
+	}
+	if err != nil {
+		return nil, err
+	}
+	return loadBlockchain(store, lastHash)
+}
 
-	blockchain := &Blockchain{LastHash: lastHash, Database: db}
+func loadBlockchain(store ChainStore, lastHash []byte) (*Blockchain, error) {
+	blockchain := &Blockchain{LastHash: lastHash, Store: store}
+	if err := blockchain.checkNetwork(); err != nil {
+		return nil, err
+	}
+	tip, err := blockchain.GetBlock(lastHash)
+	if err != nil {
+		return nil, err
+	}
+	blockchain.bestHeight = tip.Height
 
-	return blockchain
+	if blockchain.Emission, err = blockchain.loadEmission(); err != nil {
+		return nil, err
+	}
+	if blockchain.Indexed, err = blockchain.loadIndexFlag(); err != nil {
+		return nil, err
+	}
+	return blockchain, nil
 }
 
-func (bc *Blockchain) AddBlock(transactions []*Transaction) {
+func (bc *Blockchain) AddBlock(transactions []*Transaction, state *StateBatch) (*Block, error) {
 
 	prevBlockHash := bc.LastHash
-	newBlock := NewBlock(prevBlockHash, transactions)
-
-	err := bc.Database.Update(func(txn *badger.Txn) error {
-		err := txn.Set(newBlock.Hash, newBlock.Serialize())
-		Handle(err)
-		err = txn.Set([]byte(lastHashKey), newBlock.Hash)
-		Handle(err)
-		bc.LastHash = newBlock.Hash
+	newBlock := NewBlock(prevBlockHash, transactions, bc.GetBestHeight()+1)
+
+	if err := bc.connectBlock(newBlock, state); err != nil {
+		return nil, err
+	}
+	return newBlock, nil
+}
+
+func (bc *Blockchain) HasBlock(hash []byte) bool {
+	_, err := bc.GetBlock(hash)
+	return err == nil
+}
+
+func (bc *Blockchain) CheckBlockHeader(block *Block) error {
+	if !bytes.Equal(block.PrevBlockHash, bc.LastHash) {
+		return fmt.Errorf("%w: block %x nối vào %x, tip hiện tại là %x", ErrBlockNotOnTip, block.Hash, block.PrevBlockHash, bc.LastHash)
+	}
+	if height := bc.GetBestHeight() + 1; block.Height != height {
+		return fmt.Errorf("%w: block %x có chiều cao %d, cần %d", ErrInvalidBlock, block.Hash, block.Height, height)
+	}
+	if err := bc.checkBlockTime(block); err != nil {
 		return err
-	})
-	Handle(err)
+	}
+	return checkBlockStructure(block)
+}
+
+func (bc *Blockchain) checkBlockTime(block *Block) error {
+	if limit := time.Now().Add(maxFutureBlockTime).Unix(); block.Timestamp > limit {
+		return fmt.Errorf("%w: block %x có timestamp %d vượt quá giờ hiện tại hơn %v", ErrInvalidBlock, block.Hash, block.Timestamp, maxFutureBlockTime)
+	}
+	tip, err := bc.GetBlock(bc.LastHash)
+	if err != nil {
+		return err
+	}
+	if block.Timestamp < tip.Timestamp {
+		return fmt.Errorf("%w: block %x có timestamp %d sớm hơn block cha (%d)", ErrInvalidBlock, block.Hash, block.Timestamp, tip.Timestamp)
+	}
+	return nil
+}
+
+func checkBlockStructure(block *Block) error {
+	if !bytes.Equal(block.CalculateHash(), block.Hash) || !NewProofOfWork(block).Validate() {
+		return fmt.Errorf("%w: block %x có proof-of-work không hợp lệ", ErrInvalidBlock, block.Hash)
+	}
+	if len(block.Transactions) == 0 || !block.Transactions[0].IsCoinbase() {
+		return fmt.Errorf("%w: block %x thiếu giao dịch coinbase", ErrInvalidBlock, block.Hash)
+	}
+	for _, tx := range block.Transactions[1:] {
+		if tx.IsCoinbase() {
+			return fmt.Errorf("%w: block %x có nhiều hơn một giao dịch coinbase", ErrInvalidBlock, block.Hash)
+		}
+	}
+	return nil
+}
+
+func (bc *Blockchain) ConnectBlock(block *Block, state *StateBatch) error {
+	if err := bc.CheckBlockHeader(block); err != nil {
+		return err
+	}
+	return bc.connectBlock(block, state)
+}
+
+func (bc *Blockchain) connectBlock(newBlock *Block, state *StateBatch) error {
+	supply, err := bc.GetSupply()
+	if err != nil {
+		return err
+	}
+	supply += bc.issuedIn(newBlock)
 
 	utxoSet := UTXOSet{Blockchain: bc}
-	utxoSet.Update(newBlock)
+	utxoChanges, err := utxoSet.changes(newBlock)
+	if err != nil {
+		return err
+	}
+	undo, err := bc.blockUndo(utxoChanges, state)
+	if err != nil {
+		return err
+	}
+
+	err = bc.Store.Update(func(w ChainWriter) error {
+		if err := w.PutBlock(newBlock); err != nil {
+			return err
+		}
+		if err := w.PutBlockUndo(newBlock.Hash, undo); err != nil {
+			return err
+		}
+		if err := writeUTXOChanges(w, utxoChanges); err != nil {
+			return err
+		}
+		if state != nil {
+			if err := state.write(w); err != nil {
+				return err
+			}
+		}
+		if bc.Indexed {
+			if err := bc.indexBlock(w, newBlock); err != nil {
+				return err
+			}
+			if err := w.SetMeta(indexTipKey, newBlock.Hash); err != nil {
+				return err
+			}
+		}
+		if err := w.SetMeta(supplyKey, encodeInt64(supply)); err != nil {
+			return err
+		}
+		if err := w.SetMeta(utxoTipKey, newBlock.Hash); err != nil {
+			return err
+		}
+		return w.SetLastHash(newBlock.Hash)
+	})
+	if err != nil {
+		return err
+	}
+	bc.LastHash = newBlock.Hash
+	bc.bestHeight = newBlock.Height
+
+	bc.Events.Publish(ChainEvent{Kind: EventBlockConnected, Block: newBlock})
+	return nil
 }
 
 type BlockchainIterator struct {
 	CurrentHash []byte
-	Database    *badger.DB
+	Store       ChainStore
 }
 
 func (bc *Blockchain) Iterator() *BlockchainIterator {
 	return &BlockchainIterator{
 		CurrentHash: bc.LastHash,
-		Database:    bc.Database,
+		Store:       bc.Store,
 	}
 }
 
-func (it *BlockchainIterator) Next() *Block {
-	var block *Block
-
-	err := it.Database.View(func(txn *badger.Txn) error {
-		item, err := txn.Get(it.CurrentHash)
-		Handle(err)
-		err = item.Value(func(val []byte) error {
-			block = DeserializeBlock(val)
-			return nil
-		})
-		return err
-	})
-	Handle(err)
+func (it *BlockchainIterator) Next() (*Block, error) {
+	block, err := it.Store.Block(it.CurrentHash)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w: %x", ErrBlockNotFound, it.CurrentHash)
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	it.CurrentHash = block.PrevBlockHash
-	return block
+	return block, nil
+}
+
+func (bc *Blockchain) BlockHashes() ([][]byte, error) {
+	var hashes [][]byte
+	it := bc.Iterator()
+	for {
+		hash := it.CurrentHash
+		block, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+		if len(block.PrevBlockHash) == 0 {
+			break
+		}
+	}
+	for i, j := 0, len(hashes)-1; i < j; i, j = i+1, j-1 {
+		hashes[i], hashes[j] = hashes[j], hashes[i]
+	}
+	return hashes, nil
 }
 
 func (bc *Blockchain) FindTransaction(ID []byte) (Transaction, error) {
+	tx, _, err := bc.FindTransactionWithBlock(ID)
+	return tx, err
+}
+
+func (bc *Blockchain) FindTransactionWithBlock(ID []byte) (Transaction, *Block, error) {
+	if bc.Indexed {
+		return bc.findIndexedTransaction(ID)
+	}
+
 	it := bc.Iterator()
 	for {
-		block := it.Next()
+		block, err := it.Next()
+		if err != nil {
+			return Transaction{}, nil, err
+		}
 		for _, tx := range block.Transactions {
 			if bytes.Equal(tx.ID, ID) {
-				return *tx, nil
+				return *tx, block, nil
 			}
 		}
 
@@ -157,113 +373,431 @@ func (bc *Blockchain) FindTransaction(ID []byte) (Transaction, error) {
 			break
 		}
 	}
-	return Transaction{}, errors.New("Transaction không tồn tại")
+	return Transaction{}, nil, fmt.Errorf("%w: %x", ErrTxNotFound, ID)
 }
 
-func (bc *Blockchain) FindReferencedTxs(tx *Transaction) map[string]Transaction {
-	prevTxs := make(map[string]Transaction)
+func (bc *Blockchain) CheckLocks(tx *Transaction, ctx VerifyContext) error {
+	utxoSet := UTXOSet{Blockchain: bc}
+	return checkLocks(tx, ctx, func(txID []byte) (int64, int64, error) {
+		entry, err := utxoSet.GetEntry(txID)
+		if err != nil {
+			return 0, 0, err
+		}
+		if entry == nil {
+			return 0, 0, fmt.Errorf("%w: output chưa tiêu của %x", ErrTxNotFound, txID)
+		}
+		return entry.Height, entry.Time, nil
+	})
+}
+
+func checkLocks(tx *Transaction, ctx VerifyContext, origin func(txID []byte) (int64, int64, error)) error {
+	if !tx.IsFinal(ctx) {
+		return fmt.Errorf("giao dịch bị khóa đến %d", tx.LockTime)
+	}
+	if tx.IsCoinbase() {
+		return nil
+	}
+
 	for _, vin := range tx.Vin {
-		prevTx, err := bc.FindTransaction(vin.TxID)
-		Handle(err)
-		prevTxs[string(prevTx.ID)] = prevTx
+		// Khóa tương đối bằng 0 (ví mặc định dùng Sequence 0) luôn thỏa, không cần tra output được tiêu.
+		if vin.Sequence&SequenceLockTimeDisableFlag != 0 || vin.Sequence&SequenceLockTimeMask == 0 {
+			continue
+		}
+
+		prevHeight, prevTime := ctx.Height, ctx.Time
+		if _, ok := ctx.Pending[string(vin.TxID)]; !ok {
+			var err error
+			if prevHeight, prevTime, err = origin(vin.TxID); err != nil {
+				return err
+			}
+		}
+
+		relative := int64(vin.Sequence & SequenceLockTimeMask)
+		if vin.Sequence&SequenceLockTimeTypeFlag != 0 {
+			unlockTime := prevTime + relative<<SequenceLockTimeGranularity
+			if ctx.Time < unlockTime {
+				return fmt.Errorf("input %x:%d bị khóa tương đối đến thời điểm %d", vin.TxID, vin.VoutIndex, unlockTime)
+			}
+		} else {
+			unlockHeight := prevHeight + relative
+			if ctx.Height < unlockHeight {
+				return fmt.Errorf("input %x:%d bị khóa tương đối đến block %d", vin.TxID, vin.VoutIndex, unlockHeight)
+			}
+		}
 	}
-	return prevTxs
+	return nil
 }
 
-func (bc *Blockchain) Close() {
-	bc.Database.Close()
+func (bc *Blockchain) CheckInputs(tx *Transaction, ctx VerifyContext, spent map[string]bool) (int64, error) {
+	utxoSet := UTXOSet{Blockchain: bc}
+	return bc.checkInputs(tx, ctx, spent, func(txID []byte) (*UTXOEntry, error) {
+		entry, err := utxoSet.GetEntry(txID)
+		if entry == nil && err == nil {
+			entry = pendingEntry(ctx.Pending[string(txID)], ctx.Height)
+		}
+		return entry, err
+	})
 }
 
-func ContinueBlockchainReadOnly() *Blockchain {
-	opts := badger.DefaultOptions(dbPath)
-	opts.WithValueLogFileSize(1024 * 1024)
-	opts.WithLogger(nil)
+func (bc *Blockchain) checkInputs(tx *Transaction, ctx VerifyContext, spent map[string]bool, lookup func(txID []byte) (*UTXOEntry, error)) (int64, error) {
+	if tx.IsCoinbase() {
+		return 0, fmt.Errorf("%w: giao dịch coinbase chỉ được tạo bởi miner", ErrInvalidTransaction)
+	}
+	if len(tx.Vin) == 0 {
+		return 0, fmt.Errorf("%w: giao dịch không có input", ErrInvalidTransaction)
+	}
 
-	opts.ReadOnly = true
-	db, err := badger.Open(opts)
-	Handle(err)
-	var lastHash []byte
-	err = db.View(func(txn *badger.Txn) error {
-		item, err := txn.Get([]byte(lastHashKey))
-		if err == badger.ErrKeyNotFound {
-			return errors.New("Blockchain chưa được khởi tạo. Hãy chạy 'gochain init' trước")
+	var outputTotal int64
+	for _, out := range tx.Vout {
+		if out.Value < 0 {
+			return 0, fmt.Errorf("%w: output có giá trị âm: %d", ErrInvalidTransaction, out.Value)
 		}
+		outputTotal += out.Value
+	}
+
+	seen := make(map[string]bool)
+	var inputTotal int64
+	for _, vin := range tx.Vin {
+		outpoint := OutpointKey(vin.TxID, vin.VoutIndex)
+		if seen[outpoint] || spent[outpoint] {
+			return 0, fmt.Errorf("%w: input %s bị tiêu hai lần", ErrInvalidTransaction, outpoint)
+		}
+		seen[outpoint] = true
+
+		entry, err := lookup(vin.TxID)
 		if err != nil {
-			return err
+			return 0, err
 		}
-		err = item.Value(func(val []byte) error {
-			lastHash = val
-			return nil
-		})
-		return err
-	})
-	Handle(err)
+		if entry == nil {
+			return 0, fmt.Errorf("%w: input %s không tồn tại hoặc đã được tiêu", ErrInvalidTransaction, outpoint)
+		}
+		prevOut, ok := entry.Outputs[vin.VoutIndex]
+		if !ok {
+			return 0, fmt.Errorf("%w: input %s không tồn tại hoặc đã được tiêu", ErrInvalidTransaction, outpoint)
+		}
+		inputTotal += prevOut.Value
+		if entry.Coinbase && !bc.Emission.IsMature(entry.Height, ctx.Height) {
+			return 0, fmt.Errorf("input %s: %w (cần đến block %d)", outpoint, ErrImmatureCoinbase, entry.Height+bc.Emission.CoinbaseMaturity)
+		}
+	}
 
-	blockchain := &Blockchain{LastHash: lastHash, Database: db}
+	if inputTotal < outputTotal {
+		return 0, fmt.Errorf("%w: tổng input (%d) nhỏ hơn tổng output (%d)", ErrInsufficientFunds, inputTotal, outputTotal)
+	}
 
-	return blockchain
+	for outpoint := range seen {
+		if spent != nil {
+			spent[outpoint] = true
+		}
+	}
+	return inputTotal - outputTotal, nil
 }
 
-func (bc *Blockchain) SetContractState(contractAddress []byte, key []byte, value []byte) error {
-	return bc.Database.Update(func(txn *badger.Txn) error {
+func pendingEntry(tx *Transaction, height int64) *UTXOEntry {
+	if tx == nil || tx.IsCoinbase() {
+		return nil
+	}
+	entry := &UTXOEntry{Height: height, Outputs: make(map[int]TxOutput, len(tx.Vout))}
+	for outIdx, out := range tx.Vout {
+		entry.Outputs[outIdx] = out
+	}
+	return entry
+}
 
-		dbKey := append([]byte(contractStatePrefix), contractAddress...)
-		dbKey = append(dbKey, key...)
-		return txn.Set(dbKey, value)
-	})
+func (bc *Blockchain) FindReferencedTxs(tx *Transaction) (map[string]Transaction, error) {
+	prevTxs := make(map[string]Transaction)
+	for _, vin := range tx.Vin {
+		prevTx, err := bc.FindTransaction(vin.TxID)
+		if err != nil {
+			return nil, err
+		}
+		prevTxs[string(prevTx.ID)] = prevTx
+	}
+	return prevTxs, nil
 }
 
-func (bc *Blockchain) GetContractState(contractAddress []byte, key []byte) ([]byte, error) {
-	var value []byte
-	err := bc.Database.View(func(txn *badger.Txn) error {
-		dbKey := append([]byte(contractStatePrefix), contractAddress...)
-		dbKey = append(dbKey, key...)
+func (bc *Blockchain) ReferencedOutputs(tx *Transaction, pending map[string]*Transaction) (map[string]Transaction, error) {
+	utxoSet := UTXOSet{Blockchain: bc}
+	return referencedOutputs(tx, func(txID []byte) (*UTXOEntry, error) {
+		if parent, ok := pending[string(txID)]; ok {
+			return pendingEntry(parent, 0), nil
+		}
+		return utxoSet.GetEntry(txID)
+	})
+}
 
-		item, err := txn.Get(dbKey)
-		if err == badger.ErrKeyNotFound {
-			return nil
+func referencedOutputs(tx *Transaction, lookup func(txID []byte) (*UTXOEntry, error)) (map[string]Transaction, error) {
+	prevTxs := make(map[string]Transaction)
+	for _, vin := range tx.Vin {
+		if _, ok := prevTxs[string(vin.TxID)]; ok {
+			continue
 		}
+		entry, err := lookup(vin.TxID)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if entry == nil {
+			continue
 		}
+		prevTx := Transaction{ID: vin.TxID}
+		for _, idx := range entry.Indexes() {
+			for len(prevTx.Vout) <= idx {
+				prevTx.Vout = append(prevTx.Vout, TxOutput{})
+			}
+			prevTx.Vout[idx] = entry.Outputs[idx]
+		}
+		prevTxs[string(vin.TxID)] = prevTx
+	}
+	return prevTxs, nil
+}
 
-		value, err = item.ValueCopy(nil)
-		return err
-	})
+func (bc *Blockchain) TransactionFee(tx *Transaction) int64 {
+	if tx.IsCoinbase() {
+		return 0
+	}
+
+	var fee int64
+	for _, vin := range tx.Vin {
+		prevTx, err := bc.FindTransaction(vin.TxID)
+		if err != nil || vin.VoutIndex < 0 || vin.VoutIndex >= len(prevTx.Vout) {
+			return 0
+		}
+		fee += prevTx.Vout[vin.VoutIndex].Value
+	}
+	for _, out := range tx.Vout {
+		fee -= out.Value
+	}
+	return fee
+}
 
+func (bc *Blockchain) GetBlock(hash []byte) (*Block, error) {
+	block, err := bc.Store.Block(hash)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w: %x", ErrBlockNotFound, hash)
+	}
 	if err != nil {
 		return nil, err
 	}
-	return value, nil
+	return block, nil
 }
 
-func (bc *Blockchain) SetContractCode(contractAddress []byte, code []byte) error {
-	return bc.Database.Update(func(txn *badger.Txn) error {
-
-		dbKey := append([]byte(contractCodePrefix), contractAddress...)
-		return txn.Set(dbKey, code)
-	})
+func (bc *Blockchain) GetBlockByHeight(height int64) (*Block, error) {
+	bestHeight := bc.GetBestHeight()
+	if height < 0 || height > bestHeight {
+		return nil, fmt.Errorf("%w: độ cao %d", ErrBlockNotFound, height)
+	}
+	blocks, err := bc.ListBlocks(bestHeight-height, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 || blocks[0].Height != height {
+		return nil, fmt.Errorf("%w: độ cao %d", ErrBlockNotFound, height)
+	}
+	return blocks[0], nil
 }
 
-func (bc *Blockchain) GetContractCode(contractAddress []byte) ([]byte, error) {
-	var code []byte
-	err := bc.Database.View(func(txn *badger.Txn) error {
-		dbKey := append([]byte(contractCodePrefix), contractAddress...)
+func (bc *Blockchain) ListBlocks(offset, limit int64) ([]*Block, error) {
+	if offset < 0 || limit < 0 {
+		return nil, errors.New("offset và limit không được âm")
+	}
 
-		item, err := txn.Get(dbKey)
-		if err == badger.ErrKeyNotFound {
-			return fmt.Errorf("không tìm thấy contract: %x", contractAddress)
+	var blocks []*Block
+	hash := bc.LastHash
+	for skipped := int64(0); len(hash) > 0; skipped++ {
+		block, err := bc.GetBlock(hash)
+		if err != nil {
+			return nil, err
 		}
+		if skipped >= offset {
+			blocks = append(blocks, block)
+			if limit > 0 && int64(len(blocks)) >= limit {
+				break
+			}
+		}
+		hash = block.PrevBlockHash
+	}
+	return blocks, nil
+}
+
+func (bc *Blockchain) GetBestHeight() int64 {
+	return bc.bestHeight
+}
+
+func (bc *Blockchain) NextVerifyContext() VerifyContext {
+	return VerifyContext{
+		Height: bc.GetBestHeight() + 1,
+		Time:   time.Now().Unix(),
+	}
+}
+
+func (bc *Blockchain) GetSupply() (int64, error) {
+	supply, err := bc.readInt64(supplyKey)
+	if errors.Is(err, ErrNotFound) {
+		return bc.computeSupply()
+	}
+	return supply, err
+}
+
+func (bc *Blockchain) computeSupply() (int64, error) {
+	var supply int64
+	it := bc.Iterator()
+	for {
+		block, err := it.Next()
 		if err != nil {
+			return 0, err
+		}
+		supply += bc.issuedIn(block)
+
+		if len(block.PrevBlockHash) == 0 {
+			break
+		}
+	}
+	return supply, nil
+}
+
+func (bc *Blockchain) issuedIn(block *Block) int64 {
+	issued := block.CoinbaseValue()
+	if subsidy := bc.Emission.BlockReward(block.Height); issued > subsidy {
+		return subsidy
+	}
+	return issued
+}
+
+func (bc *Blockchain) loadEmission() (EmissionSchedule, error) {
+	value, err := bc.Store.Meta(emissionKey)
+	if errors.Is(err, ErrNotFound) {
+		return DefaultEmissionSchedule, nil
+	}
+	if err != nil {
+		return EmissionSchedule{}, err
+	}
+	return DeserializeEmissionSchedule(value)
+}
+
+func (bc *Blockchain) checkNetwork() error {
+	network := MainNetParams.Name
+	value, err := bc.Store.Meta(networkKey)
+	if err == nil {
+		network = string(value)
+	} else if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if network != activeParams.Name {
+		return fmt.Errorf("CSDL thuộc mạng %s nhưng node đang chạy mạng %s (dùng --network %s)", network, activeParams.Name, network)
+	}
+	return nil
+}
+
+func (bc *Blockchain) hasCurrentUTXOVersion() (bool, error) {
+	value, err := bc.Store.Meta(utxoVersionKey)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(value) > 0 && value[0] == utxoVersion, nil
+}
+
+func (bc *Blockchain) checkConsistency() error {
+	tip, err := bc.GetBlock(bc.LastHash)
+	if err != nil {
+		return err
+	}
+
+	utxoTip, err := bc.Store.Meta(utxoTipKey)
+	if errors.Is(err, ErrNotFound) {
+		utxoTip, err = bc.legacyUTXOTip(tip)
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(utxoTip, bc.LastHash) {
+		log.Printf("UTXO Set không khớp với tip %x (node có thể đã dừng đột ngột). Đang re-index...", bc.LastHash)
+		utxoSet := UTXOSet{Blockchain: bc}
+		if err := utxoSet.Reindex(); err != nil {
 			return err
 		}
+	}
 
-		code, err = item.ValueCopy(nil)
+	if !bc.Indexed {
+		return nil
+	}
+	indexTip, err := bc.Store.Meta(indexTipKey)
+	if errors.Is(err, ErrNotFound) {
+		indexTip, err = bc.legacyIndexTip(tip)
+	}
+	if err != nil {
 		return err
+	}
+	if !bytes.Equal(indexTip, bc.LastHash) {
+		log.Printf("Chỉ mục giao dịch được ghi ở block %x nhưng tip là %x (chỉ mục chỉ ghi tiến). Đang xây dựng lại...", indexTip, bc.LastHash)
+		return bc.RebuildIndex()
+	}
+	return nil
+}
+
+func (bc *Blockchain) legacyIndexTip(tip *Block) ([]byte, error) {
+	for _, tx := range tip.Transactions {
+		if _, _, err := bc.Store.TxLocation(tx.ID); errors.Is(err, ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	err := bc.Store.Update(func(w ChainWriter) error {
+		return w.SetMeta(indexTipKey, tip.Hash)
 	})
+	return tip.Hash, err
+}
 
+func (bc *Blockchain) legacyUTXOTip(tip *Block) ([]byte, error) {
+	_, err := bc.Store.UTXO(tip.Transactions[0].ID)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	return code, nil
+	err = bc.Store.Update(func(w ChainWriter) error {
+		return w.SetMeta(utxoTipKey, tip.Hash)
+	})
+	return tip.Hash, err
+}
+
+func (bc *Blockchain) readInt64(key string) (int64, error) {
+	value, err := bc.Store.Meta(key)
+	if err != nil {
+		return 0, err
+	}
+	if len(value) != 8 {
+		return 0, fmt.Errorf("%w: giá trị của key %s", ErrCorruptData, key)
+	}
+	return int64(binary.BigEndian.Uint64(value)), nil
+}
+
+func encodeInt64(value int64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(value))
+	return buf
+}
+
+func (bc *Blockchain) Close() error {
+	return bc.Store.Close()
+}
+
+func (bc *Blockchain) GetContractState(contractAddress []byte, key []byte) ([]byte, error) {
+	value, err := bc.Store.ContractState(contractAddress, key)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return value, err
+}
+
+func (bc *Blockchain) GetContractCode(contractAddress []byte) ([]byte, error) {
+	code, err := bc.Store.ContractCode(contractAddress)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w: %x", ErrContractNotFound, contractAddress)
+	}
+	return code, err
 }
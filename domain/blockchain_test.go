@@ -0,0 +1,132 @@
+package domain_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+	"github.com/khoahotran/gochain-ledger/storage"
+)
+
+var errCrash = errors.New("node dừng giữa chừng")
+
+// crashingStore để mọi lệnh ghi trong Update chạy bình thường rồi thất bại ở bước cập nhật tip,
+// giống node chết ngay trước khi commit.
+type crashingStore struct {
+	domain.ChainStore
+}
+
+type crashingWriter struct {
+	domain.ChainWriter
+}
+
+func (s crashingStore) Update(fn func(w domain.ChainWriter) error) error {
+	return s.ChainStore.Update(func(w domain.ChainWriter) error {
+		return fn(crashingWriter{w})
+	})
+}
+
+func (crashingWriter) SetLastHash(hash []byte) error {
+	return errCrash
+}
+
+func TestAddBlockCommitsAtomically(t *testing.T) {
+	store := storage.NewMemory()
+	bc, alice := testutil.NewChain(t, store)
+	bob := testutil.NewWallet(t)
+	if err := bc.EnableIndex(); err != nil {
+		t.Fatal(err)
+	}
+	genesis, err := bc.GetBlockByHeight(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tipBefore := bc.LastHash
+	supplyBefore, err := bc.GetSupply()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := testutil.Spend(t, bc, alice, genesis.Transactions[0].ID, 0, testutil.PayTo(t, bob.GetAddress(), 100))
+	coinbase, err := domain.NewCoinbaseTransaction(bob.GetAddress(), testutil.Emission.BlockReward(1), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc.Store = crashingStore{store}
+	if _, err := bc.AddBlock([]*domain.Transaction{coinbase, tx}, nil); !errors.Is(err, errCrash) {
+		t.Fatalf("AddBlock trả về %v, muốn lỗi khi commit", err)
+	}
+	bc.Store = store
+
+	if !bytes.Equal(bc.LastHash, tipBefore) || bc.GetBestHeight() != 0 {
+		t.Fatal("tip trong bộ nhớ không được đổi khi commit thất bại")
+	}
+	if stored, err := store.LastHash(); err != nil || !bytes.Equal(stored, tipBefore) {
+		t.Fatalf("tip trong CSDL bị đổi: %x %v", stored, err)
+	}
+	if entry, err := store.UTXO(genesis.Transactions[0].ID); err != nil || entry == nil {
+		t.Fatalf("UTXO đã tiêu trong block thất bại phải còn nguyên: %v", err)
+	}
+	if _, err := store.UTXO(tx.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("UTXO của block thất bại không được ghi: %v", err)
+	}
+	if supply, err := bc.GetSupply(); err != nil || supply != supplyBefore {
+		t.Fatalf("supply %d sau commit thất bại, muốn %d (%v)", supply, supplyBefore, err)
+	}
+	if _, _, err := bc.FindTransactionWithBlock(tx.ID); !errors.Is(err, domain.ErrTxNotFound) {
+		t.Fatalf("chỉ mục không được chứa giao dịch của block thất bại: %v", err)
+	}
+
+	report, err := bc.Verify(domain.VerifyOptions{Full: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() {
+		t.Fatalf("CSDL không nhất quán sau commit thất bại: %v %v", report.Invalid, report.Mismatches)
+	}
+	if _, err := bc.AddBlock([]*domain.Transaction{coinbase, tx}, nil); err != nil {
+		t.Fatalf("block phải thêm được sau khi thử lại: %v", err)
+	}
+}
+
+func TestContinueBlockchainReindexesStaleUTXOSet(t *testing.T) {
+	store := storage.NewMemory()
+	bc, _ := testutil.NewChain(t, store)
+	bob := testutil.NewWallet(t)
+
+	// Giả lập CSDL do phiên bản cũ để lại: block và tip đã ghi nhưng UTXO Set chưa được cập nhật.
+	coinbase, err := domain.NewCoinbaseTransaction(bob.GetAddress(), testutil.Emission.BlockReward(1), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := domain.NewBlock(bc.LastHash, []*domain.Transaction{coinbase}, 1)
+	err = store.Update(func(w domain.ChainWriter) error {
+		if err := w.PutBlock(block); err != nil {
+			return err
+		}
+		return w.SetLastHash(block.Hash)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bc, err = domain.ContinueBlockchain(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry, err := store.UTXO(coinbase.ID); err != nil || entry == nil {
+		t.Fatalf("UTXO Set phải được re-index khi khởi động: %v", err)
+	}
+	if supply, err := bc.GetSupply(); err != nil || supply != testutil.Emission.SupplyAt(1) {
+		t.Fatalf("supply %d (%v), muốn %d", supply, err, testutil.Emission.SupplyAt(1))
+	}
+	report, err := bc.Verify(domain.VerifyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() {
+		t.Fatalf("verify sau khi re-index: %v %v", report.Invalid, report.Mismatches)
+	}
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	BootstrapVersion   byte = 1
+	maxBootstrapRecord      = 64 << 20
+)
+
+var (
+	bootstrapMagic      = []byte{0x00, 'G', 'C', 'S'}
+	ErrInvalidBootstrap = errors.New("file bootstrap không hợp lệ")
+)
+
+type BootstrapHeader struct {
+	Network  string
+	Magic    uint32
+	From     int64
+	To       int64
+	Emission EmissionSchedule
+}
+
+func NewBootstrapHeader(from, to int64, emission EmissionSchedule) BootstrapHeader {
+	return BootstrapHeader{
+		Network:  activeParams.Name,
+		Magic:    activeParams.Magic,
+		From:     from,
+		To:       to,
+		Emission: emission,
+	}
+}
+
+func (h BootstrapHeader) Check() error {
+	if h.Network != activeParams.Name || h.Magic != activeParams.Magic {
+		return fmt.Errorf("file bootstrap thuộc mạng %s nhưng node đang chạy mạng %s (dùng --network %s)", h.Network, activeParams.Name, h.Network)
+	}
+	if h.From < 0 || h.To < h.From {
+		return fmt.Errorf("%w: khoảng chiều cao %d-%d", ErrInvalidBootstrap, h.From, h.To)
+	}
+	return h.Emission.Validate()
+}
+
+func WriteBootstrapHeader(w io.Writer, h BootstrapHeader) error {
+	e := &encoder{}
+	e.buf.Write(bootstrapMagic)
+	e.writeUint8(BootstrapVersion)
+	e.writeBytes([]byte(h.Network))
+	e.writeUint32(h.Magic)
+	e.writeInt64(h.From)
+	e.writeInt64(h.To)
+	e.writeInt64(h.Emission.InitialReward)
+	e.writeInt64(h.Emission.HalvingInterval)
+	e.writeInt64(h.Emission.MaxSupply)
+	e.writeInt64(h.Emission.CoinbaseMaturity)
+	return writeBootstrapRecord(w, e.Bytes())
+}
+
+func ReadBootstrapHeader(r io.Reader) (BootstrapHeader, error) {
+	data, err := readBootstrapRecord(r)
+	if errors.Is(err, io.EOF) {
+		return BootstrapHeader{}, fmt.Errorf("%w: file rỗng", ErrInvalidBootstrap)
+	}
+	if err != nil {
+		return BootstrapHeader{}, err
+	}
+	if !bytes.HasPrefix(data, bootstrapMagic) {
+		return BootstrapHeader{}, fmt.Errorf("%w: sai magic", ErrInvalidBootstrap)
+	}
+
+	h, err := decodeBootstrapHeader(newDecoder(data[len(bootstrapMagic):]))
+	if err != nil {
+		return BootstrapHeader{}, fmt.Errorf("%w: header: %v", ErrInvalidBootstrap, err)
+	}
+	return h, nil
+}
+
+func decodeBootstrapHeader(d *decoder) (BootstrapHeader, error) {
+	var h BootstrapHeader
+	version, err := d.readUint8()
+	if err != nil {
+		return h, err
+	}
+	if version != BootstrapVersion {
+		return h, fmt.Errorf("phiên bản file bootstrap không hỗ trợ: %d", version)
+	}
+	network, err := d.readBytes()
+	if err != nil {
+		return h, err
+	}
+	h.Network = string(network)
+	if h.Magic, err = d.readUint32(); err != nil {
+		return h, err
+	}
+	for _, field := range []*int64{&h.From, &h.To, &h.Emission.InitialReward, &h.Emission.HalvingInterval, &h.Emission.MaxSupply, &h.Emission.CoinbaseMaturity} {
+		if *field, err = d.readInt64(); err != nil {
+			return h, err
+		}
+	}
+	return h, nil
+}
+
+func WriteBootstrapBlock(w io.Writer, block *Block) error {
+	return writeBootstrapRecord(w, block.Serialize())
+}
+
+func ReadBootstrapBlock(r io.Reader) (*Block, error) {
+	data, err := readBootstrapRecord(r)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, blockMagic) {
+		return nil, fmt.Errorf("%w: block không dùng mã hóa chuẩn", ErrInvalidBootstrap)
+	}
+	block, err := decodeBlock(newDecoder(data[len(blockMagic):]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: block: %v", ErrInvalidBootstrap, err)
+	}
+	if !bytes.Equal(block.Serialize(), data) {
+		return nil, fmt.Errorf("%w: block %x không dùng mã hóa chuẩn", ErrInvalidBootstrap, block.Hash)
+	}
+	return block, nil
+}
+
+func writeBootstrapRecord(w io.Writer, data []byte) error {
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(len(data)))
+	if _, err := w.Write(size[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+func readBootstrapRecord(r io.Reader) ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: file bị cắt cụt", ErrInvalidBootstrap)
+		}
+		return nil, err
+	}
+	n := binary.LittleEndian.Uint32(size[:])
+	if n > maxBootstrapRecord {
+		return nil, fmt.Errorf("%w: bản ghi dài %d byte", ErrInvalidBootstrap, n)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: file bị cắt cụt", ErrInvalidBootstrap)
+		}
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+)
+
+type EmissionSchedule struct {
+	InitialReward    int64
+	HalvingInterval  int64
+	MaxSupply        int64
+	CoinbaseMaturity int64
+}
+
+var DefaultEmissionSchedule = EmissionSchedule{
+	InitialReward:    100,
+	HalvingInterval:  100000,
+	MaxSupply:        20000000,
+	CoinbaseMaturity: 10,
+}
+
+func (e EmissionSchedule) Validate() error {
+	if e.InitialReward <= 0 {
+		return errors.New("phần thưởng ban đầu phải lớn hơn 0")
+	}
+	if e.HalvingInterval <= 0 {
+		return errors.New("chu kỳ halving phải lớn hơn 0")
+	}
+	if e.MaxSupply < e.InitialReward {
+		return errors.New("tổng cung tối đa phải lớn hơn hoặc bằng phần thưởng ban đầu")
+	}
+	if e.CoinbaseMaturity < 0 {
+		return errors.New("coinbase maturity không được âm")
+	}
+	return nil
+}
+
+func (e EmissionSchedule) subsidy(height int64) int64 {
+	halvings := height / e.HalvingInterval
+	if halvings >= 63 {
+		return 0
+	}
+	return e.InitialReward >> uint(halvings)
+}
+
+func (e EmissionSchedule) SupplyAt(height int64) int64 {
+	if height < 0 {
+		return 0
+	}
+
+	var supply int64
+	for start := int64(0); start <= height; start += e.HalvingInterval {
+		reward := e.subsidy(start)
+		if reward == 0 {
+			break
+		}
+		end := start + e.HalvingInterval - 1
+		if end > height {
+			end = height
+		}
+		supply += reward * (end - start + 1)
+		if supply >= e.MaxSupply {
+			return e.MaxSupply
+		}
+	}
+	return supply
+}
+
+func (e EmissionSchedule) BlockReward(height int64) int64 {
+	return e.SupplyAt(height) - e.SupplyAt(height-1)
+}
+
+func (e EmissionSchedule) IsMature(coinbaseHeight int64, spendHeight int64) bool {
+	return spendHeight-coinbaseHeight >= e.CoinbaseMaturity
+}
+
+func (e EmissionSchedule) Serialize() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(e); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func DeserializeEmissionSchedule(data []byte) (EmissionSchedule, error) {
+	var e EmissionSchedule
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&e)
+	return e, err
+}
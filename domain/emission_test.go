@@ -0,0 +1,101 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+	"github.com/khoahotran/gochain-ledger/storage"
+)
+
+func TestEmissionHalvingAndCap(t *testing.T) {
+	e := domain.EmissionSchedule{InitialReward: 100, HalvingInterval: 10, MaxSupply: 1630}
+	rewards := map[int64]int64{0: 100, 9: 100, 10: 50, 19: 50, 20: 25, 24: 25, 25: 5, 26: 0, 1000: 0}
+	for height, want := range rewards {
+		if got := e.BlockReward(height); got != want {
+			t.Errorf("BlockReward(%d) = %d, muốn %d", height, got, want)
+		}
+	}
+
+	var total int64
+	for height := int64(0); height < 100; height++ {
+		total += e.BlockReward(height)
+		if supply := e.SupplyAt(height); supply != total {
+			t.Fatalf("SupplyAt(%d) = %d, tổng phần thưởng %d", height, supply, total)
+		}
+	}
+	if total != e.MaxSupply {
+		t.Fatalf("tổng phát hành %d, muốn đúng bằng max supply %d", total, e.MaxSupply)
+	}
+	if e.SupplyAt(-1) != 0 {
+		t.Fatal("SupplyAt(-1) phải bằng 0")
+	}
+}
+
+func TestEmissionValidate(t *testing.T) {
+	valid := domain.EmissionSchedule{InitialReward: 100, HalvingInterval: 10, MaxSupply: 1000, CoinbaseMaturity: 0}
+	if err := valid.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []domain.EmissionSchedule{
+		{InitialReward: 0, HalvingInterval: 10, MaxSupply: 1000},
+		{InitialReward: 100, HalvingInterval: 0, MaxSupply: 1000},
+		{InitialReward: 100, HalvingInterval: 10, MaxSupply: 99},
+		{InitialReward: 100, HalvingInterval: 10, MaxSupply: 1000, CoinbaseMaturity: -1},
+	} {
+		if err := e.Validate(); err == nil {
+			t.Errorf("%+v phải không hợp lệ", e)
+		}
+	}
+}
+
+func TestCoinbaseMaturity(t *testing.T) {
+	domain.SelectParams(&domain.RegTestParams)
+	w := testutil.NewWallet(t)
+	emission := testutil.Emission
+	emission.CoinbaseMaturity = 3
+	bc, err := domain.InitBlockchain(storage.NewMemory(), w.GetAddress(), emission)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesis, err := bc.GetBlockByHeight(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := testutil.Spend(t, bc, w, genesis.Transactions[0].ID, 0, testutil.PayTo(t, w.GetAddress(), 100))
+
+	if _, err := bc.CheckInputs(tx, domain.VerifyContext{Height: 2}, nil); !errors.Is(err, domain.ErrImmatureCoinbase) {
+		t.Fatalf("tiêu coinbase genesis ở block 2 phải trả ErrImmatureCoinbase, nhận %v", err)
+	}
+	if _, err := bc.CheckInputs(tx, domain.VerifyContext{Height: 3}, nil); err != nil {
+		t.Fatalf("coinbase genesis phải tiêu được ở block 3: %v", err)
+	}
+}
+
+func TestSupplyFollowsSchedule(t *testing.T) {
+	bc, w := testutil.NewChain(t, nil)
+	for i := 0; i < 3; i++ {
+		testutil.MineBlock(t, bc, w)
+	}
+	supply, err := bc.GetSupply()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := testutil.Emission.SupplyAt(bc.GetBestHeight()); supply != want {
+		t.Fatalf("GetSupply = %d, muốn %d", supply, want)
+	}
+
+	// Coinbase trả dư (phí giao dịch) không làm tăng lượng phát hành quá subsidy.
+	height := bc.GetBestHeight() + 1
+	coinbase, err := domain.NewCoinbaseTransaction(w.GetAddress(), testutil.Emission.BlockReward(height)+25, height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bc.AddBlock([]*domain.Transaction{coinbase}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if supply, err = bc.GetSupply(); err != nil || supply != testutil.Emission.SupplyAt(height) {
+		t.Fatalf("GetSupply = %d (%v), muốn %d", supply, err, testutil.Emission.SupplyAt(height))
+	}
+}
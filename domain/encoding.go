@@ -0,0 +1,563 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	TxEncodingVersion    byte = 1
+	BlockEncodingVersion byte = 1
+
+	BlockHeaderVersion uint32 = 1
+	BlockHeaderSize           = 96
+	blockHeaderNonceAt        = 80
+)
+
+var blockMagic = []byte{0x00, 'G', 'C', 'B'}
+
+type encoder struct {
+	buf bytes.Buffer
+}
+
+func (e *encoder) writeUint8(v byte) {
+	e.buf.WriteByte(v)
+}
+
+func (e *encoder) writeUint32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) writeInt64(v int64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(v))
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) writeVarInt(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	e.buf.Write(b[:n])
+}
+
+func (e *encoder) writeBytes(data []byte) {
+	e.writeVarInt(uint64(len(data)))
+	e.buf.Write(data)
+}
+
+func (e *encoder) Bytes() []byte {
+	return e.buf.Bytes()
+}
+
+type decoder struct {
+	r *bytes.Reader
+}
+
+func newDecoder(data []byte) *decoder {
+	return &decoder{r: bytes.NewReader(data)}
+}
+
+func (d *decoder) readUint8() (byte, error) {
+	return d.r.ReadByte()
+}
+
+func (d *decoder) readUint32() (uint32, error) {
+	var b [4]byte
+	if _, err := io.ReadFull(d.r, b[:]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b[:]), nil
+}
+
+func (d *decoder) readInt64() (int64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(d.r, b[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.LittleEndian.Uint64(b[:])), nil
+}
+
+func (d *decoder) readVarInt() (uint64, error) {
+	return binary.ReadUvarint(d.r)
+}
+
+func (d *decoder) readCount() (int, error) {
+	n, err := d.readVarInt()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(d.r.Len()) {
+		return 0, fmt.Errorf("số phần tử %d vượt quá dữ liệu còn lại", n)
+	}
+	return int(n), nil
+}
+
+func (d *decoder) readBytes() ([]byte, error) {
+	n, err := d.readCount()
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(d.r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (tx *Transaction) encode(e *encoder, withUnlocking bool) {
+	e.writeUint8(TxEncodingVersion)
+	e.writeUint32(uint32(tx.Type))
+
+	coinbase := tx.IsCoinbase()
+	e.writeVarInt(uint64(len(tx.Vin)))
+	for _, vin := range tx.Vin {
+		e.writeBytes(vin.TxID)
+		e.writeUint32(uint32(int32(vin.VoutIndex)))
+		if withUnlocking {
+			e.writeBytes(vin.Signature)
+			e.writeBytes(vin.PublicKey)
+			e.writeVarInt(uint64(len(vin.Witness)))
+			for _, item := range vin.Witness {
+				e.writeBytes(item)
+			}
+		} else {
+			e.writeBytes(nil)
+			if coinbase {
+				e.writeBytes(vin.PublicKey)
+			} else {
+				e.writeBytes(nil)
+			}
+			e.writeVarInt(0)
+		}
+		e.writeUint32(vin.Sequence)
+	}
+
+	e.writeVarInt(uint64(len(tx.Vout)))
+	for _, out := range tx.Vout {
+		out.encode(e)
+	}
+
+	e.writeBytes(tx.Payload)
+	e.writeInt64(tx.LockTime)
+}
+
+func (out *TxOutput) encode(e *encoder) {
+	e.writeInt64(out.Value)
+	e.writeBytes(out.PubKeyHash)
+	e.writeBytes(out.Script)
+}
+
+func (out *TxOutput) decode(d *decoder) error {
+	var err error
+	if out.Value, err = d.readInt64(); err != nil {
+		return err
+	}
+	if out.PubKeyHash, err = d.readBytes(); err != nil {
+		return err
+	}
+	out.Script, err = d.readBytes()
+	return err
+}
+
+func (tx *Transaction) Serialize() []byte {
+	e := &encoder{}
+	tx.encode(e, true)
+	return e.Bytes()
+}
+
+func (tx *Transaction) ComputeID() []byte {
+	e := &encoder{}
+	tx.encode(e, false)
+	hash := sha256.Sum256(e.Bytes())
+	return hash[:]
+}
+
+func (tx *Transaction) RawHex() string {
+	return hex.EncodeToString(tx.Serialize())
+}
+
+func decodeTransaction(d *decoder) (*Transaction, error) {
+	version, err := d.readUint8()
+	if err != nil {
+		return nil, err
+	}
+	if version != TxEncodingVersion {
+		return nil, fmt.Errorf("phiên bản mã hóa giao dịch không hỗ trợ: %d", version)
+	}
+
+	tx := &Transaction{}
+	txType, err := d.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	tx.Type = TxType(txType)
+
+	inCount, err := d.readCount()
+	if err != nil {
+		return nil, err
+	}
+	tx.Vin = make([]TxInput, inCount)
+	for i := range tx.Vin {
+		vin := &tx.Vin[i]
+		if vin.TxID, err = d.readBytes(); err != nil {
+			return nil, err
+		}
+		voutIndex, err := d.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		vin.VoutIndex = int(int32(voutIndex))
+		if vin.Signature, err = d.readBytes(); err != nil {
+			return nil, err
+		}
+		if vin.PublicKey, err = d.readBytes(); err != nil {
+			return nil, err
+		}
+		witnessCount, err := d.readCount()
+		if err != nil {
+			return nil, err
+		}
+		if witnessCount > 0 {
+			vin.Witness = make([][]byte, witnessCount)
+			for j := range vin.Witness {
+				if vin.Witness[j], err = d.readBytes(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if vin.Sequence, err = d.readUint32(); err != nil {
+			return nil, err
+		}
+	}
+
+	outCount, err := d.readCount()
+	if err != nil {
+		return nil, err
+	}
+	tx.Vout = make([]TxOutput, outCount)
+	for i := range tx.Vout {
+		if err := tx.Vout[i].decode(d); err != nil {
+			return nil, err
+		}
+	}
+
+	if tx.Payload, err = d.readBytes(); err != nil {
+		return nil, err
+	}
+	if tx.LockTime, err = d.readInt64(); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func DeserializeTransaction(data []byte) (*Transaction, error) {
+	if len(data) > 0 && data[0] != TxEncodingVersion {
+		var tx Transaction
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&tx); err != nil {
+			return nil, err
+		}
+		return &tx, nil
+	}
+
+	d := newDecoder(data)
+	tx, err := decodeTransaction(d)
+	if err != nil {
+		return nil, err
+	}
+	if d.r.Len() != 0 {
+		return nil, errors.New("dữ liệu thừa sau giao dịch")
+	}
+	tx.ID = tx.ComputeID()
+	return tx, nil
+}
+
+func DecodeRawTransaction(rawHex string) (*Transaction, error) {
+	data, err := hex.DecodeString(rawHex)
+	if err != nil {
+		return nil, fmt.Errorf("raw hex không hợp lệ: %v", err)
+	}
+	if len(data) == 0 || data[0] != TxEncodingVersion {
+		return nil, errors.New("raw hex không phải giao dịch đã mã hóa chuẩn")
+	}
+	return DeserializeTransaction(data)
+}
+
+func (b *Block) Serialize() []byte {
+	e := &encoder{}
+	e.buf.Write(blockMagic)
+	e.writeUint8(BlockEncodingVersion)
+	e.writeInt64(b.Timestamp)
+	e.writeBytes(b.PrevBlockHash)
+	e.writeBytes(b.Hash)
+	e.writeInt64(b.Nonce)
+	e.writeInt64(b.Height)
+
+	e.writeVarInt(uint64(len(b.Transactions)))
+	for _, tx := range b.Transactions {
+		e.writeBytes(tx.ID)
+		e.writeBytes(tx.Serialize())
+	}
+	return e.Bytes()
+}
+
+// Header mã hóa header chuẩn của block: version, prev hash, Merkle root, time, bits, nonce, height.
+// Mọi trường có độ dài cố định và little-endian; hash của genesis (rỗng) được ghi thành 32 byte 0.
+func (b *Block) Header(bits uint32, nonce int64) []byte {
+	var prevHash, merkleRoot [32]byte
+	copy(prevHash[:], b.PrevBlockHash)
+	copy(merkleRoot[:], b.MerkleRoot())
+
+	e := &encoder{}
+	e.writeUint32(BlockHeaderVersion)
+	e.buf.Write(prevHash[:])
+	e.buf.Write(merkleRoot[:])
+	e.writeInt64(b.Timestamp)
+	e.writeUint32(bits)
+	e.writeInt64(nonce)
+	e.writeInt64(b.Height)
+	return e.Bytes()
+}
+
+func DeserializeBlock(data []byte) (*Block, error) {
+	if !bytes.HasPrefix(data, blockMagic) {
+		var block Block
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&block); err != nil {
+			return nil, fmt.Errorf("%w: block: %v", ErrCorruptData, err)
+		}
+		return &block, nil
+	}
+
+	block, err := decodeBlock(newDecoder(data[len(blockMagic):]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: block: %v", ErrCorruptData, err)
+	}
+	return block, nil
+}
+
+func decodeBlock(d *decoder) (*Block, error) {
+	version, err := d.readUint8()
+	if err != nil {
+		return nil, err
+	}
+	if version != BlockEncodingVersion {
+		return nil, fmt.Errorf("phiên bản mã hóa block không hỗ trợ: %d", version)
+	}
+
+	block := &Block{}
+	if block.Timestamp, err = d.readInt64(); err != nil {
+		return nil, err
+	}
+	if block.PrevBlockHash, err = d.readBytes(); err != nil {
+		return nil, err
+	}
+	if block.Hash, err = d.readBytes(); err != nil {
+		return nil, err
+	}
+	if block.Nonce, err = d.readInt64(); err != nil {
+		return nil, err
+	}
+	if block.Height, err = d.readInt64(); err != nil {
+		return nil, err
+	}
+
+	txCount, err := d.readCount()
+	if err != nil {
+		return nil, err
+	}
+	block.Transactions = make([]*Transaction, txCount)
+	for i := range block.Transactions {
+		id, err := d.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		txData, err := d.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		tx, err := decodeTransaction(newDecoder(txData))
+		if err != nil {
+			return nil, err
+		}
+		tx.ID = id
+		block.Transactions[i] = tx
+	}
+
+	if d.r.Len() != 0 {
+		return nil, errors.New("dữ liệu thừa sau block")
+	}
+	return block, nil
+}
+
+func (e *UTXOEntry) Serialize() []byte {
+	enc := &encoder{}
+	enc.writeInt64(e.Height)
+	enc.writeInt64(e.Time)
+	if e.Coinbase {
+		enc.writeUint8(1)
+	} else {
+		enc.writeUint8(0)
+	}
+
+	indexes := e.Indexes()
+	enc.writeVarInt(uint64(len(indexes)))
+	for _, idx := range indexes {
+		enc.writeVarInt(uint64(idx))
+		out := e.Outputs[idx]
+		out.encode(enc)
+	}
+	return enc.Bytes()
+}
+
+func DeserializeUTXOEntry(data []byte) (*UTXOEntry, error) {
+	d := newDecoder(data)
+	entry := &UTXOEntry{}
+
+	var err error
+	if entry.Height, err = d.readInt64(); err != nil {
+		return nil, err
+	}
+	if entry.Time, err = d.readInt64(); err != nil {
+		return nil, err
+	}
+	coinbase, err := d.readUint8()
+	if err != nil {
+		return nil, err
+	}
+	entry.Coinbase = coinbase == 1
+
+	count, err := d.readCount()
+	if err != nil {
+		return nil, err
+	}
+	entry.Outputs = make(map[int]TxOutput, count)
+	for i := 0; i < count; i++ {
+		idx, err := d.readVarInt()
+		if err != nil {
+			return nil, err
+		}
+		var out TxOutput
+		if err := out.decode(d); err != nil {
+			return nil, err
+		}
+		entry.Outputs[int(idx)] = out
+	}
+	return entry, nil
+}
+
+func (undo *BlockUndo) Serialize() []byte {
+	e := &encoder{}
+	e.writeVarInt(uint64(len(undo.UTXOs)))
+	for _, u := range undo.UTXOs {
+		e.writeBytes(u.TxID)
+		if u.Entry == nil {
+			e.writeUint8(0)
+			continue
+		}
+		e.writeUint8(1)
+		e.writeBytes(u.Entry.Serialize())
+	}
+
+	e.writeVarInt(uint64(len(undo.States)))
+	for _, s := range undo.States {
+		e.writeBytes(s.Address)
+		e.writeBytes(s.Key)
+		if !s.Existed {
+			e.writeUint8(0)
+			continue
+		}
+		e.writeUint8(1)
+		e.writeBytes(s.Value)
+	}
+
+	e.writeVarInt(uint64(len(undo.Codes)))
+	for _, address := range undo.Codes {
+		e.writeBytes(address)
+	}
+	return e.Bytes()
+}
+
+func DeserializeBlockUndo(data []byte) (*BlockUndo, error) {
+	undo, err := decodeBlockUndo(newDecoder(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: undo: %v", ErrCorruptData, err)
+	}
+	return undo, nil
+}
+
+func decodeBlockUndo(d *decoder) (*BlockUndo, error) {
+	undo := &BlockUndo{}
+	count, err := d.readCount()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < count; i++ {
+		var u UndoUTXO
+		if u.TxID, err = d.readBytes(); err != nil {
+			return nil, err
+		}
+		present, err := d.readUint8()
+		if err != nil {
+			return nil, err
+		}
+		if present == 1 {
+			data, err := d.readBytes()
+			if err != nil {
+				return nil, err
+			}
+			if u.Entry, err = DeserializeUTXOEntry(data); err != nil {
+				return nil, err
+			}
+		}
+		undo.UTXOs = append(undo.UTXOs, u)
+	}
+
+	if count, err = d.readCount(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < count; i++ {
+		var s UndoState
+		if s.Address, err = d.readBytes(); err != nil {
+			return nil, err
+		}
+		if s.Key, err = d.readBytes(); err != nil {
+			return nil, err
+		}
+		existed, err := d.readUint8()
+		if err != nil {
+			return nil, err
+		}
+		if s.Existed = existed == 1; s.Existed {
+			if s.Value, err = d.readBytes(); err != nil {
+				return nil, err
+			}
+		}
+		undo.States = append(undo.States, s)
+	}
+
+	if count, err = d.readCount(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < count; i++ {
+		address, err := d.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		undo.Codes = append(undo.Codes, address)
+	}
+
+	if d.r.Len() != 0 {
+		return nil, errors.New("dữ liệu thừa sau undo")
+	}
+	return undo, nil
+}
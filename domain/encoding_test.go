@@ -0,0 +1,277 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+const (
+	vectorCoinbaseRaw = "01000000000100ffffffff00085265776172642d31000000000001640000000000000020111111111111111111111111111111111111111111111111111111111111111100000000000000000000"
+	vectorCoinbaseID  = "0b6617e48b04b8a5ee0596701da8f6bf74774db6448a35895c9bdc4178aa57cc"
+
+	vectorTransferID          = "be53824257212dfe0319cc9826f17d86f63e900af8b436ee38dd5a91041e381a"
+	vectorTransferUnsignedRaw = "010000000001200b6617e48b04b8a5ee0596701da8f6bf74774db6448a35895c9bdc4178aa57cc00000000000000ffffffff023c0000000000000020222222222222222222222222222222222222222222222222222222222222222200280000000000000020111111111111111111111111111111111111111111111111111111111111111100000000000000000000"
+	vectorTransferSignedRaw   = "010000000001200b6617e48b04b8a5ee0596701da8f6bf74774db6448a35895c9bdc4178aa57cc0000000040aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa40bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb00ffffffff023c0000000000000020222222222222222222222222222222222222222222222222222222222222222200280000000000000020111111111111111111111111111111111111111111111111111111111111111100000000000000000000"
+
+	vectorBlockMerkleRoot = "08418b83a11497225671dc2e59eb0affdee565d08fd4cb693e261603298fd1a1"
+	vectorBlockHeader     = "01000000333333333333333333333333333333333333333333333333333333333333333308418b83a11497225671dc2e59eb0affdee565d08fd4cb693e261603298fd1a180857467000000001000000007000000000000000100000000000000"
+	vectorBlockHash       = "b21f9a0ce421f6569e4828846b57f4fdcec109487fc3c710c3097d12e3938b2e"
+)
+
+func useParams(t *testing.T, params *ChainParams) {
+	t.Helper()
+	prev := activeParams
+	SelectParams(params)
+	t.Cleanup(func() { SelectParams(prev) })
+}
+
+func vectorCoinbase() *Transaction {
+	return newCoinbaseTo(bytes.Repeat([]byte{0x11}, 32), 100, 1)
+}
+
+func vectorTransfer(coinbaseID []byte) *Transaction {
+	tx := &Transaction{
+		Vin: []TxInput{{TxID: coinbaseID, VoutIndex: 0, Sequence: SequenceFinal}},
+		Vout: []TxOutput{
+			{Value: 60, PubKeyHash: bytes.Repeat([]byte{0x22}, 32)},
+			{Value: 40, PubKeyHash: bytes.Repeat([]byte{0x11}, 32)},
+		},
+	}
+	tx.SetID()
+	return tx
+}
+
+func TestEncodingCoinbaseVector(t *testing.T) {
+	coinbase := vectorCoinbase()
+	if got := coinbase.RawHex(); got != vectorCoinbaseRaw {
+		t.Errorf("raw coinbase = %s, muốn %s", got, vectorCoinbaseRaw)
+	}
+	if got := hex.EncodeToString(coinbase.ID); got != vectorCoinbaseID {
+		t.Errorf("ID coinbase = %s, muốn %s", got, vectorCoinbaseID)
+	}
+
+	decoded, err := DecodeRawTransaction(vectorCoinbaseRaw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(decoded.ID) != vectorCoinbaseID || decoded.RawHex() != vectorCoinbaseRaw {
+		t.Errorf("giải mã raw coinbase không khứ hồi: ID %x", decoded.ID)
+	}
+}
+
+func TestEncodingTransferVector(t *testing.T) {
+	coinbase := vectorCoinbase()
+	tx := vectorTransfer(coinbase.ID)
+	if got := tx.RawHex(); got != vectorTransferUnsignedRaw {
+		t.Errorf("raw chưa ký = %s, muốn %s", got, vectorTransferUnsignedRaw)
+	}
+	if got := hex.EncodeToString(tx.ID); got != vectorTransferID {
+		t.Errorf("ID giao dịch = %s, muốn %s", got, vectorTransferID)
+	}
+
+	tx.Vin[0].Signature = bytes.Repeat([]byte{0xaa}, 64)
+	tx.Vin[0].PublicKey = bytes.Repeat([]byte{0xbb}, 64)
+	if got := tx.RawHex(); got != vectorTransferSignedRaw {
+		t.Errorf("raw đã ký = %s, muốn %s", got, vectorTransferSignedRaw)
+	}
+	if got := hex.EncodeToString(tx.ComputeID()); got != vectorTransferID {
+		t.Errorf("ID thay đổi sau khi ký: %s", got)
+	}
+
+	for _, raw := range []string{vectorTransferUnsignedRaw, vectorTransferSignedRaw} {
+		decoded, err := DecodeRawTransaction(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(decoded.ID) != vectorTransferID || decoded.RawHex() != raw {
+			t.Errorf("giải mã raw không khứ hồi: ID %x", decoded.ID)
+		}
+	}
+}
+
+func TestEncodingSigHashVectors(t *testing.T) {
+	useParams(t, &MainNetParams)
+
+	coinbase := vectorCoinbase()
+	tx := vectorTransfer(coinbase.ID)
+	prevTxs := map[string]Transaction{string(coinbase.ID): *coinbase}
+
+	vectors := []struct {
+		hashType SigHashType
+		want     string
+	}{
+		{SigHashAll, "7730b7a7295bc2be7f472da96dcf866ff23cdcafbf540f55c860694920e7dc0c"},
+		{SigHashNone, "370e1e66a6002e87bca7bdfcafdd6542a5c9e557b1f0a73f592be567c808ecbb"},
+		{SigHashSingle, "5723f6442e8b01ec1b56e25ad4d7fc95ccafde37033bca9c7fc40791d4440615"},
+		{SigHashAll | SigHashAnyoneCanPay, "1c5a656004b04cada7c857d6dcbe2f1c460e24e690d18ac26d6f47117b733971"},
+	}
+	for _, v := range vectors {
+		hash, err := tx.inputSigHash(0, prevTxs, v.hashType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(hash); got != v.want {
+			t.Errorf("sighash %v = %s, muốn %s", v.hashType, got, v.want)
+		}
+	}
+}
+
+func TestSigHashCommitsToNetwork(t *testing.T) {
+	coinbase := vectorCoinbase()
+	tx := vectorTransfer(coinbase.ID)
+	prevTxs := map[string]Transaction{string(coinbase.ID): *coinbase}
+
+	seen := make(map[string]string)
+	for _, params := range Networks {
+		useParams(t, params)
+		hash, err := tx.inputSigHash(0, prevTxs, SigHashAll)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if other, ok := seen[string(hash)]; ok {
+			t.Errorf("sighash của %s trùng với %s", params.Name, other)
+		}
+		seen[string(hash)] = params.Name
+	}
+}
+
+func TestDecodeRawTransactionRejectsMalformed(t *testing.T) {
+	cases := map[string]string{
+		"rỗng":             "",
+		"không phải hex":   "zz",
+		"sai phiên bản":    "02" + vectorTransferUnsignedRaw[2:],
+		"bị cắt cụt":       vectorTransferUnsignedRaw[:len(vectorTransferUnsignedRaw)-10],
+		"dữ liệu thừa":     vectorTransferUnsignedRaw + "00",
+		"số input quá lớn": "0100000000ff",
+	}
+	for name, raw := range cases {
+		if _, err := DecodeRawTransaction(raw); err == nil {
+			t.Errorf("%s: raw %q phải bị từ chối", name, raw)
+		}
+	}
+}
+
+func TestTransactionWitnessAndScriptRoundTrip(t *testing.T) {
+	tx := &Transaction{
+		Type: TxTypeContractCall,
+		Vin: []TxInput{{
+			TxID:      bytes.Repeat([]byte{0x01}, 32),
+			VoutIndex: 3,
+			Witness:   [][]byte{{0xaa}, nil, bytes.Repeat([]byte{0xbb}, 300)},
+			Sequence:  7,
+		}},
+		Vout:     []TxOutput{{Value: 5, Script: NewTimeLockScript(10, bytes.Repeat([]byte{0x02}, 32))}},
+		Payload:  []byte("payload"),
+		LockTime: 42,
+	}
+	tx.SetID()
+
+	decoded, err := DeserializeTransaction(tx.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded.ID, tx.ID) || decoded.RawHex() != tx.RawHex() {
+		t.Fatalf("giao dịch không khứ hồi: %x != %x", decoded.ID, tx.ID)
+	}
+	if len(decoded.Vin[0].Witness) != 3 || !bytes.Equal(decoded.Vin[0].Witness[2], tx.Vin[0].Witness[2]) ||
+		decoded.Vin[0].Sequence != 7 || decoded.LockTime != 42 || decoded.Type != TxTypeContractCall {
+		t.Fatalf("giải mã mất trường: %+v", decoded)
+	}
+
+	withoutWitness := *tx
+	withoutWitness.Vin = []TxInput{tx.Vin[0]}
+	withoutWitness.Vin[0].Witness = nil
+	if !bytes.Equal(withoutWitness.ComputeID(), tx.ID) {
+		t.Fatal("witness không được làm thay đổi ID giao dịch")
+	}
+}
+
+func TestBlockSerializeRoundTrip(t *testing.T) {
+	coinbase := vectorCoinbase()
+	block := &Block{
+		Timestamp:     1735689600,
+		PrevBlockHash: bytes.Repeat([]byte{0x03}, 32),
+		Hash:          bytes.Repeat([]byte{0x04}, 32),
+		Nonce:         99,
+		Height:        1,
+		Transactions:  []*Transaction{coinbase, vectorTransfer(coinbase.ID)},
+	}
+	data := block.Serialize()
+
+	decoded, err := DeserializeBlock(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded.Serialize(), data) || len(decoded.Transactions) != 2 ||
+		!bytes.Equal(decoded.Transactions[1].ID, block.Transactions[1].ID) {
+		t.Fatalf("block không khứ hồi: %+v", decoded)
+	}
+
+	if _, err := DeserializeBlock(append(data, 0)); !errors.Is(err, ErrCorruptData) {
+		t.Fatalf("dữ liệu thừa sau block: lỗi %v, muốn ErrCorruptData", err)
+	}
+	if _, err := DeserializeBlock(data[:len(data)-5]); !errors.Is(err, ErrCorruptData) {
+		t.Fatalf("block bị cắt cụt: lỗi %v, muốn ErrCorruptData", err)
+	}
+}
+
+func vectorBlock() *Block {
+	coinbase := vectorCoinbase()
+	return &Block{
+		Timestamp:     1735689600,
+		PrevBlockHash: bytes.Repeat([]byte{0x33}, 32),
+		Transactions:  []*Transaction{coinbase, vectorTransfer(coinbase.ID)},
+		Nonce:         7,
+		Height:        1,
+	}
+}
+
+func TestEncodingBlockHashVector(t *testing.T) {
+	useParams(t, &MainNetParams)
+	block := vectorBlock()
+	if got := hex.EncodeToString(block.MerkleRoot()); got != vectorBlockMerkleRoot {
+		t.Errorf("Merkle root = %s, muốn %s", got, vectorBlockMerkleRoot)
+	}
+	header := block.Header(uint32(MainNetParams.Difficulty), block.Nonce)
+	if got := hex.EncodeToString(header); got != vectorBlockHeader || len(header) != BlockHeaderSize {
+		t.Errorf("header = %s, muốn %s", got, vectorBlockHeader)
+	}
+	if got := hex.EncodeToString(block.CalculateHash()); got != vectorBlockHash {
+		t.Errorf("hash block = %s, muốn %s", got, vectorBlockHash)
+	}
+
+	// Hash không phụ thuộc cách lưu block.
+	decoded, err := DeserializeBlock(block.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(decoded.CalculateHash()); got != vectorBlockHash {
+		t.Errorf("hash sau khi giải mã = %s, muốn %s", got, vectorBlockHash)
+	}
+}
+
+func TestMerkleRootShape(t *testing.T) {
+	ids := [][]byte{bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32), bytes.Repeat([]byte{3}, 32)}
+	block := &Block{}
+	for _, id := range ids {
+		block.Transactions = append(block.Transactions, &Transaction{ID: id})
+	}
+
+	left := sha256.Sum256(append(append([]byte{}, ids[0]...), ids[1]...))
+	want := sha256.Sum256(append(left[:], ids[2]...))
+	if got := block.MerkleRoot(); !bytes.Equal(got, want[:]) {
+		t.Fatalf("root 3 giao dịch = %x, muốn %x (nút lẻ được đưa thẳng lên)", got, want)
+	}
+
+	block.Transactions = block.Transactions[:1]
+	if got := block.MerkleRoot(); !bytes.Equal(got, ids[0]) {
+		t.Fatalf("root của block một giao dịch phải là ID của nó: %x", got)
+	}
+	block.Transactions = nil
+	if got := block.MerkleRoot(); !bytes.Equal(got, make([]byte, 32)) {
+		t.Fatalf("root của block rỗng: %x", got)
+	}
+}
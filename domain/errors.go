@@ -0,0 +1,16 @@
+package domain
+
+import "errors"
+
+var (
+	ErrNoBlockchain       = errors.New("blockchain chưa được khởi tạo, hãy chạy 'gochain init' trước")
+	ErrBlockNotFound      = errors.New("không tìm thấy block")
+	ErrTxNotFound         = errors.New("không tìm thấy giao dịch")
+	ErrContractNotFound   = errors.New("không tìm thấy contract")
+	ErrInvalidAddress     = errors.New("địa chỉ không hợp lệ")
+	ErrInvalidSignature   = errors.New("chữ ký hoặc script không hợp lệ")
+	ErrInvalidTransaction = errors.New("giao dịch không hợp lệ")
+	ErrInvalidBlock       = errors.New("block không hợp lệ")
+	ErrInsufficientFunds  = errors.New("không đủ tiền")
+	ErrCorruptData        = errors.New("dữ liệu trong CSDL bị hỏng")
+)
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"log"
+	"sync"
+)
+
+type EventKind int
+
+const (
+	EventBlockConnected EventKind = iota + 1
+	EventTxAccepted
+	EventTxReplaced
+	EventBlockDisconnected
+)
+
+const eventBufferSize = 64
+
+type ChainEvent struct {
+	Kind       EventKind
+	Block      *Block
+	Tx         *Transaction
+	Fee        int64
+	ReplacedBy []byte
+}
+
+var ErrSubscriberLagging = errors.New("subscriber quá chậm và đã bị ngắt, cần kết nối lại để đọc trạng thái mới")
+
+type EventBus struct {
+	mu     sync.Mutex
+	nextID int
+	subs   map[int]chan ChainEvent
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{subs: make(map[int]chan ChainEvent)}
+}
+
+func (b *EventBus) Subscribe() (<-chan ChainEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan ChainEvent, eventBufferSize)
+	b.subs[id] = ch
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.drop(id)
+	}
+}
+
+func (b *EventBus) drop(id int) {
+	if ch, ok := b.subs[id]; ok {
+		delete(b.subs, id)
+		close(ch)
+	}
+}
+
+func (b *EventBus) Publish(event ChainEvent) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for id, ch := range b.subs {
+		select {
+		case ch <- event:
+		default:
+			log.Printf("Event bus: subscriber %d quá chậm, ngắt kết nối để client đọc lại trạng thái", id)
+			b.drop(id)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "testing"
+
+func TestEventBusDisconnectsLaggingSubscriber(t *testing.T) {
+	bus := NewEventBus()
+	slow, cancelSlow := bus.Subscribe()
+	defer cancelSlow()
+	fast, cancelFast := bus.Subscribe()
+	defer cancelFast()
+
+	for i := 0; i <= eventBufferSize; i++ {
+		bus.Publish(ChainEvent{Kind: EventTxAccepted, Fee: int64(i)})
+		<-fast
+	}
+
+	received := 0
+	for range slow {
+		received++
+	}
+	if received != eventBufferSize {
+		t.Fatalf("subscriber chậm nhận %d sự kiện trước khi bị ngắt, muốn %d", received, eventBufferSize)
+	}
+
+	bus.Publish(ChainEvent{Kind: EventBlockConnected})
+	if event, ok := <-fast; !ok || event.Kind != EventBlockConnected {
+		t.Fatalf("subscriber theo kịp bị ảnh hưởng: %v %v", event, ok)
+	}
+}
+
+func TestEventBusCancelAfterDisconnect(t *testing.T) {
+	bus := NewEventBus()
+	_, cancel := bus.Subscribe()
+	for i := 0; i <= eventBufferSize; i++ {
+		bus.Publish(ChainEvent{Kind: EventTxAccepted})
+	}
+	cancel()
+	cancel()
+
+	var nilBus *EventBus
+	nilBus.Publish(ChainEvent{Kind: EventTxAccepted})
+}
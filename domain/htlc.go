@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+type HTLCParams struct {
+	Hash                []byte
+	RecipientPubKeyHash []byte
+	RefundPubKeyHash    []byte
+	Timeout             int64
+}
+
+func NewHTLCScript(params HTLCParams) []byte {
+	return NewScriptBuilder().
+		AddOp(OpIf).
+		AddOp(OpSHA256).AddData(params.Hash).AddOp(OpEqualVerify).
+		AddOp(OpDup).AddOp(OpHash256).AddData(params.RecipientPubKeyHash).
+		AddOp(OpElse).
+		AddInt(params.Timeout).AddOp(OpCheckLockTimeVerify).AddOp(OpDrop).
+		AddOp(OpDup).AddOp(OpHash256).AddData(params.RefundPubKeyHash).
+		AddOp(OpEndIf).
+		AddOp(OpEqualVerify).AddOp(OpCheckSig).
+		Script()
+}
+
+func ParseHTLCScript(script []byte) (*HTLCParams, error) {
+	ops, err := ParseScript(script)
+	if err != nil {
+		return nil, err
+	}
+	if !isHTLCOps(ops) {
+		return nil, errors.New("không phải HTLC script")
+	}
+
+	timeout, err := scriptOpInt(ops[8])
+	if err != nil {
+		return nil, err
+	}
+
+	return &HTLCParams{
+		Hash:                ops[2].Data,
+		RecipientPubKeyHash: ops[6].Data,
+		RefundPubKeyHash:    ops[13].Data,
+		Timeout:             timeout,
+	}, nil
+}
+
+func NewHTLCClaimWitness(sig []byte, pubKey []byte, preimage []byte) [][]byte {
+	return [][]byte{sig, pubKey, preimage, {1}}
+}
+
+func NewHTLCRefundWitness(sig []byte, pubKey []byte) [][]byte {
+	return [][]byte{sig, pubKey, nil}
+}
+
+func HashPreimage(preimage []byte) []byte {
+	hash := sha256.Sum256(preimage)
+	return hash[:]
+}
+
+func ExtractHTLCPreimage(claimTx *Transaction, htlcTxID []byte, voutIndex int, params *HTLCParams) ([]byte, error) {
+	for _, vin := range claimTx.Vin {
+		if !bytes.Equal(vin.TxID, htlcTxID) || vin.VoutIndex != voutIndex {
+			continue
+		}
+		if len(vin.Witness) != 4 || !castToBool(vin.Witness[3]) {
+			return nil, errors.New("input không phải là claim HTLC (có thể là refund)")
+		}
+
+		preimage := vin.Witness[2]
+		if !bytes.Equal(HashPreimage(preimage), params.Hash) {
+			return nil, errors.New("preimage không khớp với hash của HTLC")
+		}
+		return preimage, nil
+	}
+	return nil, fmt.Errorf("giao dịch %x không tiêu HTLC %x:%d", claimTx.ID, htlcTxID, voutIndex)
+}
+
+func isHTLCOps(ops []ScriptOp) bool {
+	return len(ops) == 17 &&
+		ops[0].Code == OpIf &&
+		ops[1].Code == OpSHA256 &&
+		ops[2].IsPush() && len(ops[2].Data) == 32 &&
+		ops[3].Code == OpEqualVerify &&
+		ops[4].Code == OpDup &&
+		ops[5].Code == OpHash256 &&
+		ops[6].IsPush() && len(ops[6].Data) == 32 &&
+		ops[7].Code == OpElse &&
+		ops[8].IsPush() &&
+		ops[9].Code == OpCheckLockTimeVerify &&
+		ops[10].Code == OpDrop &&
+		ops[11].Code == OpDup &&
+		ops[12].Code == OpHash256 &&
+		ops[13].IsPush() && len(ops[13].Data) == 32 &&
+		ops[14].Code == OpEndIf &&
+		ops[15].Code == OpEqualVerify &&
+		ops[16].Code == OpCheckSig
+}
@@ -0,0 +1,134 @@
+package domain_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+)
+
+type htlcFixture struct {
+	recipient, sender *domain.Wallet
+	preimage          []byte
+	params            domain.HTLCParams
+	htlcTx            domain.Transaction
+	prevTxs           map[string]domain.Transaction
+}
+
+func newHTLCFixture(t *testing.T) *htlcFixture {
+	t.Helper()
+	domain.SelectParams(&domain.RegTestParams)
+	f := &htlcFixture{recipient: testutil.NewWallet(t), sender: testutil.NewWallet(t), preimage: []byte("preimage của atomic swap")}
+	f.params = domain.HTLCParams{
+		Hash:                domain.HashPreimage(f.preimage),
+		RecipientPubKeyHash: domain.HashPubKey(f.recipient.PublicKey),
+		RefundPubKeyHash:    domain.HashPubKey(f.sender.PublicKey),
+		Timeout:             200,
+	}
+	f.htlcTx = domain.Transaction{Vout: []domain.TxOutput{{Value: 500, Script: domain.NewHTLCScript(f.params)}}}
+	f.htlcTx.SetID()
+	f.prevTxs = map[string]domain.Transaction{string(f.htlcTx.ID): f.htlcTx}
+	return f
+}
+
+func (f *htlcFixture) spend(t *testing.T, signer *domain.Wallet, witness func(sig []byte) [][]byte) *domain.Transaction {
+	t.Helper()
+	tx := &domain.Transaction{
+		Vin:  []domain.TxInput{{TxID: f.htlcTx.ID, VoutIndex: 0}},
+		Vout: []domain.TxOutput{{Value: 500, PubKeyHash: domain.HashPubKey(signer.PublicKey)}},
+	}
+	tx.SetID()
+	sig, err := tx.SignatureFor(0, signer.PrivateKey, f.prevTxs, domain.SigHashAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Vin[0].Witness = witness(sig)
+	return tx
+}
+
+func TestHTLCScriptRoundTrip(t *testing.T) {
+	f := newHTLCFixture(t)
+	script := f.htlcTx.Vout[0].Script
+	if class := domain.ClassifyScript(script); class != domain.ScriptHTLC {
+		t.Fatalf("ClassifyScript = %s, muốn htlc", class)
+	}
+	parsed, err := domain.ParseHTLCScript(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(parsed.Hash, f.params.Hash) || !bytes.Equal(parsed.RecipientPubKeyHash, f.params.RecipientPubKeyHash) ||
+		!bytes.Equal(parsed.RefundPubKeyHash, f.params.RefundPubKeyHash) || parsed.Timeout != f.params.Timeout {
+		t.Fatalf("ParseHTLCScript = %+v, muốn %+v", parsed, f.params)
+	}
+	if _, err := domain.ParseHTLCScript(domain.NewPubKeyHashScript(f.params.Hash)); err == nil {
+		t.Fatal("script P2PKH không được nhận là HTLC")
+	}
+}
+
+func TestHTLCClaim(t *testing.T) {
+	f := newHTLCFixture(t)
+	ctx := domain.VerifyContext{Height: 1}
+
+	claim := f.spend(t, f.recipient, func(sig []byte) [][]byte {
+		return domain.NewHTLCClaimWitness(sig, f.recipient.PublicKey, f.preimage)
+	})
+	if err := claim.Verify(f.prevTxs, ctx); err != nil {
+		t.Fatalf("người nhận có preimage phải claim được: %v", err)
+	}
+
+	wrongPreimage := f.spend(t, f.recipient, func(sig []byte) [][]byte {
+		return domain.NewHTLCClaimWitness(sig, f.recipient.PublicKey, []byte("sai"))
+	})
+	if err := wrongPreimage.Verify(f.prevTxs, ctx); err == nil {
+		t.Fatal("claim với preimage sai phải bị từ chối")
+	}
+
+	senderClaim := f.spend(t, f.sender, func(sig []byte) [][]byte {
+		return domain.NewHTLCClaimWitness(sig, f.sender.PublicKey, f.preimage)
+	})
+	if err := senderClaim.Verify(f.prevTxs, ctx); err == nil {
+		t.Fatal("người gửi không được claim dù biết preimage")
+	}
+}
+
+func TestHTLCRefund(t *testing.T) {
+	f := newHTLCFixture(t)
+	refund := f.spend(t, f.sender, func(sig []byte) [][]byte {
+		return domain.NewHTLCRefundWitness(sig, f.sender.PublicKey)
+	})
+	if err := refund.Verify(f.prevTxs, domain.VerifyContext{Height: f.params.Timeout - 1}); err == nil {
+		t.Fatal("refund trước timeout phải bị từ chối")
+	}
+	if err := refund.Verify(f.prevTxs, domain.VerifyContext{Height: f.params.Timeout}); err != nil {
+		t.Fatalf("refund tại timeout phải hợp lệ: %v", err)
+	}
+
+	recipientRefund := f.spend(t, f.recipient, func(sig []byte) [][]byte {
+		return domain.NewHTLCRefundWitness(sig, f.recipient.PublicKey)
+	})
+	if err := recipientRefund.Verify(f.prevTxs, domain.VerifyContext{Height: f.params.Timeout}); err == nil {
+		t.Fatal("người nhận không được đi nhánh refund")
+	}
+}
+
+func TestExtractHTLCPreimage(t *testing.T) {
+	f := newHTLCFixture(t)
+	claim := f.spend(t, f.recipient, func(sig []byte) [][]byte {
+		return domain.NewHTLCClaimWitness(sig, f.recipient.PublicKey, f.preimage)
+	})
+	preimage, err := domain.ExtractHTLCPreimage(claim, f.htlcTx.ID, 0, &f.params)
+	if err != nil || !bytes.Equal(preimage, f.preimage) {
+		t.Fatalf("ExtractHTLCPreimage = %q, %v", preimage, err)
+	}
+
+	refund := f.spend(t, f.sender, func(sig []byte) [][]byte {
+		return domain.NewHTLCRefundWitness(sig, f.sender.PublicKey)
+	})
+	if _, err := domain.ExtractHTLCPreimage(refund, f.htlcTx.ID, 0, &f.params); err == nil {
+		t.Fatal("giao dịch refund không chứa preimage")
+	}
+	if _, err := domain.ExtractHTLCPreimage(claim, f.htlcTx.ID, 1, &f.params); err == nil {
+		t.Fatal("claim không tiêu output 1 của HTLC")
+	}
+}
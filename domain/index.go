@@ -0,0 +1,247 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+const (
+	indexFlagKey = "txindex"
+	indexTipKey  = "txindex-tip"
+)
+
+var ErrIndexDisabled = errors.New("chỉ mục giao dịch chưa được bật (chạy node với --txindex)")
+
+type HistoryDirection byte
+
+const (
+	HistoryReceived HistoryDirection = 1
+	HistorySent     HistoryDirection = 2
+	HistorySelf     HistoryDirection = 3
+)
+
+func (d HistoryDirection) String() string {
+	switch d {
+	case HistoryReceived:
+		return "received"
+	case HistorySent:
+		return "sent"
+	case HistorySelf:
+		return "self"
+	}
+	return "unknown"
+}
+
+type AddressHistoryEntry struct {
+	TxID      []byte
+	BlockHash []byte
+	Height    int64
+	Position  int
+	Direction HistoryDirection
+	Amount    int64
+}
+
+func (bc *Blockchain) loadIndexFlag() (bool, error) {
+	_, err := bc.Store.Meta(indexFlagKey)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (bc *Blockchain) EnableIndex() error {
+	if bc.Indexed {
+		return nil
+	}
+	return bc.RebuildIndex()
+}
+
+func (bc *Blockchain) RebuildIndex() error {
+	log.Println("Đang xây dựng chỉ mục giao dịch và địa chỉ...")
+	bc.Indexed = false
+	err := bc.Store.Update(func(w ChainWriter) error {
+		if err := w.ClearIndex(); err != nil {
+			return err
+		}
+		return w.SetMeta(indexTipKey, []byte{})
+	})
+	if err != nil {
+		return err
+	}
+
+	hashes, err := bc.BlockHashes()
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range hashes {
+		block, err := bc.GetBlock(hash)
+		if err != nil {
+			return err
+		}
+		err = bc.Store.Update(func(w ChainWriter) error {
+			return bc.indexBlock(w, block)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = bc.Store.Update(func(w ChainWriter) error {
+		if err := w.SetMeta(indexFlagKey, []byte{1}); err != nil {
+			return err
+		}
+		return w.SetMeta(indexTipKey, bc.LastHash)
+	})
+	if err != nil {
+		return err
+	}
+	bc.Indexed = true
+	log.Printf("Đã lập chỉ mục %d block.", len(hashes))
+	return nil
+}
+
+type indexWriter interface {
+	PutTxLocation(txID, blockHash []byte, position int) error
+	PutAddressIndex(pubKeyHash []byte, entry AddressHistoryEntry) error
+}
+
+// indexEraser xóa đúng các bản ghi mà indexBlock ghi cho một block, dùng khi gỡ block khỏi tip.
+type indexEraser struct {
+	w ChainWriter
+}
+
+func (e indexEraser) PutTxLocation(txID, blockHash []byte, position int) error {
+	return e.w.DeleteTxLocation(txID)
+}
+
+func (e indexEraser) PutAddressIndex(pubKeyHash []byte, entry AddressHistoryEntry) error {
+	return e.w.DeleteAddressIndex(pubKeyHash, entry.Height, entry.Position)
+}
+
+func (bc *Blockchain) unindexBlock(w ChainWriter, block *Block) error {
+	return bc.indexBlock(indexEraser{w}, block)
+}
+
+func (bc *Blockchain) indexBlock(w indexWriter, block *Block) error {
+	blockTxs := make(map[string]*Transaction, len(block.Transactions))
+	for _, tx := range block.Transactions {
+		blockTxs[string(tx.ID)] = tx
+	}
+
+	for pos, tx := range block.Transactions {
+		if err := w.PutTxLocation(tx.ID, block.Hash, pos); err != nil {
+			return err
+		}
+
+		received := make(map[string]int64)
+		sent := make(map[string]int64)
+		var outputTotal int64
+		for _, out := range tx.Vout {
+			outputTotal += out.Value
+			if len(out.PubKeyHash) > 0 {
+				received[string(out.PubKeyHash)] += out.Value
+			}
+		}
+		if !tx.IsCoinbase() {
+			for _, vin := range tx.Vin {
+				prevOut, err := bc.indexedOutput(blockTxs, vin.TxID, vin.VoutIndex)
+				if err != nil {
+					return err
+				}
+				if len(prevOut.PubKeyHash) > 0 {
+					sent[string(prevOut.PubKeyHash)] += prevOut.Value
+				}
+			}
+		}
+
+		touched := make(map[string]bool)
+		for pkh := range received {
+			touched[pkh] = true
+		}
+		for pkh := range sent {
+			touched[pkh] = true
+		}
+		for pkh := range touched {
+			direction := HistorySent
+			switch {
+			case sent[pkh] == 0:
+				direction = HistoryReceived
+			case received[pkh] == outputTotal:
+				direction = HistorySelf
+			}
+
+			entry := AddressHistoryEntry{
+				TxID:      tx.ID,
+				Height:    block.Height,
+				Position:  pos,
+				Direction: direction,
+				Amount:    received[pkh] - sent[pkh],
+			}
+			if err := w.PutAddressIndex([]byte(pkh), entry); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (bc *Blockchain) indexedOutput(blockTxs map[string]*Transaction, txID []byte, voutIndex int) (TxOutput, error) {
+	tx, ok := blockTxs[string(txID)]
+	if !ok {
+		indexed, _, err := bc.findIndexedTransaction(txID)
+		if err != nil {
+			return TxOutput{}, err
+		}
+		tx = &indexed
+	}
+	if voutIndex < 0 || voutIndex >= len(tx.Vout) {
+		return TxOutput{}, fmt.Errorf("%w: output %x:%d không tồn tại", ErrInvalidTransaction, txID, voutIndex)
+	}
+	return tx.Vout[voutIndex], nil
+}
+
+func (bc *Blockchain) findIndexedTransaction(txID []byte) (Transaction, *Block, error) {
+	hash, pos, err := bc.Store.TxLocation(txID)
+	if errors.Is(err, ErrNotFound) {
+		return Transaction{}, nil, fmt.Errorf("%w: %x không có trong chỉ mục", ErrTxNotFound, txID)
+	}
+	if err != nil {
+		return Transaction{}, nil, err
+	}
+	block, err := bc.Store.Block(hash)
+	if err != nil {
+		return Transaction{}, nil, fmt.Errorf("%w: block %x của giao dịch %x: %v", ErrCorruptData, hash, txID, err)
+	}
+	if pos >= len(block.Transactions) {
+		return Transaction{}, nil, fmt.Errorf("%w: vị trí giao dịch %x trong chỉ mục", ErrCorruptData, txID)
+	}
+	return *block.Transactions[pos], block, nil
+}
+
+func (bc *Blockchain) GetAddressHistory(pubKeyHash []byte, offset, limit int) ([]AddressHistoryEntry, int, error) {
+	if !bc.Indexed {
+		return nil, 0, ErrIndexDisabled
+	}
+
+	var entries []AddressHistoryEntry
+	total := 0
+	var indexErr error
+	err := bc.Store.AddressIndex(pubKeyHash, func(entry AddressHistoryEntry) bool {
+		total++
+		if total <= offset || (limit > 0 && len(entries) >= limit) {
+			return true
+		}
+		entry.BlockHash, _, indexErr = bc.Store.TxLocation(entry.TxID)
+		if indexErr != nil {
+			return false
+		}
+		entries = append(entries, entry)
+		return true
+	})
+	if err == nil {
+		err = indexErr
+	}
+	return entries, total, err
+}
@@ -0,0 +1,126 @@
+package domain_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+	"github.com/khoahotran/gochain-ledger/storage"
+)
+
+func TestIndexRebuiltWhenChainRewinds(t *testing.T) {
+	store := storage.NewMemory()
+	bc, alice := testutil.NewChain(t, store)
+	bob := testutil.NewWallet(t)
+	if err := bc.EnableIndex(); err != nil {
+		t.Fatal(err)
+	}
+
+	first := testutil.MineBlock(t, bc, alice)
+	second := testutil.MineBlock(t, bc, alice, testutil.Spend(t, bc, alice, first.Transactions[0].ID, 0, testutil.PayTo(t, bob.GetAddress(), 100)))
+	payment := second.Transactions[1]
+
+	history, total, err := bc.GetAddressHistory(domain.HashPubKey(bob.PublicKey), 0, 0)
+	if err != nil || total != 1 || len(history) != 1 {
+		t.Fatalf("lịch sử của bob trước khi lùi chain: %v %d %v", history, total, err)
+	}
+
+	err = store.Update(func(w domain.ChainWriter) error {
+		return w.SetLastHash(first.Hash)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc, err = domain.ContinueBlockchain(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := bc.FindTransactionWithBlock(payment.ID); !errors.Is(err, domain.ErrTxNotFound) {
+		t.Fatalf("giao dịch ở block đã bị lùi vẫn còn trong chỉ mục: %v", err)
+	}
+	if _, total, err := bc.GetAddressHistory(domain.HashPubKey(bob.PublicKey), 0, 0); err != nil || total != 0 {
+		t.Fatalf("lịch sử của bob sau khi lùi chain: %d mục, lỗi %v", total, err)
+	}
+
+	report, err := bc.Verify(domain.VerifyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() {
+		t.Fatalf("chain sau khi dựng lại chỉ mục không khớp: %v", report.Mismatches)
+	}
+}
+
+func TestIndexTracksTip(t *testing.T) {
+	bc, alice := testutil.NewChain(t, nil)
+	if err := bc.EnableIndex(); err != nil {
+		t.Fatal(err)
+	}
+	block := testutil.MineBlock(t, bc, alice)
+
+	_, found, err := bc.FindTransactionWithBlock(block.Transactions[0].ID)
+	if err != nil || found.Height != block.Height {
+		t.Fatalf("không tìm thấy coinbase mới qua chỉ mục: %v", err)
+	}
+	report, err := bc.Verify(domain.VerifyOptions{Full: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() {
+		t.Fatalf("verify: %v %v", report.Invalid, report.Mismatches)
+	}
+}
+
+func TestAddressHistoryDirectionsAndPaging(t *testing.T) {
+	bc, alice := testutil.NewChain(t, nil)
+	bob, miner := testutil.NewWallet(t), testutil.NewWallet(t)
+	if _, _, err := bc.GetAddressHistory(domain.HashPubKey(alice.PublicKey), 0, 0); !errors.Is(err, domain.ErrIndexDisabled) {
+		t.Fatalf("chưa bật chỉ mục: lỗi %v, muốn ErrIndexDisabled", err)
+	}
+	if err := bc.EnableIndex(); err != nil {
+		t.Fatal(err)
+	}
+
+	genesis, err := bc.GetBlockByHeight(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payment := testutil.Spend(t, bc, alice, genesis.Transactions[0].ID, 0, testutil.PayTo(t, bob.GetAddress(), 60), testutil.PayTo(t, alice.GetAddress(), 30))
+	paymentBlock := testutil.MineBlock(t, bc, miner, payment)
+	self := testutil.Spend(t, bc, bob, payment.ID, 0, testutil.PayTo(t, bob.GetAddress(), 60))
+	testutil.MineBlock(t, bc, miner, self)
+
+	// Lịch sử trả về mới nhất trước.
+	history, total, err := bc.GetAddressHistory(domain.HashPubKey(alice.PublicKey), 0, 0)
+	if err != nil || total != 2 || len(history) != 2 {
+		t.Fatalf("lịch sử của alice: %v %d %v", history, total, err)
+	}
+	if history[0].Direction != domain.HistorySent || history[0].Amount != -70 || history[0].Height != 1 {
+		t.Fatalf("mục gửi tiền của alice (30 tiền thừa - 100 đã tiêu): %+v", history[0])
+	}
+	if !bytes.Equal(history[0].BlockHash, paymentBlock.Hash) {
+		t.Fatal("mục lịch sử phải trỏ tới block chứa giao dịch")
+	}
+
+	page, total, err := bc.GetAddressHistory(domain.HashPubKey(alice.PublicKey), 1, 1)
+	if err != nil || total != 2 || len(page) != 1 {
+		t.Fatalf("trang offset 1, limit 1: %v %d %v", page, total, err)
+	}
+	if !bytes.Equal(page[0].TxID, genesis.Transactions[0].ID) || page[0].Direction != domain.HistoryReceived || page[0].Amount != 100 {
+		t.Fatalf("mục coinbase genesis của alice: %+v", page[0])
+	}
+
+	history, _, err = bc.GetAddressHistory(domain.HashPubKey(bob.PublicKey), 0, 0)
+	if err != nil || len(history) != 2 {
+		t.Fatalf("lịch sử của bob: %v %v", history, err)
+	}
+	if history[0].Direction != domain.HistorySelf || history[0].Amount != 0 {
+		t.Fatalf("bob tự chuyển cho mình: %+v", history[0])
+	}
+	if history[1].Direction != domain.HistoryReceived || history[1].Amount != 60 {
+		t.Fatalf("bob nhận tiền: %+v", history[1])
+	}
+}
@@ -0,0 +1,165 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+	"github.com/khoahotran/gochain-ledger/storage"
+)
+
+// blockCountingStore đếm số lần đọc block để kiểm tra CheckLocks không duyệt chain.
+type blockCountingStore struct {
+	domain.ChainStore
+	reads int
+}
+
+func (s *blockCountingStore) Block(hash []byte) (*domain.Block, error) {
+	s.reads++
+	return s.ChainStore.Block(hash)
+}
+
+func TestIsFinalAbsoluteLockTime(t *testing.T) {
+	const unlockTime = 1800000000
+	cases := []struct {
+		lockTime int64
+		sequence uint32
+		ctx      domain.VerifyContext
+		final    bool
+	}{
+		{0, 0, domain.VerifyContext{}, true},
+		{10, 0, domain.VerifyContext{Height: 9}, false},
+		{10, 0, domain.VerifyContext{Height: 10}, true},
+		{10, domain.SequenceFinal, domain.VerifyContext{Height: 9}, true},
+		{unlockTime, 0, domain.VerifyContext{Height: 1 << 40, Time: unlockTime - 1}, false},
+		{unlockTime, 0, domain.VerifyContext{Time: unlockTime}, true},
+	}
+	for _, c := range cases {
+		tx := &domain.Transaction{LockTime: c.lockTime, Vin: []domain.TxInput{{TxID: []byte{1}, Sequence: c.sequence}}}
+		if got := tx.IsFinal(c.ctx); got != c.final {
+			t.Errorf("lockTime %d, sequence %x, ctx %+v: IsFinal = %v, muốn %v", c.lockTime, c.sequence, c.ctx, got, c.final)
+		}
+	}
+}
+
+func TestCheckLocksRelativeHeight(t *testing.T) {
+	bc, w := testutil.NewChain(t, nil)
+	genesis, err := bc.GetBlockByHeight(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := testutil.Spend(t, bc, w, genesis.Transactions[0].ID, 0, testutil.PayTo(t, w.GetAddress(), 100))
+
+	tx.Vin[0].Sequence = 5
+	if err := bc.CheckLocks(tx, domain.VerifyContext{Height: 4, Time: genesis.Timestamp}); err == nil {
+		t.Fatal("input khóa 5 block sau genesis không được tiêu ở block 4")
+	}
+	if err := bc.CheckLocks(tx, domain.VerifyContext{Height: 5, Time: genesis.Timestamp}); err != nil {
+		t.Fatalf("input phải mở ở block 5: %v", err)
+	}
+
+	tx.Vin[0].Sequence = 5 | domain.SequenceLockTimeDisableFlag
+	if err := bc.CheckLocks(tx, domain.VerifyContext{Height: 1, Time: genesis.Timestamp}); err != nil {
+		t.Fatalf("cờ disable phải bỏ khóa tương đối: %v", err)
+	}
+}
+
+func TestCheckLocksRelativeTime(t *testing.T) {
+	bc, w := testutil.NewChain(t, nil)
+	genesis, err := bc.GetBlockByHeight(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := testutil.Spend(t, bc, w, genesis.Transactions[0].ID, 0, testutil.PayTo(t, w.GetAddress(), 100))
+	tx.Vin[0].Sequence = domain.SequenceLockTimeTypeFlag | 2
+
+	unlock := genesis.Timestamp + 2<<domain.SequenceLockTimeGranularity
+	if err := bc.CheckLocks(tx, domain.VerifyContext{Height: 100, Time: unlock - 1}); err == nil {
+		t.Fatalf("input khóa theo thời gian không được tiêu trước %d", unlock)
+	}
+	if err := bc.CheckLocks(tx, domain.VerifyContext{Height: 100, Time: unlock}); err != nil {
+		t.Fatalf("input phải mở tại %d: %v", unlock, err)
+	}
+}
+
+func TestCheckLocksDoesNotReadBlocks(t *testing.T) {
+	store := &blockCountingStore{ChainStore: storage.NewMemory()}
+	bc, w := testutil.NewChain(t, store)
+	genesis := testutil.GenesisCoinbase(t, bc)
+	for i := 0; i < 5; i++ {
+		testutil.MineBlock(t, bc, w)
+	}
+	genesisBlock, err := bc.GetBlockByHeight(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := testutil.Spend(t, bc, w, genesis.ID, 0, testutil.PayTo(t, w.GetAddress(), 100))
+	unlock := genesisBlock.Timestamp + 1<<domain.SequenceLockTimeGranularity
+	ctx := domain.VerifyContext{Height: 6, Time: unlock}
+
+	store.reads = 0
+	for _, sequence := range []uint32{0, 3, domain.SequenceLockTimeTypeFlag | 1} {
+		tx.Vin[0].Sequence = sequence
+		if err := bc.CheckLocks(tx, ctx); err != nil {
+			t.Fatalf("sequence %x: %v", sequence, err)
+		}
+	}
+	if store.reads != 0 {
+		t.Fatalf("CheckLocks đọc %d block, muốn lấy độ cao và thời điểm từ UTXO Set", store.reads)
+	}
+}
+
+func TestCheckLocksPendingParent(t *testing.T) {
+	bc, w := testutil.NewChain(t, nil)
+	parent := &domain.Transaction{ID: []byte("cha-chưa-xác-nhận")}
+	ctx := domain.VerifyContext{Height: 50, Time: 1800000000, Pending: map[string]*domain.Transaction{string(parent.ID): parent}}
+
+	child := &domain.Transaction{
+		Vin:  []domain.TxInput{{TxID: parent.ID, Sequence: 1}},
+		Vout: []domain.TxOutput{testutil.PayTo(t, w.GetAddress(), 1)},
+	}
+	if err := bc.CheckLocks(child, ctx); err == nil {
+		t.Fatal("khóa tương đối 1 block trên cha chưa xác nhận phải chưa mở")
+	}
+	child.Vin[0].Sequence = 0
+	if err := bc.CheckLocks(child, ctx); err != nil {
+		t.Fatalf("khóa tương đối 0 trên cha chưa xác nhận phải mở: %v", err)
+	}
+
+	// Khóa 0 không cần tra input; input không tồn tại do CheckInputs từ chối.
+	child.Vin[0].TxID = []byte("không-tồn-tại")
+	if err := bc.CheckLocks(child, ctx); err != nil {
+		t.Fatalf("khóa tương đối 0 không được tra output được tiêu: %v", err)
+	}
+	child.Vin[0].Sequence = 1
+	if err := bc.CheckLocks(child, ctx); err == nil {
+		t.Fatal("input không có trong UTXO Set lẫn mempool phải bị từ chối")
+	}
+}
+
+func TestCheckLockTimeVerifyScript(t *testing.T) {
+	domain.SelectParams(&domain.RegTestParams)
+	w := testutil.NewWallet(t)
+	prev := domain.Transaction{Vout: []domain.TxOutput{{Value: 100, Script: domain.NewTimeLockScript(100, domain.HashPubKey(w.PublicKey))}}}
+	prev.SetID()
+	prevTxs := map[string]domain.Transaction{string(prev.ID): prev}
+
+	tx := &domain.Transaction{
+		Vin:      []domain.TxInput{{TxID: prev.ID, VoutIndex: 0, Sequence: 0}},
+		Vout:     []domain.TxOutput{testutil.PayTo(t, w.GetAddress(), 90)},
+		LockTime: 100,
+	}
+	tx.SetID()
+	sig, err := tx.SignatureFor(0, w.PrivateKey, prevTxs, domain.SigHashAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Vin[0].Witness = [][]byte{sig, w.PublicKey}
+
+	if err := tx.Verify(prevTxs, domain.VerifyContext{Height: 99}); err == nil {
+		t.Fatal("OP_CHECKLOCKTIMEVERIFY phải chặn trước block 100")
+	}
+	if err := tx.Verify(prevTxs, domain.VerifyContext{Height: 100}); err != nil {
+		t.Fatalf("output phải mở ở block 100: %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+type ChainParams struct {
+	Name                string
+	Magic               uint32
+	AddressVersion      byte
+	TypedAddressVersion byte
+	Difficulty          int
+	Emission            EmissionSchedule
+
+	GenesisTimestamp    int64
+	GenesisPubKeyHash   []byte
+	GenesisHash         []byte
+	AllowCustomGenesis  bool
+	AllowParamOverrides bool
+	OnDemandMining      bool
+
+	DefaultPort     string
+	DefaultGRPCPort string
+	DataSubdir      string
+}
+
+var MainNetParams = ChainParams{
+	Name:                "mainnet",
+	Magic:               0x47434d4e,
+	AddressVersion:      0x00,
+	TypedAddressVersion: 0x01,
+	Difficulty:          16,
+	Emission:            DefaultEmissionSchedule,
+	GenesisTimestamp:    1735689600,
+	GenesisPubKeyHash:   genesisBurnHash("mainnet"),
+	GenesisHash:         mustDecodeHex("000046c7e0770f6c816c393665899c33bdd9b5da7894e31396ad52b49464894a"),
+	DefaultPort:         "3000",
+	DefaultGRPCPort:     "50051",
+}
+
+var TestNetParams = ChainParams{
+	Name:                "testnet",
+	Magic:               0x4743544e,
+	AddressVersion:      0x6f,
+	TypedAddressVersion: 0x70,
+	Difficulty:          12,
+	Emission: EmissionSchedule{
+		InitialReward:    100,
+		HalvingInterval:  10000,
+		MaxSupply:        20000000,
+		CoinbaseMaturity: 10,
+	},
+	GenesisTimestamp:  1735689600,
+	GenesisPubKeyHash: genesisBurnHash("testnet"),
+	GenesisHash:       mustDecodeHex("000113468951a556e37f6bb986330767d6b3f9cfed9b53e794134d38a1e998d0"),
+	DefaultPort:       "3100",
+	DefaultGRPCPort:   "50151",
+	DataSubdir:        "testnet",
+}
+
+var RegTestParams = ChainParams{
+	Name:                "regtest",
+	Magic:               0x47435254,
+	AddressVersion:      0x3c,
+	TypedAddressVersion: 0x3d,
+	Difficulty:          1,
+	Emission: EmissionSchedule{
+		InitialReward:    100,
+		HalvingInterval:  150,
+		MaxSupply:        20000000,
+		CoinbaseMaturity: 10,
+	},
+	GenesisTimestamp:    1735689600,
+	GenesisPubKeyHash:   genesisBurnHash("regtest"),
+	AllowCustomGenesis:  true,
+	AllowParamOverrides: true,
+	OnDemandMining:      true,
+	DefaultPort:         "3200",
+	DefaultGRPCPort:     "50251",
+	DataSubdir:          "regtest",
+}
+
+var Networks = []*ChainParams{&MainNetParams, &TestNetParams, &RegTestParams}
+
+var activeParams = &MainNetParams
+
+func genesisBurnHash(network string) []byte {
+	hash := sha256.Sum256([]byte("gochain-ledger genesis " + network))
+	return hash[:]
+}
+
+func mustDecodeHex(s string) []byte {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func ParamsForNetwork(name string) (*ChainParams, error) {
+	for _, params := range Networks {
+		if params.Name == strings.ToLower(name) {
+			return params, nil
+		}
+	}
+	return nil, fmt.Errorf("mạng không hợp lệ: %q (mainnet, testnet hoặc regtest)", name)
+}
+
+func SelectParams(params *ChainParams) {
+	activeParams = params
+}
+
+func ActiveParams() *ChainParams {
+	return activeParams
+}
+
+func (p *ChainParams) GenesisBlock(address string, emission EmissionSchedule) (*Block, error) {
+	pubKeyHash := p.GenesisPubKeyHash
+	if address != "" {
+		if !p.AllowCustomGenesis {
+			return nil, fmt.Errorf("mạng %s dùng block genesis cố định, không nhận địa chỉ genesis", p.Name)
+		}
+		var err error
+		pubKeyHash, err = DecodeAddress(address)
+		if err != nil {
+			return nil, fmt.Errorf("địa chỉ genesis: %w", err)
+		}
+	}
+
+	coinbaseTx := newCoinbaseTo(pubKeyHash, emission.BlockReward(0), 0)
+	genesis := newBlockAt([]byte{}, []*Transaction{coinbaseTx}, 0, p.GenesisTimestamp)
+	if address == "" && len(p.GenesisHash) > 0 && !bytes.Equal(genesis.Hash, p.GenesisHash) {
+		return nil, fmt.Errorf("genesis %x không khớp tham số mạng %s (%x)", genesis.Hash, p.Name, p.GenesisHash)
+	}
+	return genesis, nil
+}
@@ -1,14 +1,12 @@
 package domain
 
 import (
-	"bytes"
 	"crypto/sha256"
+	"encoding/binary"
 	"math"
 	"math/big"
 )
 
-const Difficulty = 16
-
 type ProofOfWork struct {
 	Block  *Block
 	Target *big.Int
@@ -17,24 +15,14 @@ type ProofOfWork struct {
 func NewProofOfWork(b *Block) *ProofOfWork {
 	target := big.NewInt(1)
 
-	target.Lsh(target, uint(256-Difficulty))
+	target.Lsh(target, uint(256-activeParams.Difficulty))
 
 	pow := &ProofOfWork{Block: b, Target: target}
 	return pow
 }
 
 func (pow *ProofOfWork) prepareData(nonce int64) []byte {
-	data := bytes.Join(
-		[][]byte{
-			pow.Block.PrevBlockHash,
-			pow.Block.HashTransactions(),
-			IntToHex(pow.Block.Timestamp),
-			IntToHex(int64(Difficulty)),
-			IntToHex(nonce),
-		},
-		[]byte{},
-	)
-	return data
+	return pow.Block.Header(uint32(activeParams.Difficulty), nonce)
 }
 
 func (pow *ProofOfWork) Run() (int64, []byte) {
@@ -42,8 +30,10 @@ func (pow *ProofOfWork) Run() (int64, []byte) {
 	var hash [32]byte
 	var nonce int64 = 0
 
+	// Giữa các lần thử chỉ nonce đổi nên ghi đè nó trong header thay vì dựng lại Merkle root.
+	data := pow.prepareData(nonce)
 	for nonce < math.MaxInt64 {
-		data := pow.prepareData(nonce)
+		binary.LittleEndian.PutUint64(data[blockHeaderNonceAt:], uint64(nonce))
 		hash = sha256.Sum256(data)
 
 		hashInt.SetBytes(hash[:])
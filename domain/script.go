@@ -0,0 +1,328 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+type OpCode byte
+
+const (
+	Op0         OpCode = 0x00
+	OpPushData1 OpCode = 0x4c
+	OpPushData2 OpCode = 0x4d
+	Op1         OpCode = 0x51
+	Op16        OpCode = 0x60
+
+	OpIf     OpCode = 0x63
+	OpNotIf  OpCode = 0x64
+	OpElse   OpCode = 0x67
+	OpEndIf  OpCode = 0x68
+	OpVerify OpCode = 0x69
+	OpReturn OpCode = 0x6a
+
+	OpDrop OpCode = 0x75
+	OpDup  OpCode = 0x76
+	OpSwap OpCode = 0x7c
+	OpSize OpCode = 0x82
+
+	OpEqual       OpCode = 0x87
+	OpEqualVerify OpCode = 0x88
+
+	OpSHA256  OpCode = 0xa8
+	OpHash256 OpCode = 0xaa
+
+	OpCheckSig            OpCode = 0xac
+	OpCheckSigVerify      OpCode = 0xad
+	OpCheckMultiSig       OpCode = 0xae
+	OpCheckMultiSigVerify OpCode = 0xaf
+
+	OpCheckLockTimeVerify OpCode = 0xb1
+)
+
+const (
+	maxScriptSize        = 10000
+	maxScriptElementSize = 520
+	maxMultiSigKeys      = 16
+	lockTimeThreshold    = 500000000
+)
+
+type ScriptClass int
+
+const (
+	ScriptNonStandard ScriptClass = iota
+	ScriptPubKeyHash
+	ScriptMultiSig
+	ScriptHashLock
+	ScriptTimeLock
+	ScriptHTLC
+)
+
+func (c ScriptClass) String() string {
+	switch c {
+	case ScriptPubKeyHash:
+		return "pubkeyhash"
+	case ScriptMultiSig:
+		return "multisig"
+	case ScriptHashLock:
+		return "hashlock"
+	case ScriptTimeLock:
+		return "timelock"
+	case ScriptHTLC:
+		return "htlc"
+	default:
+		return "nonstandard"
+	}
+}
+
+type ScriptOp struct {
+	Code OpCode
+	Data []byte
+}
+
+func (op ScriptOp) IsPush() bool {
+	return op.Code <= OpPushData2 || (op.Code >= Op1 && op.Code <= Op16)
+}
+
+type ScriptBuilder struct {
+	buf bytes.Buffer
+}
+
+func NewScriptBuilder() *ScriptBuilder {
+	return &ScriptBuilder{}
+}
+
+func (b *ScriptBuilder) AddOp(op OpCode) *ScriptBuilder {
+	b.buf.WriteByte(byte(op))
+	return b
+}
+
+func (b *ScriptBuilder) AddData(data []byte) *ScriptBuilder {
+	switch {
+	case len(data) == 0:
+		b.buf.WriteByte(byte(Op0))
+	case len(data) < int(OpPushData1):
+		b.buf.WriteByte(byte(len(data)))
+	case len(data) <= 0xff:
+		b.buf.WriteByte(byte(OpPushData1))
+		b.buf.WriteByte(byte(len(data)))
+	default:
+		b.buf.WriteByte(byte(OpPushData2))
+		var l [2]byte
+		binary.LittleEndian.PutUint16(l[:], uint16(len(data)))
+		b.buf.Write(l[:])
+	}
+	b.buf.Write(data)
+	return b
+}
+
+func (b *ScriptBuilder) AddInt(n int64) *ScriptBuilder {
+	if n == 0 {
+		return b.AddOp(Op0)
+	}
+	if n >= 1 && n <= 16 {
+		return b.AddOp(Op1 + OpCode(n-1))
+	}
+	return b.AddData(encodeScriptNum(n))
+}
+
+func (b *ScriptBuilder) Script() []byte {
+	return append([]byte{}, b.buf.Bytes()...)
+}
+
+func ParseScript(script []byte) ([]ScriptOp, error) {
+	if len(script) > maxScriptSize {
+		return nil, errors.New("script quá lớn")
+	}
+
+	var ops []ScriptOp
+	for i := 0; i < len(script); {
+		code := OpCode(script[i])
+		i++
+
+		var size int
+		switch {
+		case code > Op0 && code < OpPushData1:
+			size = int(code)
+		case code == OpPushData1:
+			if i+1 > len(script) {
+				return nil, errors.New("script bị cắt cụt (PUSHDATA1)")
+			}
+			size = int(script[i])
+			i++
+		case code == OpPushData2:
+			if i+2 > len(script) {
+				return nil, errors.New("script bị cắt cụt (PUSHDATA2)")
+			}
+			size = int(binary.LittleEndian.Uint16(script[i : i+2]))
+			i += 2
+		default:
+			ops = append(ops, ScriptOp{Code: code})
+			continue
+		}
+
+		if i+size > len(script) {
+			return nil, errors.New("script bị cắt cụt (dữ liệu push)")
+		}
+		ops = append(ops, ScriptOp{Code: code, Data: script[i : i+size]})
+		i += size
+	}
+	return ops, nil
+}
+
+func NewPubKeyHashScript(pubKeyHash []byte) []byte {
+	return NewScriptBuilder().
+		AddOp(OpDup).AddOp(OpHash256).AddData(pubKeyHash).AddOp(OpEqualVerify).AddOp(OpCheckSig).
+		Script()
+}
+
+func NewMultiSigScript(required int, pubKeys [][]byte) ([]byte, error) {
+	if len(pubKeys) == 0 || len(pubKeys) > maxMultiSigKeys {
+		return nil, fmt.Errorf("số khóa multisig không hợp lệ: %d", len(pubKeys))
+	}
+	if required < 1 || required > len(pubKeys) {
+		return nil, fmt.Errorf("số chữ ký yêu cầu không hợp lệ: %d/%d", required, len(pubKeys))
+	}
+
+	b := NewScriptBuilder().AddInt(int64(required))
+	for _, pubKey := range pubKeys {
+		b.AddData(pubKey)
+	}
+	return b.AddInt(int64(len(pubKeys))).AddOp(OpCheckMultiSig).Script(), nil
+}
+
+func NewHashLockScript(hash []byte, pubKeyHash []byte) []byte {
+	return NewScriptBuilder().
+		AddOp(OpSHA256).AddData(hash).AddOp(OpEqualVerify).
+		AddOp(OpDup).AddOp(OpHash256).AddData(pubKeyHash).AddOp(OpEqualVerify).AddOp(OpCheckSig).
+		Script()
+}
+
+func NewTimeLockScript(lockTime int64, pubKeyHash []byte) []byte {
+	return NewScriptBuilder().
+		AddInt(lockTime).AddOp(OpCheckLockTimeVerify).AddOp(OpDrop).
+		AddOp(OpDup).AddOp(OpHash256).AddData(pubKeyHash).AddOp(OpEqualVerify).AddOp(OpCheckSig).
+		Script()
+}
+
+func ClassifyScript(script []byte) ScriptClass {
+	if len(script) == 0 {
+		return ScriptPubKeyHash
+	}
+
+	ops, err := ParseScript(script)
+	if err != nil {
+		return ScriptNonStandard
+	}
+
+	switch {
+	case isPubKeyHashOps(ops):
+		return ScriptPubKeyHash
+	case isMultiSigOps(ops):
+		return ScriptMultiSig
+	case len(ops) == 8 && ops[0].Code == OpSHA256 && len(ops[1].Data) == 32 && ops[1].IsPush() &&
+		ops[2].Code == OpEqualVerify && isPubKeyHashOps(ops[3:]):
+		return ScriptHashLock
+	case len(ops) == 8 && ops[0].IsPush() && ops[1].Code == OpCheckLockTimeVerify && ops[2].Code == OpDrop &&
+		isPubKeyHashOps(ops[3:]):
+		return ScriptTimeLock
+	case isHTLCOps(ops):
+		return ScriptHTLC
+	}
+	return ScriptNonStandard
+}
+
+func IsStandardScript(script []byte) bool {
+	return ClassifyScript(script) != ScriptNonStandard
+}
+
+func isPubKeyHashOps(ops []ScriptOp) bool {
+	return len(ops) == 5 &&
+		ops[0].Code == OpDup &&
+		ops[1].Code == OpHash256 &&
+		ops[2].IsPush() && len(ops[2].Data) == 32 &&
+		ops[3].Code == OpEqualVerify &&
+		ops[4].Code == OpCheckSig
+}
+
+func isMultiSigOps(ops []ScriptOp) bool {
+	if len(ops) < 4 || ops[len(ops)-1].Code != OpCheckMultiSig {
+		return false
+	}
+	required, okM := smallInt(ops[0])
+	total, okN := smallInt(ops[len(ops)-2])
+	if !okM || !okN || required < 1 || required > total || total != len(ops)-3 {
+		return false
+	}
+	for _, op := range ops[1 : len(ops)-2] {
+		if op.Code >= Op1 || len(op.Data) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func smallInt(op ScriptOp) (int, bool) {
+	if op.Code >= Op1 && op.Code <= Op16 {
+		return int(op.Code-Op1) + 1, true
+	}
+	return 0, false
+}
+
+func scriptOpInt(op ScriptOp) (int64, error) {
+	if n, ok := smallInt(op); ok {
+		return int64(n), nil
+	}
+	return decodeScriptNum(op.Data)
+}
+
+func encodeScriptNum(n int64) []byte {
+	if n == 0 {
+		return nil
+	}
+
+	negative := n < 0
+	abs := uint64(n)
+	if negative {
+		abs = uint64(-n)
+	}
+
+	var result []byte
+	for abs > 0 {
+		result = append(result, byte(abs&0xff))
+		abs >>= 8
+	}
+
+	if result[len(result)-1]&0x80 != 0 {
+		extra := byte(0x00)
+		if negative {
+			extra = 0x80
+		}
+		result = append(result, extra)
+	} else if negative {
+		result[len(result)-1] |= 0x80
+	}
+	return result
+}
+
+func decodeScriptNum(data []byte) (int64, error) {
+	if len(data) > 8 {
+		return 0, errors.New("số trong script quá lớn")
+	}
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	var result int64
+	for i, b := range data {
+		result |= int64(b) << uint(8*i)
+	}
+
+	if data[len(data)-1]&0x80 != 0 {
+		result &= ^(int64(0x80) << uint(8*(len(data)-1)))
+		return -result, nil
+	}
+	return result, nil
+}
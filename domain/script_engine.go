@@ -0,0 +1,360 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+const maxStackSize = 1000
+
+type VerifyContext struct {
+	Height  int64
+	Time    int64
+	Pending map[string]*Transaction
+}
+
+type ScriptEngine struct {
+	tx         *Transaction
+	inputIndex int
+	prevTxs    map[string]Transaction
+	ctx        VerifyContext
+	stack      [][]byte
+}
+
+func NewScriptEngine(tx *Transaction, inputIndex int, prevTxs map[string]Transaction, ctx VerifyContext) *ScriptEngine {
+	return &ScriptEngine{
+		tx:         tx,
+		inputIndex: inputIndex,
+		prevTxs:    prevTxs,
+		ctx:        ctx,
+	}
+}
+
+func (e *ScriptEngine) Execute(unlocking [][]byte, lockingScript []byte) error {
+	ops, err := ParseScript(lockingScript)
+	if err != nil {
+		return err
+	}
+
+	e.stack = nil
+	for _, item := range unlocking {
+		if len(item) > maxScriptElementSize {
+			return errors.New("phần tử unlock quá lớn")
+		}
+		e.push(item)
+	}
+
+	var conds []bool
+	for _, op := range ops {
+		executing := true
+		for _, c := range conds {
+			executing = executing && c
+		}
+
+		switch op.Code {
+		case OpIf, OpNotIf:
+			branch := false
+			if executing {
+				top, err := e.pop()
+				if err != nil {
+					return err
+				}
+				branch = castToBool(top)
+				if op.Code == OpNotIf {
+					branch = !branch
+				}
+			}
+			conds = append(conds, branch)
+			continue
+		case OpElse:
+			if len(conds) == 0 {
+				return errors.New("OP_ELSE không có OP_IF")
+			}
+			conds[len(conds)-1] = !conds[len(conds)-1]
+			continue
+		case OpEndIf:
+			if len(conds) == 0 {
+				return errors.New("OP_ENDIF không có OP_IF")
+			}
+			conds = conds[:len(conds)-1]
+			continue
+		}
+
+		if !executing {
+			continue
+		}
+		if err := e.step(op); err != nil {
+			return err
+		}
+		if len(e.stack) > maxStackSize {
+			return errors.New("stack vượt quá giới hạn")
+		}
+	}
+
+	if len(conds) != 0 {
+		return errors.New("thiếu OP_ENDIF")
+	}
+	if len(e.stack) == 0 {
+		return errors.New("stack rỗng sau khi thực thi")
+	}
+	if !castToBool(e.stack[len(e.stack)-1]) {
+		return errors.New("script trả về false")
+	}
+	return nil
+}
+
+func (e *ScriptEngine) step(op ScriptOp) error {
+	switch {
+	case op.Code <= OpPushData2:
+		e.push(op.Data)
+		return nil
+	case op.Code >= Op1 && op.Code <= Op16:
+		e.push(encodeScriptNum(int64(op.Code-Op1) + 1))
+		return nil
+	}
+
+	switch op.Code {
+	case OpVerify:
+		top, err := e.pop()
+		if err != nil {
+			return err
+		}
+		if !castToBool(top) {
+			return errors.New("OP_VERIFY thất bại")
+		}
+
+	case OpReturn:
+		return errors.New("gặp OP_RETURN")
+
+	case OpDrop:
+		_, err := e.pop()
+		return err
+
+	case OpDup:
+		top, err := e.peek()
+		if err != nil {
+			return err
+		}
+		e.push(top)
+
+	case OpSwap:
+		a, err := e.pop()
+		if err != nil {
+			return err
+		}
+		b, err := e.pop()
+		if err != nil {
+			return err
+		}
+		e.push(a)
+		e.push(b)
+
+	case OpSize:
+		top, err := e.peek()
+		if err != nil {
+			return err
+		}
+		e.push(encodeScriptNum(int64(len(top))))
+
+	case OpEqual, OpEqualVerify:
+		a, err := e.pop()
+		if err != nil {
+			return err
+		}
+		b, err := e.pop()
+		if err != nil {
+			return err
+		}
+		equal := bytes.Equal(a, b)
+		if op.Code == OpEqualVerify {
+			if !equal {
+				return errors.New("OP_EQUALVERIFY thất bại")
+			}
+			return nil
+		}
+		e.push(boolBytes(equal))
+
+	case OpSHA256:
+		top, err := e.pop()
+		if err != nil {
+			return err
+		}
+		hash := sha256.Sum256(top)
+		e.push(hash[:])
+
+	case OpHash256:
+		top, err := e.pop()
+		if err != nil {
+			return err
+		}
+		e.push(HashPubKey(top))
+
+	case OpCheckSig, OpCheckSigVerify:
+		pubKey, err := e.pop()
+		if err != nil {
+			return err
+		}
+		sig, err := e.pop()
+		if err != nil {
+			return err
+		}
+		valid := e.checkSig(sig, pubKey)
+		if op.Code == OpCheckSigVerify {
+			if !valid {
+				return errors.New("OP_CHECKSIGVERIFY thất bại")
+			}
+			return nil
+		}
+		e.push(boolBytes(valid))
+
+	case OpCheckMultiSig, OpCheckMultiSigVerify:
+		valid, err := e.checkMultiSig()
+		if err != nil {
+			return err
+		}
+		if op.Code == OpCheckMultiSigVerify {
+			if !valid {
+				return errors.New("OP_CHECKMULTISIGVERIFY thất bại")
+			}
+			return nil
+		}
+		e.push(boolBytes(valid))
+
+	case OpCheckLockTimeVerify:
+		top, err := e.peek()
+		if err != nil {
+			return err
+		}
+		lockTime, err := decodeScriptNum(top)
+		if err != nil {
+			return err
+		}
+		if lockTime < 0 {
+			return errors.New("lock time âm")
+		}
+		if !e.ctx.reached(lockTime) {
+			return fmt.Errorf("output bị khóa đến %d", lockTime)
+		}
+
+	default:
+		return fmt.Errorf("opcode không hỗ trợ: 0x%02x", byte(op.Code))
+	}
+	return nil
+}
+
+func (e *ScriptEngine) checkSig(sig []byte, pubKey []byte) bool {
+	rawSig, hashType, err := splitSignature(sig)
+	if err != nil {
+		return false
+	}
+	hash, err := e.tx.inputSigHash(e.inputIndex, e.prevTxs, hashType)
+	if err != nil {
+		return false
+	}
+	return VerifySignature(pubKey, rawSig, hash)
+}
+
+func (e *ScriptEngine) checkMultiSig() (bool, error) {
+	nBytes, err := e.pop()
+	if err != nil {
+		return false, err
+	}
+	n, err := decodeScriptNum(nBytes)
+	if err != nil {
+		return false, err
+	}
+	if n < 1 || n > maxMultiSigKeys {
+		return false, fmt.Errorf("số khóa multisig không hợp lệ: %d", n)
+	}
+
+	pubKeys := make([][]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		if pubKeys[i], err = e.pop(); err != nil {
+			return false, err
+		}
+	}
+
+	mBytes, err := e.pop()
+	if err != nil {
+		return false, err
+	}
+	m, err := decodeScriptNum(mBytes)
+	if err != nil {
+		return false, err
+	}
+	if m < 1 || m > n {
+		return false, fmt.Errorf("số chữ ký multisig không hợp lệ: %d/%d", m, n)
+	}
+
+	sigs := make([][]byte, m)
+	for i := m - 1; i >= 0; i-- {
+		if sigs[i], err = e.pop(); err != nil {
+			return false, err
+		}
+	}
+
+	keyIdx := 0
+	for _, sig := range sigs {
+		matched := false
+		for keyIdx < len(pubKeys) {
+			pubKey := pubKeys[keyIdx]
+			keyIdx++
+			if e.checkSig(sig, pubKey) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (e *ScriptEngine) push(item []byte) {
+	e.stack = append(e.stack, item)
+}
+
+func (e *ScriptEngine) pop() ([]byte, error) {
+	if len(e.stack) == 0 {
+		return nil, errors.New("stack rỗng")
+	}
+	top := e.stack[len(e.stack)-1]
+	e.stack = e.stack[:len(e.stack)-1]
+	return top, nil
+}
+
+func (e *ScriptEngine) peek() ([]byte, error) {
+	if len(e.stack) == 0 {
+		return nil, errors.New("stack rỗng")
+	}
+	return e.stack[len(e.stack)-1], nil
+}
+
+func (ctx VerifyContext) reached(lockTime int64) bool {
+	if lockTime < lockTimeThreshold {
+		return ctx.Height >= lockTime
+	}
+	return ctx.Time >= lockTime
+}
+
+func castToBool(data []byte) bool {
+	for i, b := range data {
+		if b != 0 {
+			if i == len(data)-1 && b == 0x80 {
+				return false
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func boolBytes(v bool) []byte {
+	if v {
+		return []byte{1}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"bytes"
+	"testing"
+)
+
+func scriptKey(t *testing.T) *Wallet {
+	t.Helper()
+	w, err := NewWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+// scriptSpend tạo giao dịch tiêu output 0 (khóa bằng lock) của một giao dịch trước đó.
+func scriptSpend(lock []byte) (*Transaction, map[string]Transaction) {
+	prev := Transaction{Vout: []TxOutput{{Value: 100, Script: lock}}}
+	prev.SetID()
+	tx := &Transaction{
+		Vin:  []TxInput{{TxID: prev.ID, VoutIndex: 0, Sequence: SequenceFinal}},
+		Vout: []TxOutput{{Value: 90, PubKeyHash: bytes.Repeat([]byte{0x33}, 32)}},
+	}
+	tx.SetID()
+	return tx, map[string]Transaction{string(prev.ID): prev}
+}
+
+func scriptSig(t *testing.T, tx *Transaction, prevTxs map[string]Transaction, w *Wallet) []byte {
+	t.Helper()
+	sig, err := tx.SignatureFor(0, w.PrivateKey, prevTxs, SigHashAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func TestScriptNumRoundTrip(t *testing.T) {
+	for _, n := range []int64{0, 1, -1, 16, 127, 128, -128, 255, -255, 32767, lockTimeThreshold, 1 << 40} {
+		got, err := decodeScriptNum(encodeScriptNum(n))
+		if err != nil || got != n {
+			t.Errorf("số %d khứ hồi thành %d (%v)", n, got, err)
+		}
+	}
+	if _, err := decodeScriptNum(make([]byte, 9)); err == nil {
+		t.Error("số dài hơn 8 byte phải bị từ chối")
+	}
+}
+
+func TestParseScriptRejectsTruncatedPush(t *testing.T) {
+	for _, script := range [][]byte{
+		{0x05, 0x01, 0x02},
+		{byte(OpPushData1)},
+		{byte(OpPushData1), 0x10, 0x00},
+		{byte(OpPushData2), 0x01},
+	} {
+		if _, err := ParseScript(script); err == nil {
+			t.Errorf("script %x bị cắt cụt phải bị từ chối", script)
+		}
+	}
+
+	long := bytes.Repeat([]byte{0xab}, 300)
+	ops, err := ParseScript(NewScriptBuilder().AddData(long).AddOp(OpDrop).Script())
+	if err != nil || len(ops) != 2 || ops[0].Code != OpPushData2 || !bytes.Equal(ops[0].Data, long) {
+		t.Fatalf("PUSHDATA2 không khứ hồi: %v %v", ops, err)
+	}
+}
+
+func TestClassifyStandardScripts(t *testing.T) {
+	hash := bytes.Repeat([]byte{0x44}, 32)
+	multisig, err := NewMultiSigScript(2, [][]byte{{1}, {2}, {3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		script []byte
+		want   ScriptClass
+	}{
+		{nil, ScriptPubKeyHash},
+		{NewPubKeyHashScript(hash), ScriptPubKeyHash},
+		{multisig, ScriptMultiSig},
+		{NewHashLockScript(hash, hash), ScriptHashLock},
+		{NewTimeLockScript(1000, hash), ScriptTimeLock},
+		{NewHTLCScript(HTLCParams{Hash: hash, RecipientPubKeyHash: hash, RefundPubKeyHash: hash, Timeout: 10}), ScriptHTLC},
+		{NewScriptBuilder().AddOp(OpReturn).Script(), ScriptNonStandard},
+		{NewPubKeyHashScript(hash[:20]), ScriptNonStandard},
+	}
+	for _, c := range cases {
+		if got := ClassifyScript(c.script); got != c.want {
+			t.Errorf("ClassifyScript(%x) = %s, muốn %s", c.script, got, c.want)
+		}
+	}
+
+	if _, err := NewMultiSigScript(3, [][]byte{{1}, {2}}); err == nil {
+		t.Error("multisig 3-of-2 phải bị từ chối")
+	}
+}
+
+func TestScriptPubKeyHashSpend(t *testing.T) {
+	owner, other := scriptKey(t), scriptKey(t)
+	tx, prevTxs := scriptSpend(NewPubKeyHashScript(HashPubKey(owner.PublicKey)))
+
+	tx.Vin[0].Witness = [][]byte{scriptSig(t, tx, prevTxs, owner), owner.PublicKey}
+	if err := tx.Verify(prevTxs, VerifyContext{}); err != nil {
+		t.Fatalf("chủ output phải tiêu được: %v", err)
+	}
+
+	tx.Vin[0].Witness = [][]byte{scriptSig(t, tx, prevTxs, other), other.PublicKey}
+	if err := tx.Verify(prevTxs, VerifyContext{}); err == nil {
+		t.Fatal("khóa khác không được tiêu output P2PKH")
+	}
+
+	tx.Vin[0].Witness = [][]byte{scriptSig(t, tx, prevTxs, other), owner.PublicKey}
+	if err := tx.Verify(prevTxs, VerifyContext{}); err == nil {
+		t.Fatal("chữ ký của khóa khác kèm public key của chủ phải bị từ chối")
+	}
+}
+
+func TestScriptMultiSig(t *testing.T) {
+	keys := []*Wallet{scriptKey(t), scriptKey(t), scriptKey(t)}
+	lock, err := NewMultiSigScript(2, [][]byte{keys[0].PublicKey, keys[1].PublicKey, keys[2].PublicKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, prevTxs := scriptSpend(lock)
+	sigs := make([][]byte, len(keys))
+	for i, k := range keys {
+		sigs[i] = scriptSig(t, tx, prevTxs, k)
+	}
+
+	cases := []struct {
+		name    string
+		witness [][]byte
+		ok      bool
+	}{
+		{"khóa 0 và 2 theo thứ tự", [][]byte{sigs[0], sigs[2]}, true},
+		{"khóa 1 và 2 theo thứ tự", [][]byte{sigs[1], sigs[2]}, true},
+		{"sai thứ tự", [][]byte{sigs[2], sigs[0]}, false},
+		{"một chữ ký dùng hai lần", [][]byte{sigs[1], sigs[1]}, false},
+		{"thiếu chữ ký", [][]byte{sigs[0]}, false},
+	}
+	for _, c := range cases {
+		tx.Vin[0].Witness = c.witness
+		err := tx.Verify(prevTxs, VerifyContext{})
+		if (err == nil) != c.ok {
+			t.Errorf("%s: ok=%v, lỗi %v", c.name, c.ok, err)
+		}
+	}
+}
+
+func TestScriptHashLock(t *testing.T) {
+	owner := scriptKey(t)
+	preimage := []byte("bí mật")
+	tx, prevTxs := scriptSpend(NewHashLockScript(HashPreimage(preimage), HashPubKey(owner.PublicKey)))
+	sig := scriptSig(t, tx, prevTxs, owner)
+
+	tx.Vin[0].Witness = [][]byte{sig, owner.PublicKey, preimage}
+	if err := tx.Verify(prevTxs, VerifyContext{}); err != nil {
+		t.Fatalf("preimage đúng phải mở được hashlock: %v", err)
+	}
+	tx.Vin[0].Witness = [][]byte{sig, owner.PublicKey, []byte("sai")}
+	if err := tx.Verify(prevTxs, VerifyContext{}); err == nil {
+		t.Fatal("preimage sai không được mở hashlock")
+	}
+}
+
+func TestScriptEngineControlFlow(t *testing.T) {
+	cases := []struct {
+		name   string
+		script []byte
+		stack  [][]byte
+		ok     bool
+	}{
+		{"IF nhánh đúng", NewScriptBuilder().AddOp(OpIf).AddInt(1).AddOp(OpElse).AddInt(0).AddOp(OpEndIf).Script(), [][]byte{{1}}, true},
+		{"IF nhánh sai", NewScriptBuilder().AddOp(OpIf).AddInt(1).AddOp(OpElse).AddInt(0).AddOp(OpEndIf).Script(), [][]byte{nil}, false},
+		{"NOTIF", NewScriptBuilder().AddOp(OpNotIf).AddInt(1).AddOp(OpEndIf).Script(), [][]byte{nil}, true},
+		{"thiếu ENDIF", NewScriptBuilder().AddOp(OpIf).AddInt(1).Script(), [][]byte{{1}}, false},
+		{"ENDIF thừa", NewScriptBuilder().AddInt(1).AddOp(OpEndIf).Script(), nil, false},
+		{"OP_RETURN", NewScriptBuilder().AddInt(1).AddOp(OpReturn).Script(), nil, false},
+		{"OP_RETURN trong nhánh không chạy", NewScriptBuilder().AddInt(1).AddInt(0).AddOp(OpIf).AddOp(OpReturn).AddOp(OpEndIf).Script(), nil, true},
+		{"pop stack rỗng", NewScriptBuilder().AddOp(OpDrop).Script(), nil, false},
+		{"số âm 0 là false", NewScriptBuilder().AddData([]byte{0x80}).Script(), nil, false},
+		{"SIZE và EQUAL", NewScriptBuilder().AddOp(OpSize).AddInt(3).AddOp(OpEqualVerify).Script(), [][]byte{{1, 2, 3}}, true},
+		{"opcode lạ", []byte{0xff}, [][]byte{{1}}, false},
+	}
+	for _, c := range cases {
+		engine := NewScriptEngine(&Transaction{}, 0, nil, VerifyContext{})
+		err := engine.Execute(c.stack, c.script)
+		if (err == nil) != c.ok {
+			t.Errorf("%s: ok=%v, lỗi %v", c.name, c.ok, err)
+		}
+	}
+
+	engine := NewScriptEngine(&Transaction{}, 0, nil, VerifyContext{})
+	if err := engine.Execute([][]byte{make([]byte, maxScriptElementSize+1)}, NewScriptBuilder().AddInt(1).Script()); err == nil {
+		t.Error("phần tử unlock vượt quá giới hạn phải bị từ chối")
+	}
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+const (
+	secp256k1CompressedLen = secp256k1.PubKeyBytesLenCompressed
+	secp256k1SignatureLen  = 64
+)
+
+type secp256k1Scheme struct{}
+
+type secp256k1PrivateKey struct {
+	key    *secp256k1.PrivateKey
+	pubKey []byte
+}
+
+func (secp256k1Scheme) KeyType() KeyType {
+	return KeyTypeSecp256k1
+}
+
+func (secp256k1Scheme) Name() string {
+	return "secp256k1"
+}
+
+func (s secp256k1Scheme) GenerateKey() (PrivateKey, error) {
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return newSecp256k1PrivateKey(key), nil
+}
+
+func (secp256k1Scheme) PrivateKeyFromBytes(data []byte) (PrivateKey, error) {
+	var d secp256k1.ModNScalar
+	if len(data) > 32 || d.SetByteSlice(data) || d.IsZero() {
+		return nil, errors.New("private key secp256k1 không hợp lệ")
+	}
+	return newSecp256k1PrivateKey(secp256k1.NewPrivateKey(&d)), nil
+}
+
+func newSecp256k1PrivateKey(key *secp256k1.PrivateKey) *secp256k1PrivateKey {
+	pubKey := key.PubKey().SerializeCompressed()
+	return &secp256k1PrivateKey{key: key, pubKey: EncodePublicKey(KeyTypeSecp256k1, pubKey)}
+}
+
+func (secp256k1Scheme) Verify(rawPubKey []byte, hash []byte, sig []byte) bool {
+	if len(sig) != secp256k1SignatureLen || len(rawPubKey) != secp256k1CompressedLen {
+		return false
+	}
+	pubKey, err := secp256k1.ParsePubKey(rawPubKey)
+	if err != nil {
+		return false
+	}
+
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(sig[:32]) || s.SetByteSlice(sig[32:]) {
+		return false
+	}
+	if r.IsZero() || s.IsZero() || s.IsOverHalfOrder() {
+		return false
+	}
+	return ecdsa.NewSignature(&r, &s).Verify(hash, pubKey)
+}
+
+func (k *secp256k1PrivateKey) Scheme() SignatureScheme {
+	return secp256k1Scheme{}
+}
+
+func (k *secp256k1PrivateKey) PublicKey() []byte {
+	return append([]byte{}, k.pubKey...)
+}
+
+func (k *secp256k1PrivateKey) Bytes() []byte {
+	return k.key.Serialize()
+}
+
+func (k *secp256k1PrivateKey) Sign(hash []byte) ([]byte, error) {
+	sig := ecdsa.Sign(k.key, hash)
+	r, s := sig.R(), sig.S()
+
+	out := make([]byte, secp256k1SignatureLen)
+	r.PutBytesUnchecked(out[:32])
+	s.PutBytesUnchecked(out[32:])
+	return out, nil
+}
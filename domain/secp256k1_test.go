@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestSecp256k1PublicKeyVectors(t *testing.T) {
+	vectors := []struct {
+		priv, pub string
+	}{
+		{"0000000000000000000000000000000000000000000000000000000000000001", "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+		{"0000000000000000000000000000000000000000000000000000000000000003", "02f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9"},
+	}
+	for _, v := range vectors {
+		key, err := secp256k1Scheme{}.PrivateKeyFromBytes(mustHex(t, v.priv))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := EncodePublicKey(KeyTypeSecp256k1, mustHex(t, v.pub))
+		if !bytes.Equal(key.PublicKey(), want) {
+			t.Errorf("public key của %s = %x, muốn %x", v.priv, key.PublicKey(), want)
+		}
+	}
+}
+
+func TestSecp256k1RFC6979Vectors(t *testing.T) {
+	vectors := []struct {
+		priv, msg, sig string
+	}{
+		{
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"Satoshi Nakamoto",
+			"934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d82442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5",
+		},
+		{
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"All those moments will be lost in time, like tears in rain. Time to die...",
+			"8600dbd41e348fe5c9465ab92d23e3db8b98b873beecd930736488696438cb6b547fe64427496db33bf66019dacbf0039c04199abb0122918601db38a72cfc21",
+		},
+	}
+	for _, v := range vectors {
+		key, err := secp256k1Scheme{}.PrivateKeyFromBytes(mustHex(t, v.priv))
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash := sha256.Sum256([]byte(v.msg))
+		sig, err := key.Sign(hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(sig) != v.sig {
+			t.Errorf("chữ ký cho %q = %x, muốn %s", v.msg, sig, v.sig)
+		}
+		if !VerifySignature(key.PublicKey(), sig, hash[:]) {
+			t.Errorf("chữ ký chuẩn cho %q không qua được kiểm tra", v.msg)
+		}
+	}
+}
+
+var secp256k1Order, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+
+func TestSecp256k1RejectsHighS(t *testing.T) {
+	key, err := secp256k1Scheme{}.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte("low-s"))
+	sig, err := key.Sign(hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifySignature(key.PublicKey(), sig, hash[:]) {
+		t.Fatal("chữ ký hợp lệ bị từ chối")
+	}
+
+	s := new(big.Int).SetBytes(sig[32:])
+	if s.Cmp(new(big.Int).Rsh(secp256k1Order, 1)) > 0 {
+		t.Fatalf("Sign tạo ra S cao: %x", sig[32:])
+	}
+	highS := append([]byte{}, sig...)
+	new(big.Int).Sub(secp256k1Order, s).FillBytes(highS[32:])
+	if VerifySignature(key.PublicKey(), highS, hash[:]) {
+		t.Error("chữ ký S cao (N-s) vẫn được chấp nhận")
+	}
+
+	overflowR := append([]byte{}, sig...)
+	secp256k1Order.FillBytes(overflowR[:32])
+	if VerifySignature(key.PublicKey(), overflowR, hash[:]) {
+		t.Error("chữ ký có r >= N vẫn được chấp nhận")
+	}
+}
+
+func TestSecp256k1RejectsInvalidKeys(t *testing.T) {
+	for _, priv := range []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141",
+	} {
+		if _, err := (secp256k1Scheme{}).PrivateKeyFromBytes(mustHex(t, priv)); err == nil {
+			t.Errorf("private key %s lẽ ra phải bị từ chối", priv)
+		}
+	}
+
+	key, err := secp256k1Scheme{}.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte("pubkey"))
+	sig, err := key.Sign(hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := key.PublicKey()[1:]
+	tampered := append([]byte{}, raw...)
+	tampered[0] ^= 0x01
+	if (secp256k1Scheme{}).Verify(tampered, hash[:], sig) {
+		t.Error("public key sai prefix vẫn được chấp nhận")
+	}
+	if (secp256k1Scheme{}).Verify(raw[:32], hash[:], sig) {
+		t.Error("public key bị cắt ngắn vẫn được chấp nhận")
+	}
+}
@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type SigHashType byte
+
+const (
+	SigHashAll          SigHashType = 0x01
+	SigHashNone         SigHashType = 0x02
+	SigHashSingle       SigHashType = 0x03
+	SigHashAnyoneCanPay SigHashType = 0x80
+
+	sigHashBaseMask = 0x1f
+)
+
+func (t SigHashType) base() SigHashType {
+	return t & sigHashBaseMask
+}
+
+func (t SigHashType) anyoneCanPay() bool {
+	return t&SigHashAnyoneCanPay != 0
+}
+
+func (t SigHashType) Valid() bool {
+	if t&^(SigHashAnyoneCanPay|sigHashBaseMask) != 0 {
+		return false
+	}
+	base := t.base()
+	return base == SigHashAll || base == SigHashNone || base == SigHashSingle
+}
+
+func (t SigHashType) String() string {
+	var name string
+	switch t.base() {
+	case SigHashAll:
+		name = "ALL"
+	case SigHashNone:
+		name = "NONE"
+	case SigHashSingle:
+		name = "SINGLE"
+	default:
+		return fmt.Sprintf("0x%02x", byte(t))
+	}
+	if t.anyoneCanPay() {
+		name += "|ANYONECANPAY"
+	}
+	return name
+}
+
+func ParseSigHashType(s string) (SigHashType, error) {
+	var t SigHashType
+	for _, part := range strings.Split(strings.ToUpper(s), "|") {
+		var flag SigHashType
+		switch strings.TrimSpace(part) {
+		case "ALL":
+			flag = SigHashAll
+		case "NONE":
+			flag = SigHashNone
+		case "SINGLE":
+			flag = SigHashSingle
+		case "ANYONECANPAY":
+			t |= SigHashAnyoneCanPay
+			continue
+		default:
+			return 0, fmt.Errorf("sighash không hợp lệ: %q", part)
+		}
+		if t.base() != 0 {
+			return 0, fmt.Errorf("sighash không hợp lệ: %q (chỉ được chọn một trong ALL, NONE, SINGLE)", s)
+		}
+		t |= flag
+	}
+	if t.base() == 0 {
+		t |= SigHashAll
+	}
+	if !t.Valid() {
+		return 0, fmt.Errorf("sighash không hợp lệ: %q", s)
+	}
+	return t, nil
+}
+
+func splitSignature(sig []byte) ([]byte, SigHashType, error) {
+	if len(sig) == 0 {
+		return nil, 0, errors.New("chữ ký rỗng")
+	}
+	hashType := SigHashType(sig[len(sig)-1])
+	if !hashType.Valid() {
+		return nil, 0, fmt.Errorf("sighash không hợp lệ: 0x%02x", byte(hashType))
+	}
+	return sig[:len(sig)-1], hashType, nil
+}
+
+func (tx *Transaction) inputSigHash(inID int, prevTxs map[string]Transaction, hashType SigHashType) ([]byte, error) {
+	if !hashType.Valid() {
+		return nil, fmt.Errorf("sighash không hợp lệ: 0x%02x", byte(hashType))
+	}
+
+	vin := tx.Vin[inID]
+	prevTx := prevTxs[string(vin.TxID)]
+	if len(prevTx.ID) == 0 {
+		return nil, fmt.Errorf("referenced transaction not found: %x", vin.TxID)
+	}
+	if vin.VoutIndex < 0 || vin.VoutIndex >= len(prevTx.Vout) {
+		return nil, fmt.Errorf("referenced output not found: %x:%d", vin.TxID, vin.VoutIndex)
+	}
+	prevOut := prevTx.Vout[vin.VoutIndex]
+
+	zeroHash := make([]byte, 32)
+
+	hashPrevouts := zeroHash
+	if !hashType.anyoneCanPay() {
+		e := &encoder{}
+		for _, in := range tx.Vin {
+			e.writeBytes(in.TxID)
+			e.writeUint32(uint32(int32(in.VoutIndex)))
+		}
+		hashPrevouts = sha256Bytes(e.Bytes())
+	}
+
+	hashSequence := zeroHash
+	if !hashType.anyoneCanPay() && hashType.base() == SigHashAll {
+		e := &encoder{}
+		for _, in := range tx.Vin {
+			e.writeUint32(in.Sequence)
+		}
+		hashSequence = sha256Bytes(e.Bytes())
+	}
+
+	hashOutputs := zeroHash
+	switch hashType.base() {
+	case SigHashAll:
+		e := &encoder{}
+		for _, out := range tx.Vout {
+			out.encode(e)
+		}
+		hashOutputs = sha256Bytes(e.Bytes())
+	case SigHashSingle:
+		if inID >= len(tx.Vout) {
+			return nil, fmt.Errorf("SIGHASH_SINGLE: input %d không có output tương ứng", inID)
+		}
+		e := &encoder{}
+		tx.Vout[inID].encode(e)
+		hashOutputs = sha256Bytes(e.Bytes())
+	}
+
+	e := &encoder{}
+	e.writeUint8(TxEncodingVersion)
+	e.writeUint32(uint32(tx.Type))
+	e.buf.Write(hashPrevouts)
+	e.buf.Write(hashSequence)
+	e.writeBytes(vin.TxID)
+	e.writeUint32(uint32(int32(vin.VoutIndex)))
+	e.writeBytes(prevOut.lockingData())
+	e.writeInt64(prevOut.Value)
+	e.writeUint32(vin.Sequence)
+	e.buf.Write(hashOutputs)
+	e.writeBytes(tx.Payload)
+	e.writeInt64(tx.LockTime)
+	e.writeUint32(uint32(hashType))
+	e.writeUint32(activeParams.Magic)
+
+	return sha256Bytes(e.Bytes()), nil
+}
+
+func sha256Bytes(data []byte) []byte {
+	hash := sha256.Sum256(data)
+	return hash[:]
+}
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"bytes"
+	"testing"
+)
+
+// sighashFixture là giao dịch 2 input, 2 output tiêu hai output của cùng một giao dịch trước.
+func sighashFixture() (*Transaction, map[string]Transaction) {
+	prev := Transaction{Vout: []TxOutput{
+		{Value: 100, PubKeyHash: bytes.Repeat([]byte{0x11}, 32)},
+		{Value: 50, PubKeyHash: bytes.Repeat([]byte{0x11}, 32)},
+	}}
+	prev.SetID()
+	tx := &Transaction{
+		Vin: []TxInput{
+			{TxID: prev.ID, VoutIndex: 0, Sequence: SequenceFinal},
+			{TxID: prev.ID, VoutIndex: 1, Sequence: SequenceFinal},
+		},
+		Vout: []TxOutput{
+			{Value: 90, PubKeyHash: bytes.Repeat([]byte{0x22}, 32)},
+			{Value: 55, PubKeyHash: bytes.Repeat([]byte{0x33}, 32)},
+		},
+	}
+	tx.SetID()
+	return tx, map[string]Transaction{string(prev.ID): prev}
+}
+
+func TestParseSigHashType(t *testing.T) {
+	valid := map[string]SigHashType{
+		"ALL":                 SigHashAll,
+		"none":                SigHashNone,
+		"SINGLE":              SigHashSingle,
+		"ALL|ANYONECANPAY":    SigHashAll | SigHashAnyoneCanPay,
+		"ANYONECANPAY":        SigHashAll | SigHashAnyoneCanPay,
+		"single|anyonecanpay": SigHashSingle | SigHashAnyoneCanPay,
+	}
+	for s, want := range valid {
+		got, err := ParseSigHashType(s)
+		if err != nil || got != want {
+			t.Errorf("ParseSigHashType(%q) = %v, %v; muốn %v", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "FOO", "ALL|NONE", "ALL|FOO"} {
+		if _, err := ParseSigHashType(s); err == nil {
+			t.Errorf("ParseSigHashType(%q) phải lỗi", s)
+		}
+	}
+	for _, b := range []byte{0x00, 0x04, 0x41, 0x81 | 0x20} {
+		if SigHashType(b).Valid() {
+			t.Errorf("sighash 0x%02x không được hợp lệ", b)
+		}
+	}
+}
+
+func TestSigHashCoverage(t *testing.T) {
+	mutations := []struct {
+		name   string
+		mutate func(tx *Transaction)
+		// các loại sighash mà thay đổi này KHÔNG làm đổi hash của input 0
+		unaffected []SigHashType
+	}{
+		{"đổi output 1", func(tx *Transaction) { tx.Vout[1].Value = 1 },
+			[]SigHashType{SigHashNone, SigHashSingle, SigHashNone | SigHashAnyoneCanPay, SigHashSingle | SigHashAnyoneCanPay}},
+		{"đổi output 0", func(tx *Transaction) { tx.Vout[0].Value = 1 },
+			[]SigHashType{SigHashNone, SigHashNone | SigHashAnyoneCanPay}},
+		{"thêm output", func(tx *Transaction) { tx.Vout = append(tx.Vout, TxOutput{Value: 1}) },
+			[]SigHashType{SigHashNone, SigHashSingle, SigHashNone | SigHashAnyoneCanPay, SigHashSingle | SigHashAnyoneCanPay}},
+		{"bỏ input 1", func(tx *Transaction) { tx.Vin = tx.Vin[:1] },
+			[]SigHashType{SigHashAll | SigHashAnyoneCanPay, SigHashNone | SigHashAnyoneCanPay, SigHashSingle | SigHashAnyoneCanPay}},
+		{"đổi sequence input 1", func(tx *Transaction) { tx.Vin[1].Sequence = 0 },
+			[]SigHashType{SigHashNone, SigHashSingle, SigHashAll | SigHashAnyoneCanPay, SigHashNone | SigHashAnyoneCanPay, SigHashSingle | SigHashAnyoneCanPay}},
+		{"đổi locktime", func(tx *Transaction) { tx.LockTime = 5 }, nil},
+	}
+	hashTypes := []SigHashType{
+		SigHashAll, SigHashNone, SigHashSingle,
+		SigHashAll | SigHashAnyoneCanPay, SigHashNone | SigHashAnyoneCanPay, SigHashSingle | SigHashAnyoneCanPay,
+	}
+
+	for _, m := range mutations {
+		for _, hashType := range hashTypes {
+			tx, prevTxs := sighashFixture()
+			before, err := tx.inputSigHash(0, prevTxs, hashType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m.mutate(tx)
+			after, err := tx.inputSigHash(0, prevTxs, hashType)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wantSame := false
+			for _, u := range m.unaffected {
+				wantSame = wantSame || u == hashType
+			}
+			if same := bytes.Equal(before, after); same != wantSame {
+				t.Errorf("%s với %v: hash giữ nguyên = %v, muốn %v", m.name, hashType, same, wantSame)
+			}
+		}
+	}
+}
+
+func TestSigHashTypesDiffer(t *testing.T) {
+	tx, prevTxs := sighashFixture()
+	seen := make(map[string]SigHashType)
+	for _, hashType := range []SigHashType{SigHashAll, SigHashNone, SigHashSingle, SigHashAll | SigHashAnyoneCanPay} {
+		hash, err := tx.inputSigHash(0, prevTxs, hashType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if other, ok := seen[string(hash)]; ok {
+			t.Errorf("sighash %v trùng với %v", hashType, other)
+		}
+		seen[string(hash)] = hashType
+	}
+}
+
+func TestSigHashSingleRequiresMatchingOutput(t *testing.T) {
+	tx, prevTxs := sighashFixture()
+	tx.Vout = tx.Vout[:1]
+	if _, err := tx.inputSigHash(1, prevTxs, SigHashSingle); err == nil {
+		t.Fatal("SIGHASH_SINGLE cho input không có output tương ứng phải lỗi")
+	}
+	if _, err := tx.inputSigHash(1, prevTxs, SigHashAll); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyHonoursSignatureHashType(t *testing.T) {
+	w, err := NewWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, prevTxs := sighashFixture()
+	for _, prev := range prevTxs {
+		for i := range prev.Vout {
+			prev.Vout[i].PubKeyHash = HashPubKey(w.PublicKey)
+		}
+	}
+
+	if err := tx.SignWithHashType(w.PrivateKey, prevTxs, SigHashSingle); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(prevTxs, VerifyContext{}); err != nil {
+		t.Fatalf("chữ ký SINGLE hợp lệ bị từ chối: %v", err)
+	}
+
+	tx.Vout = append(tx.Vout, TxOutput{Value: 1, PubKeyHash: bytes.Repeat([]byte{0x44}, 32)})
+	if err := tx.Verify(prevTxs, VerifyContext{}); err != nil {
+		t.Fatalf("SINGLE chỉ cam kết output cùng chỉ số, thêm output khác phải vẫn hợp lệ: %v", err)
+	}
+	tx.Vout[0].Value = 1
+	if err := tx.Verify(prevTxs, VerifyContext{}); err == nil {
+		t.Fatal("đổi output mà input 0 đã cam kết phải làm chữ ký hết hợp lệ")
+	}
+
+	tx, _ = sighashFixture()
+	if err := tx.Sign(w.PrivateKey, prevTxs); err != nil {
+		t.Fatal(err)
+	}
+	sig := tx.Vin[0].Signature
+	sig[len(sig)-1] = 0x04
+	if err := tx.Verify(prevTxs, VerifyContext{}); err == nil {
+		t.Fatal("byte sighash không hợp lệ phải bị từ chối")
+	}
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type KeyType byte
+
+const (
+	KeyTypeP256      KeyType = 0x00
+	KeyTypeSecp256k1 KeyType = 0x01
+	KeyTypeEd25519   KeyType = 0x02
+)
+
+const p256PublicKeyLen = 64
+
+func (t KeyType) String() string {
+	if scheme, err := SchemeFor(t); err == nil {
+		return scheme.Name()
+	}
+	return fmt.Sprintf("unknown(0x%02x)", byte(t))
+}
+
+type SignatureScheme interface {
+	KeyType() KeyType
+	Name() string
+	GenerateKey() (PrivateKey, error)
+	PrivateKeyFromBytes(data []byte) (PrivateKey, error)
+	Verify(rawPubKey []byte, hash []byte, sig []byte) bool
+}
+
+type PrivateKey interface {
+	Scheme() SignatureScheme
+	PublicKey() []byte
+	Bytes() []byte
+	Sign(hash []byte) ([]byte, error)
+}
+
+type SignatureCheck struct {
+	PublicKey []byte
+	Signature []byte
+	Hash      []byte
+}
+
+var signatureSchemes = map[KeyType]SignatureScheme{
+	KeyTypeP256:      p256Scheme{},
+	KeyTypeSecp256k1: secp256k1Scheme{},
+	KeyTypeEd25519:   ed25519Scheme{},
+}
+
+func SchemeFor(t KeyType) (SignatureScheme, error) {
+	scheme, ok := signatureSchemes[t]
+	if !ok {
+		return nil, fmt.Errorf("loại khóa không hỗ trợ: 0x%02x", byte(t))
+	}
+	return scheme, nil
+}
+
+func ParseKeyType(name string) (KeyType, error) {
+	for t, scheme := range signatureSchemes {
+		if strings.EqualFold(scheme.Name(), name) {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("loại khóa không hỗ trợ: %q", name)
+}
+
+func EncodePublicKey(t KeyType, rawPubKey []byte) []byte {
+	if t == KeyTypeP256 {
+		return append([]byte{}, rawPubKey...)
+	}
+	return append([]byte{byte(t)}, rawPubKey...)
+}
+
+func ParsePublicKey(pubKey []byte) (SignatureScheme, []byte, error) {
+	if len(pubKey) == p256PublicKeyLen {
+		return p256Scheme{}, pubKey, nil
+	}
+	if len(pubKey) < 2 {
+		return nil, nil, errors.New("public key quá ngắn")
+	}
+	t := KeyType(pubKey[0])
+	if t == KeyTypeP256 {
+		return nil, nil, errors.New("public key P-256 phải ở dạng X||Y 64 byte")
+	}
+	scheme, err := SchemeFor(t)
+	if err != nil {
+		return nil, nil, err
+	}
+	return scheme, pubKey[1:], nil
+}
+
+func PublicKeyType(pubKey []byte) (KeyType, error) {
+	scheme, _, err := ParsePublicKey(pubKey)
+	if err != nil {
+		return 0, err
+	}
+	return scheme.KeyType(), nil
+}
+
+func VerifySignature(pubKey []byte, sig []byte, hash []byte) bool {
+	scheme, raw, err := ParsePublicKey(pubKey)
+	if err != nil {
+		return false
+	}
+	return scheme.Verify(raw, hash, sig)
+}
+
+func VerifySignatures(checks []SignatureCheck) bool {
+	for _, check := range checks {
+		if !VerifySignature(check.PublicKey, check.Signature, check.Hash) {
+			return false
+		}
+	}
+	return true
+}
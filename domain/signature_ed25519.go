@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+)
+
+type ed25519Scheme struct{}
+
+type ed25519PrivateKey struct {
+	key ed25519.PrivateKey
+}
+
+func (ed25519Scheme) KeyType() KeyType {
+	return KeyTypeEd25519
+}
+
+func (ed25519Scheme) Name() string {
+	return "ed25519"
+}
+
+func (ed25519Scheme) GenerateKey() (PrivateKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &ed25519PrivateKey{key: key}, nil
+}
+
+func (ed25519Scheme) PrivateKeyFromBytes(data []byte) (PrivateKey, error) {
+	if len(data) != ed25519.SeedSize {
+		return nil, errors.New("seed Ed25519 phải dài 32 byte")
+	}
+	return &ed25519PrivateKey{key: ed25519.NewKeyFromSeed(data)}, nil
+}
+
+func (ed25519Scheme) Verify(rawPubKey []byte, hash []byte, sig []byte) bool {
+	if len(rawPubKey) != ed25519.PublicKeySize || len(sig) != ed25519.SignatureSize {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(rawPubKey), hash, sig)
+}
+
+func (k *ed25519PrivateKey) Scheme() SignatureScheme {
+	return ed25519Scheme{}
+}
+
+func (k *ed25519PrivateKey) PublicKey() []byte {
+	return EncodePublicKey(KeyTypeEd25519, k.key.Public().(ed25519.PublicKey))
+}
+
+func (k *ed25519PrivateKey) Bytes() []byte {
+	return k.key.Seed()
+}
+
+func (k *ed25519PrivateKey) Sign(hash []byte) ([]byte, error) {
+	return ed25519.Sign(k.key, hash), nil
+}
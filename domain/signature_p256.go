@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+type p256Scheme struct{}
+
+type p256PrivateKey struct {
+	key *ecdsa.PrivateKey
+}
+
+func (p256Scheme) KeyType() KeyType {
+	return KeyTypeP256
+}
+
+func (p256Scheme) Name() string {
+	return "p256"
+}
+
+func (p256Scheme) GenerateKey() (PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &p256PrivateKey{key: key}, nil
+}
+
+func (p256Scheme) PrivateKeyFromBytes(data []byte) (PrivateKey, error) {
+	curve := elliptic.P256()
+	d := new(big.Int).SetBytes(data)
+	if d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+		return nil, errors.New("private key P-256 không hợp lệ")
+	}
+
+	key := new(ecdsa.PrivateKey)
+	key.D = d
+	key.PublicKey.Curve = curve
+	key.PublicKey.X, key.PublicKey.Y = curve.ScalarBaseMult(d.FillBytes(make([]byte, 32)))
+	return &p256PrivateKey{key: key}, nil
+}
+
+func (p256Scheme) Verify(rawPubKey []byte, hash []byte, sig []byte) bool {
+	if len(sig) != 64 || len(rawPubKey) != p256PublicKeyLen {
+		return false
+	}
+
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+	x, y := new(big.Int).SetBytes(rawPubKey[:32]), new(big.Int).SetBytes(rawPubKey[32:])
+
+	pubKey := ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+	return ecdsa.Verify(&pubKey, hash, r, s)
+}
+
+func (k *p256PrivateKey) Scheme() SignatureScheme {
+	return p256Scheme{}
+}
+
+func (k *p256PrivateKey) PublicKey() []byte {
+	pubKey := make([]byte, p256PublicKeyLen)
+	k.key.PublicKey.X.FillBytes(pubKey[:32])
+	k.key.PublicKey.Y.FillBytes(pubKey[32:])
+	return pubKey
+}
+
+func (k *p256PrivateKey) Bytes() []byte {
+	return k.key.D.FillBytes(make([]byte, 32))
+}
+
+func (k *p256PrivateKey) Sign(hash []byte) ([]byte, error) {
+	r, s, err := ecdsa.Sign(rand.Reader, k.key, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return sig, nil
+}
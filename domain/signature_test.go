@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+)
+
+func signedChecks(t *testing.T, keyType KeyType, n int) []SignatureCheck {
+	t.Helper()
+	scheme, err := SchemeFor(keyType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var checks []SignatureCheck
+	for i := 0; i < n; i++ {
+		key, err := scheme.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%s-%d", scheme.Name(), i)))
+		sig, err := key.Sign(hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		checks = append(checks, SignatureCheck{PublicKey: key.PublicKey(), Signature: sig, Hash: hash[:]})
+	}
+	return checks
+}
+
+func TestVerifySignaturesMixedSchemes(t *testing.T) {
+	var checks []SignatureCheck
+	for _, keyType := range []KeyType{KeyTypeP256, KeyTypeSecp256k1, KeyTypeEd25519} {
+		checks = append(checks, signedChecks(t, keyType, 8)...)
+	}
+	if !VerifySignatures(checks) {
+		t.Fatal("các chữ ký hợp lệ bị từ chối")
+	}
+
+	for i := range checks {
+		bad := append([]SignatureCheck{}, checks...)
+		sig := append([]byte{}, bad[i].Signature...)
+		sig[len(sig)/2] ^= 0x01
+		bad[i].Signature = sig
+		if VerifySignatures(bad) {
+			t.Fatalf("chữ ký sai ở vị trí %d không bị phát hiện", i)
+		}
+	}
+}
+
+func TestSchemesSignAndRestoreKeys(t *testing.T) {
+	hash := sha256.Sum256([]byte("thông điệp"))
+	other := sha256.Sum256([]byte("thông điệp khác"))
+	for keyType, scheme := range signatureSchemes {
+		key, err := scheme.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := key.Sign(hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub := key.PublicKey()
+		if !VerifySignature(pub, sig, hash[:]) {
+			t.Errorf("%s: chữ ký hợp lệ bị từ chối", scheme.Name())
+		}
+		if VerifySignature(pub, sig, other[:]) {
+			t.Errorf("%s: chữ ký được chấp nhận trên hash khác", scheme.Name())
+		}
+		if got, err := PublicKeyType(pub); err != nil || got != keyType {
+			t.Errorf("%s: PublicKeyType = %v, %v", scheme.Name(), got, err)
+		}
+		if got, err := ParseKeyType(scheme.Name()); err != nil || got != keyType {
+			t.Errorf("ParseKeyType(%q) = %v, %v", scheme.Name(), got, err)
+		}
+
+		restored, err := scheme.PrivateKeyFromBytes(key.Bytes())
+		if err != nil {
+			t.Fatalf("%s: khôi phục private key: %v", scheme.Name(), err)
+		}
+		if !bytes.Equal(restored.PublicKey(), pub) {
+			t.Errorf("%s: khóa khôi phục có public key khác", scheme.Name())
+		}
+	}
+	if _, err := ParseKeyType("rsa"); err == nil {
+		t.Error("ParseKeyType phải từ chối loại khóa lạ")
+	}
+}
+
+func TestPublicKeyCannotSwitchScheme(t *testing.T) {
+	checks := signedChecks(t, KeyTypeEd25519, 1)
+	pub := append([]byte{}, checks[0].PublicKey...)
+	pub[0] = byte(KeyTypeSecp256k1)
+	if VerifySignature(pub, checks[0].Signature, checks[0].Hash) {
+		t.Fatal("đổi tiền tố loại khóa không được giữ chữ ký hợp lệ")
+	}
+	if _, _, err := ParsePublicKey([]byte{0x7f, 1, 2, 3}); err == nil {
+		t.Fatal("tiền tố loại khóa lạ phải bị từ chối")
+	}
+	if _, _, err := ParsePublicKey([]byte{byte(KeyTypeP256), 1, 2}); err == nil {
+		t.Fatal("P-256 có tiền tố phải bị từ chối")
+	}
+}
+
+func TestWalletAddressPerKeyType(t *testing.T) {
+	useParams(t, &RegTestParams)
+	for keyType := range signatureSchemes {
+		w, err := NewWalletWithKeyType(keyType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		address := w.GetAddress()
+		if got, err := AddressKeyType(address); err != nil || got != keyType {
+			t.Errorf("%v: AddressKeyType = %v, %v", keyType, got, err)
+		}
+		pubKeyHash, err := DecodeAddress(address)
+		if err != nil || !bytes.Equal(pubKeyHash, HashPubKey(w.PublicKey)) {
+			t.Errorf("%v: DecodeAddress không trả về hash của public key (%v)", keyType, err)
+		}
+
+		prev := Transaction{Vout: []TxOutput{{Value: 10, PubKeyHash: pubKeyHash}}}
+		prev.SetID()
+		prevTxs := map[string]Transaction{string(prev.ID): prev}
+		tx := &Transaction{Vin: []TxInput{{TxID: prev.ID, Sequence: SequenceFinal}}, Vout: []TxOutput{{Value: 10, PubKeyHash: pubKeyHash}}}
+		tx.SetID()
+		if err := tx.Sign(w.PrivateKey, prevTxs); err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Verify(prevTxs, VerifyContext{}); err != nil {
+			t.Errorf("%v: không tiêu được output của chính ví: %v", keyType, err)
+		}
+	}
+
+	w, err := NewWalletWithKeyType(KeyTypeEd25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := w.GetAddress()
+	useParams(t, &MainNetParams)
+	if ValidateAddress(address) {
+		t.Fatal("địa chỉ regtest không được hợp lệ trên mainnet")
+	}
+}
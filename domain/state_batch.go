@@ -0,0 +1,91 @@
+package domain
+
+import "fmt"
+
+type stateKey struct {
+	address string
+	key     string
+}
+
+type StateBatch struct {
+	bc     *Blockchain
+	parent *StateBatch
+	state  map[stateKey][]byte
+	code   map[string][]byte
+}
+
+func NewStateBatch(bc *Blockchain) *StateBatch {
+	return &StateBatch{
+		bc:    bc,
+		state: make(map[stateKey][]byte),
+		code:  make(map[string][]byte),
+	}
+}
+
+func (s *StateBatch) Fork() *StateBatch {
+	child := NewStateBatch(s.bc)
+	child.parent = s
+	return child
+}
+
+func (s *StateBatch) Commit() {
+	if s.parent == nil {
+		return
+	}
+	for k, value := range s.state {
+		s.parent.state[k] = value
+	}
+	for address, code := range s.code {
+		s.parent.code[address] = code
+	}
+	s.state = make(map[stateKey][]byte)
+	s.code = make(map[string][]byte)
+}
+
+func (s *StateBatch) GetContractState(contractAddress []byte, key []byte) ([]byte, error) {
+	k := stateKey{address: string(contractAddress), key: string(key)}
+	for b := s; b != nil; b = b.parent {
+		if value, ok := b.state[k]; ok {
+			return value, nil
+		}
+	}
+	if s.bc == nil {
+		return nil, nil
+	}
+	return s.bc.GetContractState(contractAddress, key)
+}
+
+func (s *StateBatch) SetContractState(contractAddress []byte, key []byte, value []byte) error {
+	s.state[stateKey{address: string(contractAddress), key: string(key)}] = append([]byte{}, value...)
+	return nil
+}
+
+func (s *StateBatch) GetContractCode(contractAddress []byte) ([]byte, error) {
+	for b := s; b != nil; b = b.parent {
+		if code, ok := b.code[string(contractAddress)]; ok {
+			return code, nil
+		}
+	}
+	if s.bc == nil {
+		return nil, fmt.Errorf("%w: %x", ErrContractNotFound, contractAddress)
+	}
+	return s.bc.GetContractCode(contractAddress)
+}
+
+func (s *StateBatch) SetContractCode(contractAddress []byte, code []byte) {
+	s.code[string(contractAddress)] = append([]byte{}, code...)
+}
+
+func (s *StateBatch) write(w ChainWriter) error {
+	for k, value := range s.state {
+		if err := w.SetContractState([]byte(k.address), []byte(k.key), value); err != nil {
+			return err
+		}
+	}
+	for address, code := range s.code {
+		if err := w.SetContractCode([]byte(address), code); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "errors"
+
+var ErrNotFound = errors.New("không tìm thấy dữ liệu")
+
+type ChainStore interface {
+	Block(hash []byte) (*Block, error)
+	LastHash() ([]byte, error)
+
+	TxLocation(txID []byte) (blockHash []byte, position int, err error)
+	AddressIndex(pubKeyHash []byte, fn func(entry AddressHistoryEntry) bool) error
+
+	BlockUndo(hash []byte) (*BlockUndo, error)
+
+	UTXO(txID []byte) (*UTXOEntry, error)
+	ForEachUTXO(fn func(txID []byte, entry *UTXOEntry) bool) error
+
+	ContractState(address, key []byte) ([]byte, error)
+	ContractCode(address []byte) ([]byte, error)
+	ForEachContractState(fn func(address, key, value []byte) bool) error
+	ForEachContractCode(fn func(address, code []byte) bool) error
+
+	Meta(key string) ([]byte, error)
+
+	Update(fn func(w ChainWriter) error) error
+	Close() error
+}
+
+type ChainWriter interface {
+	PutBlock(block *Block) error
+	SetLastHash(hash []byte) error
+	PutBlockUndo(hash []byte, undo *BlockUndo) error
+	DeleteBlockUndo(hash []byte) error
+
+	PutTxLocation(txID, blockHash []byte, position int) error
+	PutAddressIndex(pubKeyHash []byte, entry AddressHistoryEntry) error
+	DeleteTxLocation(txID []byte) error
+	DeleteAddressIndex(pubKeyHash []byte, height int64, position int) error
+	ClearIndex() error
+
+	PutUTXO(txID []byte, entry *UTXOEntry) error
+	DeleteUTXO(txID []byte) error
+	ClearUTXOs() error
+
+	SetContractState(address, key, value []byte) error
+	DeleteContractState(address, key []byte) error
+	SetContractCode(address, code []byte) error
+	DeleteContractCode(address []byte) error
+	ClearContracts() error
+
+	SetMeta(key string, value []byte) error
+}
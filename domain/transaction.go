@@ -2,17 +2,8 @@ package domain
 
 import (
 	"bytes"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
 	"crypto/sha256"
-	"encoding/base64"
-	"encoding/gob"
-	"encoding/json"
-
 	"fmt"
-	"log"
-	"math/big"
 )
 
 type TxType int
@@ -23,125 +14,107 @@ const (
 	TxTypeContractCall   TxType = 2
 )
 
+const (
+	SequenceFinal               uint32 = 0xffffffff
+	SequenceLockTimeDisableFlag uint32 = 1 << 31
+	SequenceLockTimeTypeFlag    uint32 = 1 << 22
+	SequenceLockTimeMask        uint32 = 0x0000ffff
+	SequenceLockTimeGranularity        = 9
+)
+
 type TxInput struct {
 	TxID      []byte
 	VoutIndex int
 	Signature []byte
 	PublicKey []byte
+	Witness   [][]byte
+	Sequence  uint32
 }
 
 type TxOutput struct {
 	Value      int64
 	PubKeyHash []byte
+	Script     []byte
 }
 
 type Transaction struct {
-	ID      []byte
-	Vin     []TxInput  `json:"vinList"`
-	Vout    []TxOutput `json:"voutList"`
-	Type    TxType     `json:"type"`
-	Payload []byte     `json:"payload"`
-}
-
-type jsonTxInputHash struct {
-	TxID      string `json:"txId"`
-	VoutIndex int    `json:"voutIndex"`
-	Signature string `json:"signature"`
-	PublicKey string `json:"publicKey"`
-}
-
-type jsonTxOutputHash struct {
-	Value      string `json:"value"`
-	PubKeyHash string `json:"pubKeyHash"`
-}
-
-type jsonTransactionHash struct {
-	ID      string             `json:"id"`
-	Vin     []jsonTxInputHash  `json:"vinList"`
-	Vout    []jsonTxOutputHash `json:"voutList"`
-	Type    TxType             `json:"type"`
-	Payload string             `json:"payload"`
+	ID       []byte
+	Vin      []TxInput  `json:"vinList"`
+	Vout     []TxOutput `json:"voutList"`
+	Type     TxType     `json:"type"`
+	Payload  []byte     `json:"payload"`
+	LockTime int64      `json:"lockTime"`
 }
 
 func (tx *Transaction) Hash() []byte {
-
-	hashCopy := jsonTransactionHash{
-		ID:      base64.StdEncoding.EncodeToString(tx.ID),
-		Payload: base64.StdEncoding.EncodeToString(tx.Payload),
-		Type:    tx.Type,
-		Vin:     make([]jsonTxInputHash, len(tx.Vin)),
-		Vout:    make([]jsonTxOutputHash, len(tx.Vout)),
-	}
-
-	for i, vin := range tx.Vin {
-		hashCopy.Vin[i] = jsonTxInputHash{
-			TxID:      base64.StdEncoding.EncodeToString(vin.TxID),
-			VoutIndex: vin.VoutIndex,
-			Signature: "",
-			PublicKey: base64.StdEncoding.EncodeToString(vin.PublicKey),
-		}
-	}
-
-	for i, out := range tx.Vout {
-		hashCopy.Vout[i] = jsonTxOutputHash{
-			Value:      fmt.Sprintf("%d", out.Value),
-			PubKeyHash: base64.StdEncoding.EncodeToString(out.PubKeyHash),
-		}
-	}
-
-	jsonData, err := json.Marshal(hashCopy)
-	if err != nil {
-		Handle(fmt.Errorf("failed to marshal hash struct: %v", err))
-	}
-
-	fmt.Println("--- Backend JSON Hashed (struct) ---")
-	logData, _ := json.MarshalIndent(hashCopy, "", "  ")
-	fmt.Println(string(logData))
-	fmt.Println("----------------------------------")
-
-	hash := sha256.Sum256(jsonData)
+	hash := sha256.Sum256(tx.Serialize())
 	return hash[:]
 }
 
 func (tx *Transaction) SetID() {
-	var encoded bytes.Buffer
-	enc := gob.NewEncoder(&encoded)
-	err := enc.Encode(tx)
-	if err != nil {
-		log.Panic(err)
-	}
-	hash := sha256.Sum256(encoded.Bytes())
-	tx.ID = hash[:]
+	tx.ID = tx.ComputeID()
 }
 
 func (tx *Transaction) IsCoinbase() bool {
 	return len(tx.Vin) == 1 && len(tx.Vin[0].TxID) == 0
 }
 
-func (out *TxOutput) Lock(address string) {
-	pubKeyHash := DecodeAddress(address)
+func (out *TxOutput) Lock(address string) error {
+	pubKeyHash, err := DecodeAddress(address)
+	if err != nil {
+		return err
+	}
 	out.PubKeyHash = pubKeyHash
+	return nil
 }
 
 func (out *TxOutput) IsLockedWithKey(pubKeyHash []byte) bool {
 	return bytes.Equal(out.PubKeyHash, pubKeyHash)
 }
 
+func (out *TxOutput) LockingScript() []byte {
+	if len(out.Script) > 0 {
+		return out.Script
+	}
+	return NewPubKeyHashScript(out.PubKeyHash)
+}
+
+func (out *TxOutput) lockingData() []byte {
+	if len(out.Script) > 0 {
+		return out.Script
+	}
+	return out.PubKeyHash
+}
+
+func (in *TxInput) UnlockingStack() [][]byte {
+	if len(in.Witness) > 0 {
+		return in.Witness
+	}
+	return [][]byte{in.Signature, in.PublicKey}
+}
+
 func (in *TxInput) CanBeUnlockedWith(pubKeyHash []byte) bool {
 	lockingHash := HashPubKey(in.PublicKey)
 	return bytes.Equal(lockingHash, pubKeyHash)
 }
 
-func NewCoinbaseTransaction(toAddress string, amount int64) *Transaction {
+func NewCoinbaseTransaction(toAddress string, amount int64, height int64) (*Transaction, error) {
+	pubKeyHash, err := DecodeAddress(toAddress)
+	if err != nil {
+		return nil, err
+	}
+	return newCoinbaseTo(pubKeyHash, amount, height), nil
+}
+
+func newCoinbaseTo(pubKeyHash []byte, amount int64, height int64) *Transaction {
 	txin := TxInput{
 		TxID:      []byte{},
 		VoutIndex: -1,
 		Signature: nil,
-		PublicKey: []byte("Reward"),
+		PublicKey: []byte(fmt.Sprintf("Reward-%d", height)),
 	}
 
-	txout := TxOutput{Value: amount, PubKeyHash: nil}
-	txout.Lock(toAddress)
+	txout := TxOutput{Value: amount, PubKeyHash: pubKeyHash}
 
 	tx := Transaction{
 		ID:      nil,
@@ -162,104 +135,161 @@ func (tx *Transaction) TrimmedCopy() Transaction {
 			VoutIndex: vin.VoutIndex,
 			Signature: nil,
 			PublicKey: nil,
+			Sequence:  vin.Sequence,
 		})
 	}
 
 	return Transaction{
-		ID:      tx.ID,
-		Vin:     inputs,
-		Vout:    tx.Vout,
-		Type:    tx.Type,
-		Payload: tx.Payload,
+		ID:       tx.ID,
+		Vin:      inputs,
+		Vout:     tx.Vout,
+		Type:     tx.Type,
+		Payload:  tx.Payload,
+		LockTime: tx.LockTime,
 	}
 }
 
-func (tx *Transaction) Sign(privKey ecdsa.PrivateKey, prevTxs map[string]Transaction) {
-	if tx.IsCoinbase() {
-		return
+func (tx *Transaction) IsFinal(ctx VerifyContext) bool {
+	if tx.LockTime == 0 || ctx.reached(tx.LockTime) {
+		return true
 	}
+	for _, vin := range tx.Vin {
+		if vin.Sequence != SequenceFinal {
+			return false
+		}
+	}
+	return true
+}
 
-	txCopy := tx.TrimmedCopy()
-
-	for inID, vin := range txCopy.Vin {
-		prevTx := prevTxs[string(vin.TxID)]
-		if len(prevTx.ID) == 0 {
-			Handle(fmt.Errorf("ERROR: Referenced transaction not found: %x", vin.TxID))
-			return
+func (tx *Transaction) IsStandard() bool {
+	for _, out := range tx.Vout {
+		if !IsStandardScript(out.Script) {
+			return false
 		}
+	}
+	return true
+}
 
-		prevOut := prevTx.Vout[vin.VoutIndex]
+func (tx *Transaction) DustOutputs(threshold int64) []int {
+	var dust []int
+	for i, out := range tx.Vout {
+		if out.Value < threshold {
+			dust = append(dust, i)
+		}
+	}
+	return dust
+}
 
-		txCopy.Vin[inID].PublicKey = prevOut.PubKeyHash
+func (tx *Transaction) SignatureFor(inID int, privKey PrivateKey, prevTxs map[string]Transaction, hashType SigHashType) ([]byte, error) {
+	dataToSign, err := tx.inputSigHash(inID, prevTxs, hashType)
+	if err != nil {
+		return nil, err
+	}
 
-		dataToSign := txCopy.Hash()
+	sig, err := privKey.Sign(dataToSign)
+	if err != nil {
+		return nil, err
+	}
 
-		txCopy.Vin[inID].PublicKey = nil
+	return append(sig, byte(hashType)), nil
+}
 
-		r, s, err := ecdsa.Sign(rand.Reader, &privKey, dataToSign)
-		Handle(err)
+func (tx *Transaction) Sign(privKey PrivateKey, prevTxs map[string]Transaction) error {
+	return tx.SignWithHashType(privKey, prevTxs, SigHashAll)
+}
 
-		rBytes := r.Bytes()
-		sBytes := s.Bytes()
-		sig := make([]byte, 64)
-		copy(sig[32-len(rBytes):], rBytes)
-		copy(sig[64-len(sBytes):], sBytes)
+func (tx *Transaction) SignWithHashType(privKey PrivateKey, prevTxs map[string]Transaction, hashType SigHashType) error {
+	if tx.IsCoinbase() {
+		return nil
+	}
 
+	for inID := range tx.Vin {
+		sig, err := tx.SignatureFor(inID, privKey, prevTxs, hashType)
+		if err != nil {
+			return err
+		}
 		tx.Vin[inID].Signature = sig
+		tx.Vin[inID].PublicKey = privKey.PublicKey()
+	}
+	return nil
+}
+
+func (tx *Transaction) SignOwnedInputs(privKey PrivateKey, prevTxs map[string]Transaction, hashType SigHashType) (int, error) {
+	publicKey := privKey.PublicKey()
+	pubKeyHash := HashPubKey(publicKey)
 
-		tx.Vin[inID].PublicKey = append(privKey.PublicKey.X.Bytes(), privKey.PublicKey.Y.Bytes()...)
+	signed := 0
+	for inID, vin := range tx.Vin {
+		prevTx, ok := prevTxs[string(vin.TxID)]
+		if !ok || vin.VoutIndex < 0 || vin.VoutIndex >= len(prevTx.Vout) {
+			continue
+		}
+		prevOut := prevTx.Vout[vin.VoutIndex]
+		if len(prevOut.Script) > 0 || !prevOut.IsLockedWithKey(pubKeyHash) {
+			continue
+		}
+
+		sig, err := tx.SignatureFor(inID, privKey, prevTxs, hashType)
+		if err != nil {
+			return signed, err
+		}
+		tx.Vin[inID].Signature = sig
+		tx.Vin[inID].PublicKey = publicKey
+		signed++
 	}
+	return signed, nil
 }
 
-func (tx *Transaction) Verify(prevTxs map[string]Transaction) bool {
+func (tx *Transaction) Verify(prevTxs map[string]Transaction, ctx VerifyContext) error {
 	if tx.IsCoinbase() {
-		return true
+		return nil
 	}
 
-	txCopy := tx.TrimmedCopy()
-	curve := elliptic.P256()
-
+	var checks []SignatureCheck
 	for inID, vin := range tx.Vin {
 		prevTx := prevTxs[string(vin.TxID)]
 		if len(prevTx.ID) == 0 {
-			log.Printf("Verify ERROR: Referenced transaction not found: %x", vin.TxID)
-			return false
+			return fmt.Errorf("%w: input %d tham chiếu %x", ErrTxNotFound, inID, vin.TxID)
 		}
-
-		prevOut := prevTx.Vout[vin.VoutIndex]
-
-		txCopy.Vin[inID].PublicKey = prevOut.PubKeyHash
-		dataToVerify := txCopy.Hash()
-		txCopy.Vin[inID].PublicKey = nil
-
-		if len(vin.Signature) != 64 {
-			log.Println("Verify ERROR: Invalid signature length")
-			return false
+		if vin.VoutIndex < 0 || vin.VoutIndex >= len(prevTx.Vout) {
+			return fmt.Errorf("%w: input %d tham chiếu output %x:%d không tồn tại", ErrInvalidTransaction, inID, vin.TxID, vin.VoutIndex)
 		}
-		r, s := big.Int{}, big.Int{}
-		r.SetBytes(vin.Signature[:32])
-		s.SetBytes(vin.Signature[32:])
 
-		if len(vin.PublicKey) != 64 {
-			log.Println("Verify ERROR: Invalid public key length")
-			return false
+		prevOut := prevTx.Vout[vin.VoutIndex]
+		if len(prevOut.Script) == 0 && len(vin.Witness) == 0 {
+			check, err := tx.pubKeyHashCheck(inID, prevOut, prevTxs)
+			if err != nil {
+				return fmt.Errorf("%w: input %d: %v", ErrInvalidSignature, inID, err)
+			}
+			checks = append(checks, check)
+			continue
 		}
-		x, y := big.Int{}, big.Int{}
-		x.SetBytes(vin.PublicKey[:32])
-		y.SetBytes(vin.PublicKey[32:])
 
-		rawPubKey := ecdsa.PublicKey{Curve: curve, X: &x, Y: &y}
-		if !ecdsa.Verify(&rawPubKey, dataToVerify, &r, &s) {
-			log.Printf("Verify ERROR: ECDSA verification failed for TX %x", tx.ID)
-			return false
+		engine := NewScriptEngine(tx, inID, prevTxs, ctx)
+		if err := engine.Execute(vin.UnlockingStack(), prevOut.LockingScript()); err != nil {
+			return fmt.Errorf("%w: script thất bại ở input %d: %v", ErrInvalidSignature, inID, err)
 		}
 	}
 
-	return true
+	if !VerifySignatures(checks) {
+		return ErrInvalidSignature
+	}
+	return nil
 }
 
-func Handle(err error) {
+func (tx *Transaction) pubKeyHashCheck(inID int, prevOut TxOutput, prevTxs map[string]Transaction) (SignatureCheck, error) {
+	vin := tx.Vin[inID]
+	if !bytes.Equal(HashPubKey(vin.PublicKey), prevOut.PubKeyHash) {
+		return SignatureCheck{}, fmt.Errorf("public key không khớp với pubKeyHash")
+	}
+
+	rawSig, hashType, err := splitSignature(vin.Signature)
+	if err != nil {
+		return SignatureCheck{}, err
+	}
+	hash, err := tx.inputSigHash(inID, prevTxs, hashType)
 	if err != nil {
-		log.Panic(err)
+		return SignatureCheck{}, err
 	}
+	return SignatureCheck{PublicKey: vin.PublicKey, Signature: rawSig, Hash: hash}, nil
 }
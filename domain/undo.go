@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+)
+
+var ErrMissingUndo = errors.New("thiếu dữ liệu undo của block")
+
+// BlockUndo ghi lại trạng thái bị block ghi đè để có thể gỡ block khỏi tip khi reorg.
+type BlockUndo struct {
+	UTXOs  []UndoUTXO
+	States []UndoState
+	Codes  [][]byte
+}
+
+// UndoUTXO là bản ghi UTXO của TxID trước block; Entry nil nghĩa là block tạo mới bản ghi đó.
+type UndoUTXO struct {
+	TxID  []byte
+	Entry *UTXOEntry
+}
+
+// UndoState là giá trị state contract trước block; Existed false nghĩa là block tạo mới key đó.
+type UndoState struct {
+	Address []byte
+	Key     []byte
+	Value   []byte
+	Existed bool
+}
+
+func (bc *Blockchain) blockUndo(utxoChanges map[string]*UTXOEntry, state *StateBatch) (*BlockUndo, error) {
+	undo := &BlockUndo{}
+	for txID := range utxoChanges {
+		entry, err := bc.Store.UTXO([]byte(txID))
+		if errors.Is(err, ErrNotFound) {
+			entry, err = nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		undo.UTXOs = append(undo.UTXOs, UndoUTXO{TxID: []byte(txID), Entry: entry})
+	}
+	if state == nil {
+		return undo, nil
+	}
+
+	for k := range state.state {
+		value, err := bc.Store.ContractState([]byte(k.address), []byte(k.key))
+		existed := err == nil
+		if errors.Is(err, ErrNotFound) {
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		undo.States = append(undo.States, UndoState{Address: []byte(k.address), Key: []byte(k.key), Value: value, Existed: existed})
+	}
+	for address := range state.code {
+		undo.Codes = append(undo.Codes, []byte(address))
+	}
+	return undo, nil
+}
+
+func (undo *BlockUndo) apply(w ChainWriter) error {
+	for _, u := range undo.UTXOs {
+		var err error
+		if u.Entry == nil {
+			err = w.DeleteUTXO(u.TxID)
+		} else {
+			err = w.PutUTXO(u.TxID, u.Entry)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, s := range undo.States {
+		var err error
+		if s.Existed {
+			err = w.SetContractState(s.Address, s.Key, s.Value)
+		} else {
+			err = w.DeleteContractState(s.Address, s.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, address := range undo.Codes {
+		if err := w.DeleteContractCode(address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DisconnectTip gỡ block ở tip bằng dữ liệu undo ghi lúc nối block, đưa UTXO Set, state contract,
+// chỉ mục giao dịch và tổng cung về trạng thái của block cha. Block vẫn được giữ trong CSDL.
+func (bc *Blockchain) DisconnectTip() (*Block, error) {
+	block, err := bc.GetBlock(bc.LastHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(block.PrevBlockHash) == 0 {
+		return nil, errors.New("không thể gỡ block genesis")
+	}
+	undo, err := bc.Store.BlockUndo(block.Hash)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w: %x", ErrMissingUndo, block.Hash)
+	}
+	if err != nil {
+		return nil, err
+	}
+	supply, err := bc.GetSupply()
+	if err != nil {
+		return nil, err
+	}
+	supply -= bc.issuedIn(block)
+
+	err = bc.Store.Update(func(w ChainWriter) error {
+		if err := undo.apply(w); err != nil {
+			return err
+		}
+		if err := w.DeleteBlockUndo(block.Hash); err != nil {
+			return err
+		}
+		if err := w.SetMeta(supplyKey, encodeInt64(supply)); err != nil {
+			return err
+		}
+		if err := w.SetMeta(utxoTipKey, block.PrevBlockHash); err != nil {
+			return err
+		}
+		if bc.Indexed {
+			if err := bc.unindexBlock(w, block); err != nil {
+				return err
+			}
+			if err := w.SetMeta(indexTipKey, block.PrevBlockHash); err != nil {
+				return err
+			}
+		}
+		return w.SetLastHash(block.PrevBlockHash)
+	})
+	if err != nil {
+		return nil, err
+	}
+	bc.LastHash = block.PrevBlockHash
+	bc.bestHeight = block.Height - 1
+	log.Printf("Đã gỡ block %x (chiều cao %d) khỏi tip", block.Hash, block.Height)
+	bc.Events.Publish(ChainEvent{Kind: EventBlockDisconnected, Block: block})
+	return block, nil
+}
+
+// InMainChain cho biết block có thuộc chain chính hiện tại không; block của nhánh đã bị gỡ vẫn còn trong CSDL.
+func (bc *Blockchain) InMainChain(hash []byte) (bool, error) {
+	block, err := bc.GetBlock(hash)
+	if errors.Is(err, ErrBlockNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if block.Height > bc.GetBestHeight() {
+		return false, nil
+	}
+	main, err := bc.GetBlockByHeight(block.Height)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(main.Hash, hash), nil
+}
@@ -0,0 +1,197 @@
+package domain_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+	"github.com/khoahotran/gochain-ledger/storage"
+)
+
+type chainSnapshot struct {
+	lastHash []byte
+	supply   int64
+	utxos    map[string]string
+	state    map[string]string
+	code     map[string]string
+}
+
+func snapshot(t *testing.T, bc *domain.Blockchain, store domain.ChainStore) chainSnapshot {
+	t.Helper()
+	supply, err := bc.GetSupply()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := chainSnapshot{lastHash: bc.LastHash, supply: supply, utxos: map[string]string{}, state: map[string]string{}, code: map[string]string{}}
+	err = store.ForEachUTXO(func(txID []byte, entry *domain.UTXOEntry) bool {
+		s.utxos[string(txID)] = string(entry.Serialize())
+		return true
+	})
+	if err == nil {
+		err = store.ForEachContractState(func(address, key, value []byte) bool {
+			s.state[string(address)+string(key)] = string(value)
+			return true
+		})
+	}
+	if err == nil {
+		err = store.ForEachContractCode(func(address, code []byte) bool {
+			s.code[string(address)] = string(code)
+			return true
+		})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func expectSnapshot(t *testing.T, got, want chainSnapshot) {
+	t.Helper()
+	if !bytes.Equal(got.lastHash, want.lastHash) || got.supply != want.supply {
+		t.Fatalf("tip %x, supply %d; muốn %x, %d", got.lastHash, got.supply, want.lastHash, want.supply)
+	}
+	for name, pair := range map[string][2]map[string]string{
+		"UTXO Set": {got.utxos, want.utxos}, "state contract": {got.state, want.state}, "code contract": {got.code, want.code},
+	} {
+		if len(pair[0]) != len(pair[1]) {
+			t.Fatalf("%s có %d bản ghi, muốn %d", name, len(pair[0]), len(pair[1]))
+		}
+		for k, v := range pair[1] {
+			if pair[0][k] != v {
+				t.Fatalf("%s khác trạng thái trước block tại %x", name, k)
+			}
+		}
+	}
+}
+
+func TestDisconnectTipRestoresState(t *testing.T) {
+	store := storage.NewMemory()
+	bc, alice := testutil.NewChain(t, store)
+	bob := testutil.NewWallet(t)
+	contract := bytes.Repeat([]byte{0xc1}, 32)
+	deployed := bytes.Repeat([]byte{0xc2}, 32)
+
+	state := domain.NewStateBatch(bc)
+	state.SetContractCode(contract, []byte("code"))
+	if err := state.SetContractState(contract, []byte("counter"), []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bc.AddBlock([]*domain.Transaction{testutil.Coinbase(t, bc, alice)}, state); err != nil {
+		t.Fatal(err)
+	}
+	before := snapshot(t, bc, store)
+
+	genesis := testutil.GenesisCoinbase(t, bc)
+	spend := testutil.Spend(t, bc, alice, genesis.ID, 0, testutil.PayTo(t, bob.GetAddress(), 60), testutil.PayTo(t, alice.GetAddress(), 30))
+	state = domain.NewStateBatch(bc)
+	state.SetContractCode(deployed, []byte("code mới"))
+	if err := state.SetContractState(contract, []byte("counter"), []byte("2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := state.SetContractState(contract, []byte("owner"), []byte("bob")); err != nil {
+		t.Fatal(err)
+	}
+	block, err := bc.AddBlock([]*domain.Transaction{testutil.Coinbase(t, bc, bob, spend), spend}, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disconnected, err := bc.DisconnectTip()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(disconnected.Hash, block.Hash) || bc.GetBestHeight() != 1 {
+		t.Fatalf("gỡ block %x, chiều cao còn %d", disconnected.Hash, bc.GetBestHeight())
+	}
+	expectSnapshot(t, snapshot(t, bc, store), before)
+	if report, err := bc.Verify(domain.VerifyOptions{Full: true}); err != nil || !report.OK() {
+		t.Fatalf("chain sau khi gỡ block không nhất quán: %v %v", report, err)
+	}
+
+	// Block đã gỡ có thể được nối lại và gỡ lần nữa.
+	if err := bc.ConnectBlock(block, state); err != nil {
+		t.Fatalf("nối lại block đã gỡ: %v", err)
+	}
+	if _, err := bc.DisconnectTip(); err != nil {
+		t.Fatal(err)
+	}
+	expectSnapshot(t, snapshot(t, bc, store), before)
+}
+
+func TestDisconnectTipRequiresUndo(t *testing.T) {
+	store := storage.NewMemory()
+	bc, alice := testutil.NewChain(t, store)
+	block := testutil.MineBlock(t, bc, alice)
+
+	if err := store.Update(func(w domain.ChainWriter) error { return w.DeleteBlockUndo(block.Hash) }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bc.DisconnectTip(); !errors.Is(err, domain.ErrMissingUndo) {
+		t.Fatalf("thiếu undo: lỗi %v, muốn ErrMissingUndo", err)
+	}
+	if !bytes.Equal(bc.LastHash, block.Hash) {
+		t.Fatal("tip không được đổi khi gỡ block thất bại")
+	}
+
+	bc, _ = testutil.NewChain(t, nil)
+	if _, err := bc.DisconnectTip(); err == nil {
+		t.Fatal("không được gỡ block genesis")
+	}
+}
+
+func TestInMainChain(t *testing.T) {
+	bc, alice := testutil.NewChain(t, nil)
+	first := testutil.MineBlock(t, bc, alice)
+	if _, err := bc.DisconnectTip(); err != nil {
+		t.Fatal(err)
+	}
+	second := testutil.MineBlock(t, bc, testutil.NewWallet(t))
+
+	for _, c := range []struct {
+		hash []byte
+		want bool
+	}{{bc.LastHash, true}, {second.PrevBlockHash, true}, {first.Hash, false}, {[]byte("không-có"), false}} {
+		if got, err := bc.InMainChain(c.hash); err != nil || got != c.want {
+			t.Errorf("InMainChain(%x) = %v, %v; muốn %v", c.hash, got, err, c.want)
+		}
+	}
+}
+
+func TestDisconnectTipRemovesIndexEntries(t *testing.T) {
+	bc, alice := testutil.NewChain(t, nil)
+	if err := bc.EnableIndex(); err != nil {
+		t.Fatal(err)
+	}
+	bob := testutil.NewWallet(t)
+	_, aliceBefore, err := bc.GetAddressHistory(domain.HashPubKey(alice.PublicKey), 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	genesis := testutil.GenesisCoinbase(t, bc)
+	spend := testutil.Spend(t, bc, alice, genesis.ID, 0, testutil.PayTo(t, bob.GetAddress(), 60), testutil.PayTo(t, alice.GetAddress(), 30))
+	block := testutil.MineBlock(t, bc, bob, spend)
+	if _, _, err := bc.FindTransactionWithBlock(spend.ID); err != nil {
+		t.Fatalf("TX vừa vào block phải có trong chỉ mục: %v", err)
+	}
+
+	if _, err := bc.DisconnectTip(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tx := range block.Transactions {
+		if _, _, err := bc.FindTransactionWithBlock(tx.ID); !errors.Is(err, domain.ErrTxNotFound) {
+			t.Fatalf("TX %x của block đã gỡ: lỗi %v, muốn ErrTxNotFound", tx.ID, err)
+		}
+	}
+	if _, total, err := bc.GetAddressHistory(domain.HashPubKey(bob.PublicKey), 0, 100); err != nil || total != 0 {
+		t.Fatalf("lịch sử của bob còn %d bản ghi sau khi gỡ block (%v)", total, err)
+	}
+	if _, total, err := bc.GetAddressHistory(domain.HashPubKey(alice.PublicKey), 0, 100); err != nil || total != aliceBefore {
+		t.Fatalf("lịch sử của alice có %d bản ghi, muốn %d (%v)", total, aliceBefore, err)
+	}
+	if report, err := bc.Verify(domain.VerifyOptions{Full: true}); err != nil || !report.OK() {
+		t.Fatalf("chỉ mục sau khi gỡ block không nhất quán: %v %v", report, err)
+	}
+}
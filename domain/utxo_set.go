@@ -1,74 +1,97 @@
 package domain
 
 import (
-	"bytes"
-	"encoding/gob"
+	"errors"
+	"fmt"
 	"log"
-
-	"github.com/dgraph-io/badger/v3"
+	"sort"
 )
 
 type UTXOSet struct {
 	Blockchain *Blockchain
 }
 
-type SpendableUTXOData struct {
-	TxID       []byte
-	VoutIndex  int
-	Amount     int64
-	PubKeyHash []byte
+type UTXOEntry struct {
+	Height   int64
+	Time     int64 // timestamp của block chứa giao dịch
+	Coinbase bool
+	Outputs  map[int]TxOutput
 }
 
-func (u *UTXOSet) Reindex() {
-	db := u.Blockchain.Database
-
-	err := db.Update(func(txn *badger.Txn) error {
-		opts := badger.DefaultIteratorOptions
-		opts.PrefetchValues = false
-		it := txn.NewIterator(opts)
-		defer it.Close()
+type SpendableUTXOData struct {
+	TxID        []byte
+	VoutIndex   int
+	Amount      int64
+	PubKeyHash  []byte
+	Unconfirmed bool
+}
 
-		prefix := []byte(utxoPrefix)
-		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
-			err := txn.Delete(it.Item().Key())
-			if err != nil {
-				return err
-			}
-		}
-		return nil
-	})
-	Handle(err)
+type UnspentOutput struct {
+	TxID      []byte
+	VoutIndex int
+	Output    TxOutput
+	Height    int64
+	Coinbase  bool
+}
 
-	allUTXOs := u.Blockchain.FindAllUTXO()
+func OutpointKey(txID []byte, voutIndex int) string {
+	return fmt.Sprintf("%x:%d", txID, voutIndex)
+}
 
-	err = db.Update(func(txn *badger.Txn) error {
-		for txID, outs := range allUTXOs {
-			key := append([]byte(utxoPrefix), []byte(txID)...)
+func (e *UTXOEntry) Indexes() []int {
+	indexes := make([]int, 0, len(e.Outputs))
+	for idx := range e.Outputs {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
 
-			var outsData bytes.Buffer
-			enc := gob.NewEncoder(&outsData)
-			err := enc.Encode(outs)
-			Handle(err)
+func (u *UTXOSet) Reindex() error {
+	allUTXOs, err := u.Blockchain.FindAllUTXO()
+	if err != nil {
+		return err
+	}
+	supply, err := u.Blockchain.computeSupply()
+	if err != nil {
+		return err
+	}
 
-			err = txn.Set(key, outsData.Bytes())
-			if err != nil {
+	err = u.Blockchain.Store.Update(func(w ChainWriter) error {
+		if err := w.ClearUTXOs(); err != nil {
+			return err
+		}
+		for txID, entry := range allUTXOs {
+			if err := w.PutUTXO([]byte(txID), entry); err != nil {
 				return err
 			}
 		}
-		return nil
+		if err := w.SetMeta(utxoVersionKey, []byte{utxoVersion}); err != nil {
+			return err
+		}
+		if err := w.SetMeta(utxoTipKey, u.Blockchain.LastHash); err != nil {
+			return err
+		}
+		return w.SetMeta(supplyKey, encodeInt64(supply))
 	})
-	Handle(err)
+	if err != nil {
+		return err
+	}
 	log.Println("UTXO Set đã được re-index!")
+	return nil
 }
 
-func (bc *Blockchain) FindAllUTXO() map[string][]TxOutput {
-	utxos := make(map[string][]TxOutput)
+func (bc *Blockchain) FindAllUTXO() (map[string]*UTXOEntry, error) {
+	utxos := make(map[string]*UTXOEntry)
 	spentTXOs := make(map[string][]int)
 
 	it := bc.Iterator()
 
 	for {
-		block := it.Next()
+		block, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
 
 		for _, tx := range block.Transactions {
 			txIDStr := string(tx.ID)
@@ -88,7 +111,17 @@ func (bc *Blockchain) FindAllUTXO() map[string][]TxOutput {
 					}
 				}
 
-				utxos[txIDStr] = append(utxos[txIDStr], out)
+				entry, ok := utxos[txIDStr]
+				if !ok {
+					entry = &UTXOEntry{
+						Height:   block.Height,
+						Time:     block.Timestamp,
+						Coinbase: tx.IsCoinbase(),
+						Outputs:  make(map[int]TxOutput),
+					}
+					utxos[txIDStr] = entry
+				}
+				entry.Outputs[outIdx] = out
 			}
 
 			if !tx.IsCoinbase() {
@@ -103,177 +136,184 @@ func (bc *Blockchain) FindAllUTXO() map[string][]TxOutput {
 			break
 		}
 	}
-	return utxos
+	return utxos, nil
 }
 
-func (u *UTXOSet) FindUTXO(pubKeyHash []byte) []TxOutput {
-	var utxos []TxOutput
-	db := u.Blockchain.Database
-
-	err := db.View(func(txn *badger.Txn) error {
-		opts := badger.DefaultIteratorOptions
-		it := txn.NewIterator(opts)
-		defer it.Close()
-
-		prefix := []byte(utxoPrefix)
+func (u *UTXOSet) forEach(fn func(txID []byte, entry *UTXOEntry) bool) error {
+	return u.Blockchain.Store.ForEachUTXO(fn)
+}
 
-		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
-			item := it.Item()
-			var outs []TxOutput
+func (u *UTXOSet) GetEntry(txID []byte) (*UTXOEntry, error) {
+	entry, err := u.Blockchain.Store.UTXO(txID)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return entry, err
+}
 
-			err := item.Value(func(val []byte) error {
-				dec := gob.NewDecoder(bytes.NewReader(val))
-				return dec.Decode(&outs)
-			})
-			Handle(err)
+func (u *UTXOSet) FindUTXO(pubKeyHash []byte) ([]TxOutput, error) {
+	var utxos []TxOutput
 
-			for _, out := range outs {
-				if out.IsLockedWithKey(pubKeyHash) {
-					utxos = append(utxos, out)
-				}
+	err := u.forEach(func(txID []byte, entry *UTXOEntry) bool {
+		for _, outIdx := range entry.Indexes() {
+			out := entry.Outputs[outIdx]
+			if out.IsLockedWithKey(pubKeyHash) {
+				utxos = append(utxos, out)
 			}
 		}
-		return nil
+		return true
 	})
-	Handle(err)
-	return utxos
+	return utxos, err
 }
 
-func (u *UTXOSet) FindSpendableOutputs(pubKeyHash []byte, amount int64) (int64, map[string][]int) {
+func (u *UTXOSet) FindSpendableOutputs(pubKeyHash []byte, amount int64, spendHeight int64) (int64, map[string][]int, error) {
 	spendableUTXOs := make(map[string][]int)
 	var accumulated int64 = 0
-	db := u.Blockchain.Database
-
-	err := db.View(func(txn *badger.Txn) error {
-		opts := badger.DefaultIteratorOptions
-		it := txn.NewIterator(opts)
-		defer it.Close()
-
-		prefix := []byte(utxoPrefix)
-		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
-			item := it.Item()
-			txID := string(bytes.TrimPrefix(item.Key(), prefix))
-
-			var outs []TxOutput
-			err := item.Value(func(val []byte) error {
-				dec := gob.NewDecoder(bytes.NewReader(val))
-				return dec.Decode(&outs)
-			})
-			Handle(err)
 
-			for outIdx, out := range outs {
-				if out.IsLockedWithKey(pubKeyHash) && accumulated < amount {
-					accumulated += out.Value
-					spendableUTXOs[txID] = append(spendableUTXOs[txID], outIdx)
-				}
-			}
+	_, utxos, err := u.FindSpendableUTXOData(pubKeyHash, amount, spendHeight, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, utxo := range utxos {
+		accumulated += utxo.Amount
+		spendableUTXOs[string(utxo.TxID)] = append(spendableUTXOs[string(utxo.TxID)], utxo.VoutIndex)
+	}
+	return accumulated, spendableUTXOs, nil
+}
 
-			if accumulated >= amount {
-				return nil
-			}
-		}
-		return nil
+func (u *UTXOSet) Update(block *Block) error {
+	changed, err := u.changes(block)
+	if err != nil {
+		return err
+	}
+	return u.Blockchain.Store.Update(func(w ChainWriter) error {
+		return writeUTXOChanges(w, changed)
 	})
-	Handle(err)
-	return accumulated, spendableUTXOs
 }
 
-func (u *UTXOSet) Update(block *Block) {
-	db := u.Blockchain.Database
+func (u *UTXOSet) changes(block *Block) (map[string]*UTXOEntry, error) {
+	changed := make(map[string]*UTXOEntry)
+	for _, tx := range block.Transactions {
 
-	err := db.Update(func(txn *badger.Txn) error {
-		for _, tx := range block.Transactions {
-
-			if !tx.IsCoinbase() {
-				for _, vin := range tx.Vin {
-					key := append([]byte(utxoPrefix), vin.TxID...)
-
-					item, err := txn.Get(key)
+		if !tx.IsCoinbase() {
+			for _, vin := range tx.Vin {
+				entry, ok := changed[string(vin.TxID)]
+				if !ok {
+					var err error
+					entry, err = u.GetEntry(vin.TxID)
 					if err != nil {
-
-						continue
+						return nil, err
 					}
+				}
+				if entry == nil {
 
-					var outs []TxOutput
-					err = item.Value(func(val []byte) error {
-						dec := gob.NewDecoder(bytes.NewReader(val))
-						return dec.Decode(&outs)
-					})
-					Handle(err)
-
-					updatedOuts := []TxOutput{}
-					for outIdx, out := range outs {
-						if outIdx != vin.VoutIndex {
-							updatedOuts = append(updatedOuts, out)
-						}
-					}
-
-					if len(updatedOuts) == 0 {
-						err = txn.Delete(key)
-					} else {
-
-						var outsData bytes.Buffer
-						enc := gob.NewEncoder(&outsData)
-						enc.Encode(updatedOuts)
-						err = txn.Set(key, outsData.Bytes())
-					}
-					Handle(err)
+					continue
 				}
+
+				delete(entry.Outputs, vin.VoutIndex)
+				changed[string(vin.TxID)] = entry
 			}
+		}
 
-			key := append([]byte(utxoPrefix), tx.ID...)
-			var outsData bytes.Buffer
-			enc := gob.NewEncoder(&outsData)
-			enc.Encode(tx.Vout)
-			err := txn.Set(key, outsData.Bytes())
-			Handle(err)
+		entry := &UTXOEntry{
+			Height:   block.Height,
+			Time:     block.Timestamp,
+			Coinbase: tx.IsCoinbase(),
+			Outputs:  make(map[int]TxOutput, len(tx.Vout)),
 		}
-		return nil
-	})
-	Handle(err)
+		for outIdx, out := range tx.Vout {
+			entry.Outputs[outIdx] = out
+		}
+		changed[string(tx.ID)] = entry
+	}
+	return changed, nil
+}
+
+func writeUTXOChanges(w ChainWriter, changed map[string]*UTXOEntry) error {
+	for txID, entry := range changed {
+		var err error
+		if len(entry.Outputs) == 0 {
+			err = w.DeleteUTXO([]byte(txID))
+		} else {
+			err = w.PutUTXO([]byte(txID), entry)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (u *UTXOSet) FindSpendableUTXOData(pubKeyHash []byte, amount int64) (int64, []SpendableUTXOData) {
+func (u *UTXOSet) FindSpendableUTXOData(pubKeyHash []byte, amount int64, spendHeight int64, exclude map[string]bool) (int64, []SpendableUTXOData, error) {
 	var utxos []SpendableUTXOData
 	var accumulated int64 = 0
-	db := u.Blockchain.Database
-
-	err := db.View(func(txn *badger.Txn) error {
-		opts := badger.DefaultIteratorOptions
-		it := txn.NewIterator(opts)
-		defer it.Close()
-
-		prefix := []byte(utxoPrefix)
-		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
-			item := it.Item()
-			txID := item.KeyCopy(nil)
-			txID = bytes.TrimPrefix(txID, prefix)
-
-			var outs []TxOutput
-			err := item.Value(func(val []byte) error {
-				dec := gob.NewDecoder(bytes.NewReader(val))
-				return dec.Decode(&outs)
-			})
-			Handle(err)
-
-			for outIdx, out := range outs {
-				if out.IsLockedWithKey(pubKeyHash) && accumulated < amount {
-					accumulated += out.Value
-					utxos = append(utxos, SpendableUTXOData{
-						TxID:       txID,
-						VoutIndex:  outIdx,
-						Amount:     out.Value,
-						PubKeyHash: out.PubKeyHash,
-					})
-				}
+	emission := u.Blockchain.Emission
+
+	err := u.forEach(func(txID []byte, entry *UTXOEntry) bool {
+		if entry.Coinbase && !emission.IsMature(entry.Height, spendHeight) {
+			return true
+		}
+
+		for _, outIdx := range entry.Indexes() {
+			out := entry.Outputs[outIdx]
+			if exclude[OutpointKey(txID, outIdx)] {
+				continue
+			}
+			if out.IsLockedWithKey(pubKeyHash) && accumulated < amount {
+				accumulated += out.Value
+				utxos = append(utxos, SpendableUTXOData{
+					TxID:       txID,
+					VoutIndex:  outIdx,
+					Amount:     out.Value,
+					PubKeyHash: out.PubKeyHash,
+				})
+			}
+		}
+
+		return accumulated < amount
+	})
+	return accumulated, utxos, err
+}
+
+func (u *UTXOSet) Balance(pubKeyHash []byte, spendHeight int64, exclude map[string]bool) (int64, int64, error) {
+	var spendable, locked int64
+	emission := u.Blockchain.Emission
+
+	err := u.forEach(func(txID []byte, entry *UTXOEntry) bool {
+		immature := entry.Coinbase && !emission.IsMature(entry.Height, spendHeight)
+		for outIdx, out := range entry.Outputs {
+			if !out.IsLockedWithKey(pubKeyHash) || exclude[OutpointKey(txID, outIdx)] {
+				continue
+			}
+			if immature {
+				locked += out.Value
+			} else {
+				spendable += out.Value
 			}
+		}
+		return true
+	})
+	return spendable, locked, err
+}
 
-			if accumulated >= amount {
-				return nil
+func (u *UTXOSet) ListUnspent(pubKeyHash []byte, exclude map[string]bool) ([]UnspentOutput, error) {
+	var utxos []UnspentOutput
+
+	err := u.forEach(func(txID []byte, entry *UTXOEntry) bool {
+		for _, outIdx := range entry.Indexes() {
+			out := entry.Outputs[outIdx]
+			if !out.IsLockedWithKey(pubKeyHash) || exclude[OutpointKey(txID, outIdx)] {
+				continue
 			}
+			utxos = append(utxos, UnspentOutput{
+				TxID:      txID,
+				VoutIndex: outIdx,
+				Output:    out,
+				Height:    entry.Height,
+				Coinbase:  entry.Coinbase,
+			})
 		}
-		return nil
+		return true
 	})
-	Handle(err)
-	return accumulated, utxos
+	return utxos, err
 }
@@ -0,0 +1,431 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+)
+
+const verifyLogInterval = 1000
+
+type ContractExecutor func(state *StateBatch, tx *Transaction) error
+
+type VerifyOptions struct {
+	Full      bool
+	Contracts ContractExecutor
+}
+
+type VerifyReport struct {
+	Blocks       int64
+	Transactions int64
+	ContractTxs  int64
+	Invalid      []string
+	Mismatches   []string
+
+	utxos     map[string]*UTXOEntry
+	supply    int64
+	contracts *StateBatch
+	addresses map[string]map[string]AddressHistoryEntry
+}
+
+func (r *VerifyReport) OK() bool {
+	return len(r.Invalid) == 0 && len(r.Mismatches) == 0
+}
+
+func (r *VerifyReport) invalid(format string, args ...interface{}) {
+	r.Invalid = append(r.Invalid, fmt.Sprintf(format, args...))
+}
+
+func (r *VerifyReport) mismatch(format string, args ...interface{}) {
+	r.Mismatches = append(r.Mismatches, fmt.Sprintf(format, args...))
+}
+
+func (bc *Blockchain) Verify(opts VerifyOptions) (*VerifyReport, error) {
+	hashes, err := bc.BlockHashes()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &VerifyReport{
+		utxos:     make(map[string]*UTXOEntry),
+		addresses: make(map[string]map[string]AddressHistoryEntry),
+	}
+	if opts.Contracts != nil {
+		report.contracts = NewStateBatch(nil)
+	}
+	blockTimes := make(map[int64]int64, len(hashes))
+
+	for height, hash := range hashes {
+		block, err := bc.GetBlock(hash)
+		if err != nil {
+			return nil, err
+		}
+		blockTimes[block.Height] = block.Timestamp
+		bc.verifyBlock(report, block, hash, int64(height), opts, blockTimes)
+
+		report.Blocks++
+		report.Transactions += int64(len(block.Transactions))
+		if report.Blocks%verifyLogInterval == 0 {
+			log.Printf("Đã kiểm tra %d/%d block...", report.Blocks, len(hashes))
+		}
+	}
+
+	if err := bc.compareUTXOs(report); err != nil {
+		return nil, err
+	}
+	if err := bc.compareMeta(report); err != nil {
+		return nil, err
+	}
+	if report.contracts != nil {
+		if err := bc.compareContracts(report); err != nil {
+			return nil, err
+		}
+	}
+	if bc.Indexed {
+		if err := bc.compareAddressIndex(report); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+func (bc *Blockchain) verifyBlock(report *VerifyReport, block *Block, hash []byte, height int64, opts VerifyOptions, blockTimes map[int64]int64) {
+	if !bytes.Equal(block.Hash, hash) {
+		report.invalid("block lưu dưới khóa %x nhưng có hash %x", hash, block.Hash)
+	}
+	if block.Height != height {
+		report.invalid("block %x có chiều cao %d, cần %d", block.Hash, block.Height, height)
+	}
+	if parentTime, ok := blockTimes[height-1]; ok && block.Timestamp < parentTime {
+		report.invalid("block %x có timestamp %d sớm hơn block cha (%d)", block.Hash, block.Timestamp, parentTime)
+	}
+	if height == 0 && len(activeParams.GenesisHash) > 0 && !bytes.Equal(block.Hash, activeParams.GenesisHash) {
+		report.invalid("block genesis %x khác genesis của mạng %s (%x)", block.Hash, activeParams.Name, activeParams.GenesisHash)
+	}
+	if err := checkBlockStructure(block); err != nil {
+		report.invalid("%v", err)
+	}
+	for _, tx := range block.Transactions {
+		if !bytes.Equal(tx.ID, tx.ComputeID()) {
+			report.invalid("TX %x trong block %d: ID không khớp với nội dung", tx.ID, block.Height)
+		}
+	}
+
+	if opts.Full {
+		bc.verifyTransactions(report, block, blockTimes)
+	}
+	applyUTXOView(report.utxos, block)
+	report.supply += bc.issuedIn(block)
+
+	if report.contracts != nil {
+		for _, tx := range block.Transactions {
+			if tx.Type != TxTypeContractDeploy && tx.Type != TxTypeContractCall {
+				continue
+			}
+			report.ContractTxs++
+			txState := report.contracts.Fork()
+			if err := opts.Contracts(txState, tx); err != nil {
+				report.invalid("TX %x trong block %d: chạy lại contract thất bại: %v", tx.ID, block.Height, err)
+				continue
+			}
+			txState.Commit()
+		}
+	}
+
+	if bc.Indexed {
+		bc.verifyTxIndex(report, block)
+	}
+}
+
+func (bc *Blockchain) verifyTransactions(report *VerifyReport, block *Block, blockTimes map[int64]int64) {
+	ctx := VerifyContext{Height: block.Height, Time: block.Timestamp}
+	view := make(map[string]*UTXOEntry)
+	lookup := func(txID []byte) (*UTXOEntry, error) {
+		if entry, ok := view[string(txID)]; ok {
+			return entry, nil
+		}
+		return report.utxos[string(txID)], nil
+	}
+	origin := func(txID []byte) (int64, int64, error) {
+		entry, _ := lookup(txID)
+		if entry == nil {
+			return 0, 0, fmt.Errorf("%w: %x", ErrTxNotFound, txID)
+		}
+		return entry.Height, blockTimes[entry.Height], nil
+	}
+
+	spent := make(map[string]bool)
+	var totalFees int64
+	for _, tx := range block.Transactions {
+		if tx.IsCoinbase() {
+			continue
+		}
+		fee, err := bc.checkInputs(tx, ctx, spent, lookup)
+		if err != nil {
+			report.invalid("TX %x trong block %d: %v", tx.ID, block.Height, err)
+			continue
+		}
+		if err := checkLocks(tx, ctx, origin); err != nil {
+			report.invalid("TX %x trong block %d: %v", tx.ID, block.Height, err)
+		}
+		if err := tx.Verify(viewPrevTxs(tx, view, report.utxos), ctx); err != nil {
+			report.invalid("TX %x trong block %d: %v", tx.ID, block.Height, err)
+		}
+		totalFees += fee
+		view[string(tx.ID)] = pendingEntry(tx, block.Height)
+	}
+
+	if maxReward := bc.Emission.BlockReward(block.Height) + totalFees; block.CoinbaseValue() > maxReward {
+		report.invalid("block %d: coinbase %d vượt quá phần thưởng tối đa %d", block.Height, block.CoinbaseValue(), maxReward)
+	}
+}
+
+func viewPrevTxs(tx *Transaction, pending, utxos map[string]*UTXOEntry) map[string]Transaction {
+	prevTxs, _ := referencedOutputs(tx, func(txID []byte) (*UTXOEntry, error) {
+		if entry, ok := pending[string(txID)]; ok {
+			return entry, nil
+		}
+		return utxos[string(txID)], nil
+	})
+	return prevTxs
+}
+
+func applyUTXOView(utxos map[string]*UTXOEntry, block *Block) {
+	for _, tx := range block.Transactions {
+		if !tx.IsCoinbase() {
+			for _, vin := range tx.Vin {
+				entry := utxos[string(vin.TxID)]
+				if entry == nil {
+					continue
+				}
+				delete(entry.Outputs, vin.VoutIndex)
+				if len(entry.Outputs) == 0 {
+					delete(utxos, string(vin.TxID))
+				}
+			}
+		}
+
+		entry := &UTXOEntry{
+			Height:   block.Height,
+			Time:     block.Timestamp,
+			Coinbase: tx.IsCoinbase(),
+			Outputs:  make(map[int]TxOutput, len(tx.Vout)),
+		}
+		for outIdx, out := range tx.Vout {
+			entry.Outputs[outIdx] = out
+		}
+		if len(entry.Outputs) > 0 {
+			utxos[string(tx.ID)] = entry
+		}
+	}
+}
+
+type indexRecorder struct {
+	addresses map[string]map[string]AddressHistoryEntry
+}
+
+func (r *indexRecorder) PutTxLocation(txID, blockHash []byte, position int) error {
+	return nil
+}
+
+func (r *indexRecorder) PutAddressIndex(pubKeyHash []byte, entry AddressHistoryEntry) error {
+	entries, ok := r.addresses[string(pubKeyHash)]
+	if !ok {
+		entries = make(map[string]AddressHistoryEntry)
+		r.addresses[string(pubKeyHash)] = entries
+	}
+	entries[addressEntryKey(entry)] = entry
+	return nil
+}
+
+func addressEntryKey(entry AddressHistoryEntry) string {
+	return fmt.Sprintf("%d:%d", entry.Height, entry.Position)
+}
+
+func (bc *Blockchain) verifyTxIndex(report *VerifyReport, block *Block) {
+	recorder := &indexRecorder{addresses: report.addresses}
+	if err := bc.indexBlock(recorder, block); err != nil {
+		report.mismatch("chỉ mục block %d: %v", block.Height, err)
+		return
+	}
+	for pos, tx := range block.Transactions {
+		hash, position, err := bc.Store.TxLocation(tx.ID)
+		if errors.Is(err, ErrNotFound) {
+			report.mismatch("chỉ mục thiếu TX %x (block %d)", tx.ID, block.Height)
+			continue
+		}
+		if err != nil {
+			report.mismatch("chỉ mục TX %x: %v", tx.ID, err)
+			continue
+		}
+		if !bytes.Equal(hash, block.Hash) || position != pos {
+			report.mismatch("chỉ mục TX %x trỏ tới %x:%d, cần %x:%d", tx.ID, hash, position, block.Hash, pos)
+		}
+	}
+}
+
+func (bc *Blockchain) compareUTXOs(report *VerifyReport) error {
+	seen := make(map[string]bool, len(report.utxos))
+	err := bc.Store.ForEachUTXO(func(txID []byte, entry *UTXOEntry) bool {
+		expected, ok := report.utxos[string(txID)]
+		if !ok {
+			report.mismatch("UTXO %x có trong CSDL nhưng không còn output chưa tiêu", txID)
+			return true
+		}
+		seen[string(txID)] = true
+		if !bytes.Equal(entry.Serialize(), expected.Serialize()) {
+			report.mismatch("UTXO %x khác với kết quả tính lại (lưu %v, cần %v)", txID, entry.Indexes(), expected.Indexes())
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, txID := range sortedKeys(report.utxos) {
+		if !seen[txID] {
+			report.mismatch("UTXO %x bị thiếu trong CSDL", []byte(txID))
+		}
+	}
+	return nil
+}
+
+func (bc *Blockchain) compareMeta(report *VerifyReport) error {
+	supply, err := bc.readInt64(supplyKey)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		report.mismatch("thiếu tổng cung đã lưu (cần %d)", report.supply)
+	case err != nil:
+		report.mismatch("tổng cung đã lưu: %v", err)
+	case supply != report.supply:
+		report.mismatch("tổng cung đã lưu là %d, tính lại được %d", supply, report.supply)
+	}
+
+	utxoTip, err := bc.Store.Meta(utxoTipKey)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if !bytes.Equal(utxoTip, bc.LastHash) {
+		report.mismatch("UTXO Set được ghi ở block %x, tip là %x", utxoTip, bc.LastHash)
+	}
+
+	if bc.Indexed {
+		indexTip, err := bc.Store.Meta(indexTipKey)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		if !bytes.Equal(indexTip, bc.LastHash) {
+			report.mismatch("chỉ mục giao dịch được ghi ở block %x, tip là %x", indexTip, bc.LastHash)
+		}
+	}
+	return nil
+}
+
+func (bc *Blockchain) compareContracts(report *VerifyReport) error {
+	expectedCode := report.contracts.code
+	seenCode := make(map[string]bool, len(expectedCode))
+	err := bc.Store.ForEachContractCode(func(address, code []byte) bool {
+		expected, ok := expectedCode[string(address)]
+		switch {
+		case !ok:
+			report.mismatch("contract %x có code trong CSDL nhưng không được deploy trên chain", address)
+		case !bytes.Equal(code, expected):
+			report.mismatch("code của contract %x khác với TX deploy", address)
+		}
+		seenCode[string(address)] = true
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	for _, address := range sortedKeys(expectedCode) {
+		if !seenCode[address] {
+			report.mismatch("thiếu code của contract %x", []byte(address))
+		}
+	}
+
+	expectedState := report.contracts.state
+	seenState := make(map[stateKey]bool, len(expectedState))
+	err = bc.Store.ForEachContractState(func(address, key, value []byte) bool {
+		k := stateKey{address: string(address), key: string(key)}
+		expected, ok := expectedState[k]
+		switch {
+		case !ok:
+			report.mismatch("state %q của contract %x không được tạo bởi TX nào", key, address)
+		case !bytes.Equal(value, expected):
+			report.mismatch("state %q của contract %x là %q, chạy lại được %q", key, address, value, expected)
+		}
+		seenState[k] = true
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	for k := range expectedState {
+		if !seenState[k] {
+			report.mismatch("thiếu state %q của contract %x", k.key, []byte(k.address))
+		}
+	}
+	return nil
+}
+
+func (bc *Blockchain) compareAddressIndex(report *VerifyReport) error {
+	for _, pkh := range sortedKeys(report.addresses) {
+		expected := report.addresses[pkh]
+		seen := make(map[string]bool, len(expected))
+		err := bc.Store.AddressIndex([]byte(pkh), func(entry AddressHistoryEntry) bool {
+			key := addressEntryKey(entry)
+			want, ok := expected[key]
+			if !ok || !bytes.Equal(entry.TxID, want.TxID) || entry.Direction != want.Direction || entry.Amount != want.Amount {
+				report.mismatch("chỉ mục địa chỉ %x: mục %s (TX %x) không khớp với chain", []byte(pkh), key, entry.TxID)
+			}
+			seen[key] = true
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		for key, entry := range expected {
+			if !seen[key] {
+				report.mismatch("chỉ mục địa chỉ %x thiếu TX %x", []byte(pkh), entry.TxID)
+			}
+		}
+	}
+	return nil
+}
+
+func (bc *Blockchain) Repair(report *VerifyReport) error {
+	utxoSet := UTXOSet{Blockchain: bc}
+	if err := utxoSet.Reindex(); err != nil {
+		return err
+	}
+
+	if report.contracts != nil {
+		err := bc.Store.Update(func(w ChainWriter) error {
+			if err := w.ClearContracts(); err != nil {
+				return err
+			}
+			return report.contracts.write(w)
+		})
+		if err != nil {
+			return err
+		}
+		log.Printf("Đã ghi lại state của %d contract từ %d TX contract.", len(report.contracts.code), report.ContractTxs)
+	}
+
+	if bc.Indexed {
+		return bc.RebuildIndex()
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,118 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+	"github.com/khoahotran/gochain-ledger/storage"
+)
+
+// newVerifyChain dựng chain có chỉ mục gồm genesis, một block chuyển tiền từ alice cho bob và một block rỗng.
+func newVerifyChain(t *testing.T) (*domain.Blockchain, domain.ChainStore, *domain.Wallet, *domain.Block) {
+	t.Helper()
+	store := storage.NewMemory()
+	bc, alice := testutil.NewChain(t, store)
+	bob := testutil.NewWallet(t)
+	if err := bc.EnableIndex(); err != nil {
+		t.Fatal(err)
+	}
+	genesis, err := bc.GetBlockByHeight(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payment := testutil.MineBlock(t, bc, alice, testutil.Spend(t, bc, alice, genesis.Transactions[0].ID, 0, testutil.PayTo(t, bob.GetAddress(), 100)))
+	testutil.MineBlock(t, bc, alice)
+	return bc, store, bob, payment
+}
+
+func verifyChain(t *testing.T, bc *domain.Blockchain, full bool) *domain.VerifyReport {
+	t.Helper()
+	report, err := bc.Verify(domain.VerifyOptions{Full: full})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+func TestVerifyHealthyChain(t *testing.T) {
+	bc, _, _, _ := newVerifyChain(t)
+	report := verifyChain(t, bc, true)
+	if !report.OK() {
+		t.Fatalf("chain lành bị báo lỗi: %v %v", report.Invalid, report.Mismatches)
+	}
+	if report.Blocks != 3 || report.Transactions != 4 {
+		t.Fatalf("đã kiểm tra %d block, %d TX; muốn 3 block, 4 TX", report.Blocks, report.Transactions)
+	}
+}
+
+func TestVerifyReportsAndRepairsDerivedData(t *testing.T) {
+	bc, store, bob, payment := newVerifyChain(t)
+	transfer := payment.Transactions[1]
+	entry, err := store.UTXO(transfer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Update(func(w domain.ChainWriter) error {
+		if err := w.DeleteUTXO(transfer.ID); err != nil {
+			return err
+		}
+		if err := w.PutUTXO([]byte("utxo-không-có-thật"), entry); err != nil {
+			return err
+		}
+		return w.PutAddressIndex(domain.HashPubKey(bob.PublicKey), domain.AddressHistoryEntry{
+			TxID: []byte("tx-lạ"), Height: 2, Position: 5, Direction: domain.HistoryReceived, Amount: 1,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := verifyChain(t, bc, false)
+	if len(report.Invalid) != 0 {
+		t.Fatalf("block vẫn hợp lệ, chỉ dữ liệu dẫn xuất bị hỏng: %v", report.Invalid)
+	}
+	if len(report.Mismatches) < 3 {
+		t.Fatalf("phải báo UTXO thiếu, UTXO thừa và chỉ mục sai, nhận %v", report.Mismatches)
+	}
+
+	if err := bc.Repair(report); err != nil {
+		t.Fatal(err)
+	}
+	if report := verifyChain(t, bc, true); !report.OK() {
+		t.Fatalf("sau khi repair vẫn lỗi: %v %v", report.Invalid, report.Mismatches)
+	}
+	if entry, err := store.UTXO(transfer.ID); err != nil || entry == nil {
+		t.Fatalf("repair phải dựng lại UTXO đã mất: %v", err)
+	}
+}
+
+func TestVerifyDetectsTamperedBlocks(t *testing.T) {
+	bc, store, _, payment := newVerifyChain(t)
+	tampered, err := bc.GetBlock(payment.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Chữ ký không thuộc ID giao dịch nên chỉ chế độ --full mới phát hiện.
+	sig := tampered.Transactions[1].Vin[0].Signature
+	sig[0] ^= 0xff
+	if err := store.Update(func(w domain.ChainWriter) error { return w.PutBlock(tampered) }); err != nil {
+		t.Fatal(err)
+	}
+	if report := verifyChain(t, bc, false); !report.OK() {
+		t.Fatalf("kiểm tra nhanh không xác minh chữ ký: %v", report.Invalid)
+	}
+	if report := verifyChain(t, bc, true); len(report.Invalid) == 0 {
+		t.Fatal("--full phải phát hiện chữ ký bị sửa")
+	}
+
+	tampered.Nonce++
+	if err := store.Update(func(w domain.ChainWriter) error { return w.PutBlock(tampered) }); err != nil {
+		t.Fatal(err)
+	}
+	if report := verifyChain(t, bc, false); len(report.Invalid) == 0 {
+		t.Fatal("block có proof-of-work sai phải bị phát hiện")
+	}
+}
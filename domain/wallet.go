@@ -2,40 +2,53 @@ package domain
 
 import (
 	"bytes"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
 	"crypto/sha256"
-	"log"
+	"fmt"
 
 	"github.com/mr-tron/base58"
 )
 
 const (
-	version            = byte(0x00)
 	addressChecksumLen = 4
 )
 
 type Wallet struct {
-	PrivateKey ecdsa.PrivateKey
+	PrivateKey PrivateKey
 	PublicKey  []byte
 }
 
-func NewWallet() *Wallet {
-	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+func NewWallet() (*Wallet, error) {
+	return NewWalletWithKeyType(KeyTypeP256)
+}
+
+func NewWalletWithKeyType(keyType KeyType) (*Wallet, error) {
+	scheme, err := SchemeFor(keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := scheme.GenerateKey()
 	if err != nil {
-		log.Panic(err)
+		return nil, err
 	}
+	return &Wallet{PrivateKey: privateKey, PublicKey: privateKey.PublicKey()}, nil
+}
 
-	publicKey := append(privateKey.PublicKey.X.Bytes(), privateKey.PublicKey.Y.Bytes()...)
-	return &Wallet{PrivateKey: *privateKey, PublicKey: publicKey}
+func (w *Wallet) KeyType() KeyType {
+	return w.PrivateKey.Scheme().KeyType()
 }
 
 func (w *Wallet) GetAddress() string {
+	return AddressFromPubKey(w.PublicKey)
+}
 
-	pubKeyHash := HashPubKey(w.PublicKey)
+func AddressFromPubKey(pubKey []byte) string {
+	pubKeyHash := HashPubKey(pubKey)
 
-	versionedPayload := append([]byte{version}, pubKeyHash...)
+	versionedPayload := append([]byte{activeParams.AddressVersion}, pubKeyHash...)
+	if keyType, err := PublicKeyType(pubKey); err == nil && keyType != KeyTypeP256 {
+		versionedPayload = append([]byte{activeParams.TypedAddressVersion, byte(keyType)}, pubKeyHash...)
+	}
 
 	checksum := checksum(versionedPayload)
 
@@ -71,15 +84,45 @@ func ValidateAddress(address string) bool {
 	actualChecksum := fullPayload[len(fullPayload)-addressChecksumLen:]
 	versionedPayload := fullPayload[:len(fullPayload)-addressChecksumLen]
 	targetChecksum := checksum(versionedPayload)
-	return bytes.Equal(actualChecksum, targetChecksum)
+	if !bytes.Equal(actualChecksum, targetChecksum) {
+		return false
+	}
+
+	switch versionedPayload[0] {
+	case activeParams.AddressVersion:
+		return true
+	case activeParams.TypedAddressVersion:
+		if len(versionedPayload) < 2 {
+			return false
+		}
+		_, err := SchemeFor(KeyType(versionedPayload[1]))
+		return err == nil
+	default:
+		return false
+	}
 }
 
-func DecodeAddress(address string) []byte {
-	fullPayload, err := base58.Decode(address)
-	if err != nil {
-		log.Panic(err)
+func AddressKeyType(address string) (KeyType, error) {
+	if !ValidateAddress(address) {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidAddress, address)
 	}
+	fullPayload, _ := base58.Decode(address)
+	if fullPayload[0] == activeParams.TypedAddressVersion {
+		return KeyType(fullPayload[1]), nil
+	}
+	return KeyTypeP256, nil
+}
 
-	pubKeyHash := fullPayload[1 : len(fullPayload)-addressChecksumLen]
-	return pubKeyHash
+func DecodeAddress(address string) ([]byte, error) {
+	if !ValidateAddress(address) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAddress, address)
+	}
+	fullPayload, _ := base58.Decode(address)
+
+	prefixLen := 1
+	if fullPayload[0] == activeParams.TypedAddressVersion {
+		prefixLen = 2
+	}
+	pubKeyHash := fullPayload[prefixLen : len(fullPayload)-addressChecksumLen]
+	return pubKeyHash, nil
 }
@@ -3,6 +3,7 @@ module github.com/khoahotran/gochain-ledger
 go 1.25.3
 
 require (
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.0
 	github.com/dgraph-io/badger/v3 v3.2103.5
 	github.com/go-redis/redis/v8 v8.11.5
 	github.com/improbable-eng/grpc-web v0.15.0
@@ -0,0 +1,115 @@
+// Package testutil chứa fixture dùng chung cho test của domain và network.
+package testutil
+
+import (
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/storage"
+)
+
+// Emission dùng CoinbaseMaturity 0 để test tiêu được coinbase ngay ở block kế tiếp.
+var Emission = domain.EmissionSchedule{
+	InitialReward:    100,
+	HalvingInterval:  150,
+	MaxSupply:        1000000,
+	CoinbaseMaturity: 0,
+}
+
+func NewWallet(t *testing.T) *domain.Wallet {
+	t.Helper()
+	w, err := domain.NewWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+// NewChain khởi tạo chain regtest trên store (nil thì dùng store trong bộ nhớ);
+// phần thưởng genesis thuộc về ví được trả về.
+func NewChain(t *testing.T, store domain.ChainStore) (*domain.Blockchain, *domain.Wallet) {
+	t.Helper()
+	domain.SelectParams(&domain.RegTestParams)
+	if store == nil {
+		store = storage.NewMemory()
+	}
+	w := NewWallet(t)
+	bc, err := domain.InitBlockchain(store, w.GetAddress(), Emission)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { bc.Close() })
+	return bc, w
+}
+
+// Fork khởi tạo một chain khác trong bộ nhớ có cùng genesis với chain của owner,
+// dùng làm node thứ hai hoặc để dựng nhánh cạnh tranh.
+func Fork(t *testing.T, owner *domain.Wallet) *domain.Blockchain {
+	t.Helper()
+	bc, err := domain.InitBlockchain(storage.NewMemory(), owner.GetAddress(), Emission)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { bc.Close() })
+	return bc
+}
+
+func GenesisCoinbase(t *testing.T, bc *domain.Blockchain) *domain.Transaction {
+	t.Helper()
+	block, err := bc.GetBlockByHeight(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return block.Transactions[0]
+}
+
+func PayTo(t *testing.T, address string, value int64) domain.TxOutput {
+	t.Helper()
+	out := domain.TxOutput{Value: value}
+	if err := out.Lock(address); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// Spend ký giao dịch tiêu output voutIndex của prevID; output đó phải còn trong UTXO Set.
+func Spend(t *testing.T, bc *domain.Blockchain, from *domain.Wallet, prevID []byte, voutIndex int, outputs ...domain.TxOutput) *domain.Transaction {
+	t.Helper()
+	tx := &domain.Transaction{
+		Vin:  []domain.TxInput{{TxID: prevID, VoutIndex: voutIndex, PublicKey: from.PublicKey, Sequence: domain.SequenceFinal}},
+		Vout: outputs,
+	}
+	tx.SetID()
+	prevTxs, err := bc.ReferencedOutputs(tx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Sign(from.PrivateKey, prevTxs); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+// Coinbase trả thưởng block ở height kế tiếp cộng phí của txs cho miner.
+func Coinbase(t *testing.T, bc *domain.Blockchain, miner *domain.Wallet, txs ...*domain.Transaction) *domain.Transaction {
+	t.Helper()
+	height := bc.GetBestHeight() + 1
+	var fees int64
+	for _, tx := range txs {
+		fees += bc.TransactionFee(tx)
+	}
+	coinbase, err := domain.NewCoinbaseTransaction(miner.GetAddress(), bc.Emission.BlockReward(height)+fees, height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return coinbase
+}
+
+func MineBlock(t *testing.T, bc *domain.Blockchain, miner *domain.Wallet, txs ...*domain.Transaction) *domain.Block {
+	t.Helper()
+	block, err := bc.AddBlock(append([]*domain.Transaction{Coinbase(t, bc, miner, txs...)}, txs...), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return block
+}
@@ -0,0 +1,75 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+func TestPendingAwareBalanceAndUTXOs(t *testing.T) {
+	ctx := context.Background()
+	s, alice := newTestServer(t)
+	bob := testutil.NewWallet(t)
+	miner := testutil.NewWallet(t)
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+
+	balance := func(w *domain.Wallet) *proto.GetBalanceResponse {
+		t.Helper()
+		res, err := s.GetBalance(ctx, &proto.GetBalanceRequest{Address: w.GetAddress()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	if res := balance(alice); res.Confirmed != 100 || res.Unconfirmed != 0 {
+		t.Fatalf("số dư ban đầu của alice: %+v", res)
+	}
+
+	tx := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, bob.GetAddress(), 60), testutil.PayTo(t, alice.GetAddress(), 30))
+	if ack, err := s.acceptTransaction(ctx, tx); err != nil || !ack.Success {
+		t.Fatalf("giao dịch bị từ chối: %v %v", ack, err)
+	}
+
+	if res := balance(alice); res.Confirmed != 0 || res.Unconfirmed != 30 || res.Balance != 30 {
+		t.Fatalf("alice sau khi gửi (coin đã tiêu trong mempool không được tính): %+v", res)
+	}
+	if res := balance(bob); res.Confirmed != 0 || res.Unconfirmed != 60 {
+		t.Fatalf("bob trước khi xác nhận: %+v", res)
+	}
+
+	_, err := s.FindSpendableUTXOs(ctx, &proto.FindSpendableUTXOsRequest{Address: alice.GetAddress(), Amount: 20})
+	if !errors.Is(err, domain.ErrInsufficientFunds) {
+		t.Fatalf("không tính UTXO chưa xác nhận: lỗi %v, muốn ErrInsufficientFunds", err)
+	}
+	found, err := s.FindSpendableUTXOs(ctx, &proto.FindSpendableUTXOsRequest{Address: alice.GetAddress(), Amount: 20, IncludeUnconfirmed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found.Utxos) != 1 || !found.Utxos[0].Unconfirmed || found.Utxos[0].Amount != 30 || found.Utxos[0].VoutIndex != 1 {
+		t.Fatalf("phải chọn output tiền thừa chưa xác nhận: %+v", found.Utxos)
+	}
+
+	listed, err := s.ListUnspent(ctx, &proto.ListUnspentRequest{Address: bob.GetAddress(), IncludeUnconfirmed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if listed.Total != 1 || listed.TotalAmount != 60 || listed.Utxos[0].Confirmations != 0 {
+		t.Fatalf("ListUnspent của bob: %+v", listed)
+	}
+
+	block := testutil.MineBlock(t, s.Blockchain, miner, tx)
+	if err := s.Mempool.RemoveBlock(ctx, block); err != nil {
+		t.Fatal(err)
+	}
+	if res := balance(bob); res.Confirmed != 60 || res.Unconfirmed != 0 {
+		t.Fatalf("bob sau khi xác nhận: %+v", res)
+	}
+	if res := balance(alice); res.Confirmed != 30 || res.Unconfirmed != 0 {
+		t.Fatalf("alice sau khi xác nhận: %+v", res)
+	}
+}
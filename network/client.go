@@ -2,20 +2,19 @@ package network
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/khoahotran/gochain-ledger/domain"
 	"github.com/khoahotran/gochain-ledger/proto"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
-func SendTransactionToNode(targetNodeAddr string, tx *domain.Transaction) {
+func SendTransactionToNode(targetNodeAddr string, tx *domain.Transaction) error {
 	log.Printf("Đang kết nối đến node tại %s...", targetNodeAddr)
 
-	conn, err := grpc.Dial(targetNodeAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := Dial(targetNodeAddr)
 	if err != nil {
-		log.Fatalf("Không thể kết nối: %v", err)
+		return fmt.Errorf("không thể kết nối: %w", err)
 	}
 	defer conn.Close()
 
@@ -25,8 +24,12 @@ func SendTransactionToNode(targetNodeAddr string, tx *domain.Transaction) {
 
 	ack, err := client.SendTransaction(context.Background(), protoTx)
 	if err != nil {
-		log.Fatalf("Gọi gRPC SendTransaction thất bại: %v", err)
+		return fmt.Errorf("gọi gRPC SendTransaction thất bại: %w", err)
 	}
 
 	log.Printf("Phản hồi từ node: [%t] %s", ack.Success, ack.Message)
+	if !ack.Success {
+		return fmt.Errorf("node từ chối giao dịch: %s", ack.Message)
+	}
+	return nil
 }
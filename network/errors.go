@@ -0,0 +1,84 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"log"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+)
+
+var (
+	ErrInvalidArgument    = errors.New("tham số không hợp lệ")
+	ErrUnsupportedNetwork = errors.New("không hỗ trợ trên mạng này")
+	ErrEventBusDisabled   = errors.New("node chưa bật event bus")
+)
+
+var statusCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{domain.ErrNotFound, codes.NotFound},
+	{domain.ErrBlockNotFound, codes.NotFound},
+	{domain.ErrTxNotFound, codes.NotFound},
+	{domain.ErrContractNotFound, codes.NotFound},
+	{domain.ErrInvalidAddress, codes.InvalidArgument},
+	{domain.ErrInvalidSignature, codes.InvalidArgument},
+	{domain.ErrInvalidTransaction, codes.InvalidArgument},
+	{domain.ErrInvalidBlock, codes.InvalidArgument},
+	{domain.ErrInsufficientFunds, codes.FailedPrecondition},
+	{domain.ErrImmatureCoinbase, codes.FailedPrecondition},
+	{domain.ErrIndexDisabled, codes.FailedPrecondition},
+	{domain.ErrBlockNotOnTip, codes.FailedPrecondition},
+	{domain.ErrNoBlockchain, codes.FailedPrecondition},
+	{ErrInvalidArgument, codes.InvalidArgument},
+	{ErrUnsupportedNetwork, codes.FailedPrecondition},
+	{ErrEventBusDisabled, codes.FailedPrecondition},
+	{ErrMempoolConflict, codes.AlreadyExists},
+	{ErrMempoolDuplicate, codes.AlreadyExists},
+	{domain.ErrCorruptData, codes.DataLoss},
+	{domain.ErrSubscriberLagging, codes.Unavailable},
+	{context.Canceled, codes.Canceled},
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+}
+
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	for _, entry := range statusCodes {
+		if errors.Is(err, entry.err) {
+			return status.Error(entry.code, err.Error())
+		}
+	}
+	return err
+}
+
+func recoverUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("LỖI nghiêm trọng khi xử lý %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			res, err = nil, status.Errorf(codes.Internal, "lỗi nội bộ khi xử lý %s", info.FullMethod)
+		}
+	}()
+	res, err = handler(ctx, req)
+	return res, statusError(err)
+}
+
+func recoverStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("LỖI nghiêm trọng khi xử lý %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Errorf(codes.Internal, "lỗi nội bộ khi xử lý %s", info.FullMethod)
+		}
+	}()
+	return statusError(handler(srv, ss))
+}
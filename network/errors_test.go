@@ -0,0 +1,95 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+func statusCode(err error) codes.Code {
+	return status.Code(statusError(err))
+}
+
+func TestRequestErrorsMapToStatusCodes(t *testing.T) {
+	ctx := context.Background()
+	s, alice := newTestServer(t)
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"generate count 0", func() error {
+			_, err := s.Generate(ctx, &proto.GenerateRequest{Count: 0, Address: alice.GetAddress()})
+			return err
+		}, codes.InvalidArgument},
+		{"generate count quá lớn", func() error {
+			_, err := s.Generate(ctx, &proto.GenerateRequest{Count: MaxGenerateBlocks + 1, Address: alice.GetAddress()})
+			return err
+		}, codes.InvalidArgument},
+		{"generate địa chỉ sai", func() error {
+			_, err := s.Generate(ctx, &proto.GenerateRequest{Count: 1, Address: "khong-phai-dia-chi"})
+			return err
+		}, codes.InvalidArgument},
+		{"generate ngoài regtest", func() error {
+			domain.SelectParams(&domain.MainNetParams)
+			defer domain.SelectParams(&domain.RegTestParams)
+			_, err := s.Generate(ctx, &proto.GenerateRequest{Count: 1, Address: alice.GetAddress()})
+			return err
+		}, codes.FailedPrecondition},
+		{"listunspent offset âm", func() error {
+			_, err := s.ListUnspent(ctx, &proto.ListUnspentRequest{Address: alice.GetAddress(), Offset: -1})
+			return err
+		}, codes.InvalidArgument},
+		{"history limit âm", func() error {
+			_, err := s.GetAddressHistory(ctx, &proto.GetAddressHistoryRequest{Address: alice.GetAddress(), Limit: -1})
+			return err
+		}, codes.InvalidArgument},
+		{"history chưa bật txindex", func() error {
+			_, err := s.GetAddressHistory(ctx, &proto.GetAddressHistoryRequest{Address: alice.GetAddress()})
+			return err
+		}, codes.FailedPrecondition},
+		{"listblocks offset âm", func() error {
+			_, err := s.ListBlocks(ctx, &proto.ListBlocksRequest{Offset: -1})
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if got := statusCode(err); got != tt.want {
+				t.Fatalf("mã %v (lỗi %v), muốn %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusErrorKeepsWrappedSentinels(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{fmt.Errorf("input 0: %w", domain.ErrImmatureCoinbase), codes.FailedPrecondition},
+		{fmt.Errorf("TX: %w", domain.ErrInvalidSignature), codes.InvalidArgument},
+		{fmt.Errorf("%w: abc", domain.ErrTxNotFound), codes.NotFound},
+		{fmt.Errorf("%w: abc", ErrMempoolConflict), codes.AlreadyExists},
+		{domain.ErrSubscriberLagging, codes.Unavailable},
+		{fmt.Errorf("%w: block", domain.ErrCorruptData), codes.DataLoss},
+		{errors.New("khác"), codes.Unknown},
+	}
+	for _, tt := range tests {
+		if got := statusCode(tt.err); got != tt.want {
+			t.Errorf("%v: mã %v, muốn %v", tt.err, got, tt.want)
+		}
+	}
+	if statusError(nil) != nil {
+		t.Error("statusError(nil) phải trả về nil")
+	}
+}
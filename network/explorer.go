@@ -0,0 +1,165 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+func (s *Server) GetChainInfo(ctx context.Context, req *proto.EmptyRequest) (*proto.GetChainInfoResponse, error) {
+	tip, err := s.Blockchain.GetBlock(s.Blockchain.LastHash)
+	if err != nil {
+		return nil, err
+	}
+	issued, err := s.Blockchain.GetSupply()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &proto.GetChainInfoResponse{
+		TipHash:         tip.Hash,
+		Height:          tip.Height,
+		TipTimestamp:    tip.Timestamp,
+		Difficulty:      int64(domain.ActiveParams().Difficulty),
+		Issued:          issued,
+		MaxSupply:       s.Blockchain.Emission.MaxSupply,
+		NextBlockReward: s.Blockchain.Emission.BlockReward(tip.Height + 1),
+		TxIndex:         s.Blockchain.Indexed,
+		Network:         domain.ActiveParams().Name,
+	}
+	if s.Mempool != nil {
+		entries, err := s.Mempool.Entries(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("lỗi đọc mempool: %v", err)
+		}
+		res.MempoolSize = int32(len(entries))
+	}
+	return res, nil
+}
+
+func (s *Server) GetBlock(ctx context.Context, req *proto.GetBlockRequest) (*proto.GetBlockResponse, error) {
+	var block *domain.Block
+	var err error
+	if len(req.Hash) > 0 {
+		block, err = s.Blockchain.GetBlock(req.Hash)
+	} else {
+		block, err = s.Blockchain.GetBlockByHeight(req.Height)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.GetBlockResponse{
+		Summary: blockSummary(block, s.Blockchain.GetBestHeight()),
+		Block:   MapDomainBlockToProto(block),
+	}, nil
+}
+
+func (s *Server) ListBlocks(ctx context.Context, req *proto.ListBlocksRequest) (*proto.ListBlocksResponse, error) {
+	if req.Offset < 0 || req.Limit < 0 {
+		return nil, fmt.Errorf("%w: offset và limit không được âm", ErrInvalidArgument)
+	}
+
+	bestHeight := s.Blockchain.GetBestHeight()
+	blocks, err := s.Blockchain.ListBlocks(int64(req.Offset), int64(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	total := int(bestHeight + 1)
+	res := &proto.ListBlocksResponse{Total: int32(total)}
+	for _, block := range blocks {
+		res.Blocks = append(res.Blocks, blockSummary(block, bestHeight))
+	}
+	if end := int(req.Offset) + len(blocks); end < total {
+		res.NextOffset = int32(end)
+	}
+	return res, nil
+}
+
+func (s *Server) GetMempool(ctx context.Context, req *proto.EmptyRequest) (*proto.GetMempoolResponse, error) {
+	res := &proto.GetMempoolResponse{}
+	if s.Mempool == nil {
+		return res, nil
+	}
+
+	entries, err := s.Mempool.Entries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi đọc mempool: %v", err)
+	}
+	for _, entry := range entries {
+		size := len(entry.Tx.Serialize())
+		var outputTotal int64
+		for _, out := range entry.Tx.Vout {
+			outputTotal += out.Value
+		}
+
+		res.Transactions = append(res.Transactions, &proto.MempoolTransaction{
+			TxId:        entry.Tx.ID,
+			Fee:         entry.Fee,
+			AddedAt:     entry.AddedAt,
+			Size:        int32(size),
+			InputCount:  int32(len(entry.Tx.Vin)),
+			OutputCount: int32(len(entry.Tx.Vout)),
+			OutputTotal: outputTotal,
+		})
+		res.TotalFees += entry.Fee
+		res.TotalSize += int64(size)
+	}
+	res.Count = int32(len(res.Transactions))
+	return res, nil
+}
+
+func (s *Server) resolveInputs(tx *domain.Transaction, pending map[string]*domain.Transaction) []*proto.ResolvedInput {
+	if tx.IsCoinbase() {
+		return nil
+	}
+
+	inputs := make([]*proto.ResolvedInput, len(tx.Vin))
+	for i, vin := range tx.Vin {
+		input := &proto.ResolvedInput{TxId: vin.TxID, VoutIndex: int32(vin.VoutIndex)}
+		inputs[i] = input
+
+		prevTx, ok := pending[string(vin.TxID)]
+		if !ok {
+			found, err := s.Blockchain.FindTransaction(vin.TxID)
+			if err != nil {
+				continue
+			}
+			prevTx = &found
+		}
+		if vin.VoutIndex < 0 || vin.VoutIndex >= len(prevTx.Vout) {
+			continue
+		}
+
+		prevOut := prevTx.Vout[vin.VoutIndex]
+		input.Amount = prevOut.Value
+		input.PubKeyHash = prevOut.PubKeyHash
+		if len(vin.PublicKey) > 0 && bytes.Equal(domain.HashPubKey(vin.PublicKey), prevOut.PubKeyHash) {
+			input.Address = domain.AddressFromPubKey(vin.PublicKey)
+		}
+	}
+	return inputs
+}
+
+func blockSummary(block *domain.Block, bestHeight int64) *proto.BlockSummary {
+	summary := &proto.BlockSummary{
+		Hash:          block.Hash,
+		PrevBlockHash: block.PrevBlockHash,
+		Height:        block.Height,
+		Timestamp:     block.Timestamp,
+		Nonce:         block.Nonce,
+		TxCount:       int32(len(block.Transactions)),
+		Size:          int32(len(block.Serialize())),
+		Confirmations: bestHeight - block.Height + 1,
+	}
+	if len(block.Transactions) > 0 && block.Transactions[0].IsCoinbase() {
+		for _, out := range block.Transactions[0].Vout {
+			summary.Reward += out.Value
+		}
+	}
+	return summary
+}
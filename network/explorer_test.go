@@ -0,0 +1,128 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+func TestExplorerChainAndBlocks(t *testing.T) {
+	ctx := context.Background()
+	s, alice := newTestServer(t)
+	for i := 0; i < 2; i++ {
+		testutil.MineBlock(t, s.Blockchain, alice)
+	}
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+	pending := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, alice.GetAddress(), 90))
+	if ack, err := s.acceptTransaction(ctx, pending); err != nil || !ack.Success {
+		t.Fatalf("giao dịch bị từ chối: %v %v", ack, err)
+	}
+
+	info, err := s.GetChainInfo(ctx, &proto.EmptyRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	emission := s.Blockchain.Emission
+	if info.Height != 2 || !bytes.Equal(info.TipHash, s.Blockchain.LastHash) || info.MempoolSize != 1 ||
+		info.Difficulty != int64(domain.ActiveParams().Difficulty) || info.Issued != emission.SupplyAt(2) ||
+		info.NextBlockReward != emission.BlockReward(3) || info.Network != domain.ActiveParams().Name {
+		t.Fatalf("GetChainInfo: %+v", info)
+	}
+
+	byHeight, err := s.GetBlock(ctx, &proto.GetBlockRequest{Height: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byHash, err := s.GetBlock(ctx, &proto.GetBlockRequest{Hash: byHeight.Summary.Hash})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byHash.Summary.Height != 1 || byHash.Summary.Confirmations != 2 || byHash.Summary.TxCount != 1 ||
+		byHash.Summary.Reward != emission.BlockReward(1) {
+		t.Fatalf("GetBlock: %+v", byHash.Summary)
+	}
+	if _, err := s.GetBlock(ctx, &proto.GetBlockRequest{Height: 10}); err == nil {
+		t.Fatal("block không tồn tại phải lỗi")
+	}
+
+	page, err := s.ListBlocks(ctx, &proto.ListBlocksRequest{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 3 || len(page.Blocks) != 2 || page.NextOffset != 2 {
+		t.Fatalf("ListBlocks trang đầu: total %d, %d block, next %d", page.Total, len(page.Blocks), page.NextOffset)
+	}
+	last, err := s.ListBlocks(ctx, &proto.ListBlocksRequest{Offset: page.NextOffset, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(last.Blocks) != 1 || last.NextOffset != 0 {
+		t.Fatalf("ListBlocks trang cuối: %d block, next %d", len(last.Blocks), last.NextOffset)
+	}
+	if _, err := s.ListBlocks(ctx, &proto.ListBlocksRequest{Offset: -1}); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("offset âm: lỗi %v, muốn ErrInvalidArgument", err)
+	}
+
+	mempool, err := s.GetMempool(ctx, &proto.EmptyRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mempool.Count != 1 || mempool.TotalFees != 10 || mempool.Transactions[0].OutputTotal != 90 ||
+		!bytes.Equal(mempool.Transactions[0].TxId, pending.ID) || mempool.TotalSize != int64(len(pending.Serialize())) {
+		t.Fatalf("GetMempool: %+v", mempool)
+	}
+}
+
+func TestExplorerGetTransaction(t *testing.T) {
+	ctx := context.Background()
+	s, alice := newTestServer(t)
+	bob := testutil.NewWallet(t)
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+	tx := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, bob.GetAddress(), 60), testutil.PayTo(t, alice.GetAddress(), 30))
+	if ack, err := s.acceptTransaction(ctx, tx); err != nil || !ack.Success {
+		t.Fatalf("giao dịch bị từ chối: %v %v", ack, err)
+	}
+
+	checkInputs := func(res *proto.GetTransactionResponse) {
+		t.Helper()
+		if res.Fee != 10 || len(res.Inputs) != 1 || res.Inputs[0].Amount != 100 || res.Inputs[0].Address != alice.GetAddress() {
+			t.Fatalf("phí và input đã phân giải: fee %d, inputs %+v", res.Fee, res.Inputs)
+		}
+		if res.RawHex != tx.RawHex() {
+			t.Fatal("raw hex không khớp giao dịch")
+		}
+	}
+
+	res, err := s.GetTransaction(ctx, &proto.GetTransactionRequest{TxId: tx.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Pending || res.Confirmations != 0 || len(res.BlockHash) != 0 {
+		t.Fatalf("giao dịch trong mempool: %+v", res)
+	}
+	checkInputs(res)
+
+	block := testutil.MineBlock(t, s.Blockchain, bob, tx)
+	if err := s.Mempool.RemoveBlock(ctx, block); err != nil {
+		t.Fatal(err)
+	}
+	testutil.MineBlock(t, s.Blockchain, bob)
+
+	res, err = s.GetTransaction(ctx, &proto.GetTransactionRequest{TxId: tx.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Pending || res.Confirmations != 2 || res.BlockHeight != block.Height || !bytes.Equal(res.BlockHash, block.Hash) {
+		t.Fatalf("giao dịch đã xác nhận: pending %v, %d xác nhận, block %d", res.Pending, res.Confirmations, res.BlockHeight)
+	}
+	checkInputs(res)
+
+	if _, err := s.GetTransaction(ctx, &proto.GetTransactionRequest{TxId: []byte("không-có")}); !errors.Is(err, domain.ErrTxNotFound) {
+		t.Fatalf("giao dịch lạ: lỗi %v, muốn ErrTxNotFound", err)
+	}
+}
@@ -0,0 +1,382 @@
+package network
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+const (
+	GatewayPrefix  = "/api/v1/"
+	openAPIPath    = GatewayPrefix + "openapi.json"
+	maxRequestBody = 4 << 20
+)
+
+type gatewayMethod struct {
+	fullName string
+	desc     protoreflect.MethodDescriptor
+}
+
+type Gateway struct {
+	conn    *grpc.ClientConn
+	methods map[string]gatewayMethod
+
+	specOnce sync.Once
+	spec     []byte
+}
+
+func NewGateway(conn *grpc.ClientConn) *Gateway {
+	g := &Gateway{conn: conn, methods: make(map[string]gatewayMethod)}
+	for _, file := range []protoreflect.FileDescriptor{proto.File_proto_blockchain_proto, proto.File_proto_public_proto} {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			sd := services.Get(i)
+			methods := sd.Methods()
+			for j := 0; j < methods.Len(); j++ {
+				md := methods.Get(j)
+				g.methods[fmt.Sprintf("%s/%s", sd.Name(), md.Name())] = gatewayMethod{
+					fullName: fmt.Sprintf("/%s/%s", sd.FullName(), md.Name()),
+					desc:     md,
+				}
+			}
+		}
+	}
+	return g
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.URL.Path == openAPIPath {
+		g.serveOpenAPI(w)
+		return
+	}
+
+	method, ok := g.methods[strings.TrimPrefix(r.URL.Path, GatewayPrefix)]
+	if !ok {
+		writeGatewayError(w, http.StatusNotFound, codes.NotFound, fmt.Sprintf("không có RPC cho đường dẫn %s", r.URL.Path))
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		writeGatewayError(w, http.StatusMethodNotAllowed, codes.Unimplemented, "chỉ hỗ trợ GET và POST")
+		return
+	}
+
+	req, err := newMessage(method.desc.Input())
+	if err != nil {
+		writeGatewayError(w, http.StatusInternalServerError, codes.Internal, err.Error())
+		return
+	}
+	if err := decodeRequest(r, req.ProtoReflect()); err != nil {
+		writeGatewayError(w, http.StatusBadRequest, codes.InvalidArgument, err.Error())
+		return
+	}
+
+	if method.desc.IsStreamingServer() {
+		g.serveStream(w, r, method, req)
+		return
+	}
+
+	res, err := newMessage(method.desc.Output())
+	if err != nil {
+		writeGatewayError(w, http.StatusInternalServerError, codes.Internal, err.Error())
+		return
+	}
+	if err := g.conn.Invoke(r.Context(), method.fullName, req, res); err != nil {
+		writeRPCError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(encodeMessage(res.ProtoReflect())); err != nil {
+		log.Printf("Gateway: lỗi ghi phản hồi %s: %v", method.fullName, err)
+	}
+}
+
+func (g *Gateway) serveStream(w http.ResponseWriter, r *http.Request, method gatewayMethod, req protobuf.Message) {
+	stream, err := g.conn.NewStream(r.Context(), &grpc.StreamDesc{ServerStreams: true}, method.fullName)
+	if err != nil {
+		writeRPCError(w, err)
+		return
+	}
+	if err := stream.SendMsg(req); err != nil {
+		writeRPCError(w, err)
+		return
+	}
+	if err := stream.CloseSend(); err != nil {
+		writeRPCError(w, err)
+		return
+	}
+
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	started := false
+	for {
+		res, err := newMessage(method.desc.Output())
+		if err != nil {
+			return
+		}
+		err = stream.RecvMsg(res)
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			if !started {
+				writeRPCError(w, err)
+				return
+			}
+			st := status.Convert(err)
+			encoder.Encode(map[string]any{"error": st.Message(), "code": st.Code().String()})
+			return
+		}
+
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			started = true
+		}
+		if err := encoder.Encode(encodeMessage(res.ProtoReflect())); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+func newMessage(desc protoreflect.MessageDescriptor) (protobuf.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(desc.FullName())
+	if err != nil {
+		return nil, fmt.Errorf("không tìm thấy kiểu message %s: %v", desc.FullName(), err)
+	}
+	return mt.New().Interface(), nil
+}
+
+func decodeRequest(r *http.Request, msg protoreflect.Message) error {
+	values := make(map[string]any)
+	for key, vals := range r.URL.Query() {
+		if len(vals) == 1 {
+			values[key] = vals[0]
+		} else {
+			list := make([]any, len(vals))
+			for i, v := range vals {
+				list[i] = v
+			}
+			values[key] = list
+		}
+	}
+
+	if r.Method == http.MethodPost {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBody))
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(body)) > 0 {
+			decoder := json.NewDecoder(bytes.NewReader(body))
+			decoder.UseNumber()
+			var fields map[string]any
+			if err := decoder.Decode(&fields); err != nil {
+				return fmt.Errorf("body JSON không hợp lệ: %v", err)
+			}
+			for key, value := range fields {
+				values[key] = value
+			}
+		}
+	}
+	return decodeMessage(msg, values)
+}
+
+func decodeMessage(msg protoreflect.Message, values map[string]any) error {
+	fields := msg.Descriptor().Fields()
+	for key, value := range values {
+		fd := fields.ByName(protoreflect.Name(key))
+		if fd == nil {
+			fd = fields.ByJSONName(key)
+		}
+		if fd == nil {
+			return fmt.Errorf("trường %q không tồn tại trong %s", key, msg.Descriptor().Name())
+		}
+		if value == nil {
+			continue
+		}
+
+		if fd.IsList() {
+			items, ok := value.([]any)
+			if !ok {
+				items = []any{value}
+			}
+			list := msg.Mutable(fd).List()
+			for _, item := range items {
+				v, err := decodeValue(fd, item, list.NewElement)
+				if err != nil {
+					return err
+				}
+				list.Append(v)
+			}
+			continue
+		}
+
+		v, err := decodeValue(fd, value, func() protoreflect.Value { return msg.NewField(fd) })
+		if err != nil {
+			return err
+		}
+		msg.Set(fd, v)
+	}
+	return nil
+}
+
+func decodeValue(fd protoreflect.FieldDescriptor, value any, newValue func() protoreflect.Value) (protoreflect.Value, error) {
+	invalid := func(err error) (protoreflect.Value, error) {
+		return protoreflect.Value{}, fmt.Errorf("trường %q: giá trị %v không hợp lệ: %v", fd.Name(), value, err)
+	}
+
+	text := fmt.Sprint(value)
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return invalid(fmt.Errorf("cần một object"))
+		}
+		v := newValue()
+		if err := decodeMessage(v.Message(), fields); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return v, nil
+	case protoreflect.BytesKind:
+		b, err := hex.DecodeString(text)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfBytes(b), nil
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(text), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(text, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt32(int32(n)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt64(n), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(text, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfUint32(uint32(n)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(text, 10, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfUint64(n), nil
+	}
+	return invalid(fmt.Errorf("kiểu %s chưa được hỗ trợ", fd.Kind()))
+}
+
+func encodeMessage(msg protoreflect.Message) map[string]any {
+	out := make(map[string]any)
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := string(fd.Name())
+
+		if fd.IsList() {
+			list := msg.Get(fd).List()
+			items := make([]any, list.Len())
+			for j := 0; j < list.Len(); j++ {
+				items[j] = encodeValue(fd, list.Get(j))
+			}
+			out[name] = items
+			continue
+		}
+		if fd.Kind() == protoreflect.MessageKind && !msg.Has(fd) {
+			out[name] = nil
+			continue
+		}
+		out[name] = encodeValue(fd, msg.Get(fd))
+	}
+	return out
+}
+
+func encodeValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return encodeMessage(v.Message())
+	case protoreflect.BytesKind:
+		return hex.EncodeToString(v.Bytes())
+	}
+	return v.Interface()
+}
+
+func writeRPCError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeGatewayError(w, httpStatusFromCode(st.Code()), st.Code(), st.Message())
+}
+
+func writeGatewayError(w http.ResponseWriter, httpStatus int, code codes.Code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	json.NewEncoder(w).Encode(map[string]any{"error": message, "code": code.String()})
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Canceled:
+		return 499
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,147 @@
+package network
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+)
+
+func startTestGateway(t *testing.T, s *Server) string {
+	t.Helper()
+	conn, err := Dial(serveTestGRPC(t, s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	server := httptest.NewServer(NewGateway(conn))
+	t.Cleanup(server.Close)
+	return server.URL + GatewayPrefix
+}
+
+func gatewayCall(t *testing.T, method, target, body string) (int, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var out map[string]any
+	if res.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+			t.Fatalf("%s %s: phản hồi không phải JSON: %v", method, target, err)
+		}
+	}
+	return res.StatusCode, out
+}
+
+func TestGatewayUnaryCalls(t *testing.T) {
+	s, alice := newTestServer(t)
+	testutil.MineBlock(t, s.Blockchain, alice)
+	api := startTestGateway(t, s)
+
+	code, balance := gatewayCall(t, http.MethodGet, api+"PublicService/GetBalance?address="+url.QueryEscape(alice.GetAddress()), "")
+	if code != http.StatusOK || balance["confirmed"] != float64(s.Blockchain.Emission.SupplyAt(1)) {
+		t.Fatalf("GetBalance qua GET: %d %v", code, balance)
+	}
+
+	code, blocks := gatewayCall(t, http.MethodPost, api+"PublicService/ListBlocks", `{"limit": 1}`)
+	if code != http.StatusOK || len(blocks["blocks"].([]any)) != 1 || blocks["next_offset"] != float64(1) {
+		t.Fatalf("ListBlocks qua POST: %d %v", code, blocks)
+	}
+
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+	code, tx := gatewayCall(t, http.MethodGet, api+"NodeService/GetTransaction?tx_id="+hex.EncodeToString(coinbase.ID), "")
+	if code != http.StatusOK || tx["raw_hex"] != coinbase.RawHex() {
+		t.Fatalf("GetTransaction với tx_id hex: %d %v", code, tx)
+	}
+	if got := tx["transaction"].(map[string]any)["id"]; got != hex.EncodeToString(coinbase.ID) {
+		t.Fatalf("trường bytes phải được mã hóa hex, nhận %v", got)
+	}
+}
+
+func TestGatewayErrors(t *testing.T) {
+	s, _ := newTestServer(t)
+	api := startTestGateway(t, s)
+
+	cases := []struct {
+		method, path, body string
+		status             int
+		code               string
+	}{
+		{http.MethodGet, "NodeService/NoSuchRPC", "", http.StatusNotFound, "NotFound"},
+		{http.MethodPut, "PublicService/ListBlocks", "", http.StatusMethodNotAllowed, "Unimplemented"},
+		{http.MethodGet, "PublicService/ListBlocks?bogus=1", "", http.StatusBadRequest, "InvalidArgument"},
+		{http.MethodGet, "NodeService/GetTransaction?tx_id=zz", "", http.StatusBadRequest, "InvalidArgument"},
+		{http.MethodPost, "PublicService/ListBlocks", "{", http.StatusBadRequest, "InvalidArgument"},
+		{http.MethodGet, "NodeService/GetTransaction?tx_id=00ff", "", http.StatusNotFound, "NotFound"},
+		{http.MethodGet, "PublicService/ListBlocks?offset=-1", "", http.StatusBadRequest, "InvalidArgument"},
+	}
+	for _, c := range cases {
+		status, body := gatewayCall(t, c.method, api+c.path, c.body)
+		if status != c.status || body["code"] != c.code || body["error"] == "" {
+			t.Errorf("%s %s: %d %v, muốn %d %s", c.method, c.path, status, body, c.status, c.code)
+		}
+	}
+
+	if status, _ := gatewayCall(t, http.MethodOptions, api+"PublicService/ListBlocks", ""); status != http.StatusNoContent {
+		t.Fatalf("preflight CORS: %d", status)
+	}
+}
+
+func TestGatewayOpenAPI(t *testing.T) {
+	s, _ := newTestServer(t)
+	api := startTestGateway(t, s)
+
+	status, spec := gatewayCall(t, http.MethodGet, api+"openapi.json", "")
+	if status != http.StatusOK || spec["openapi"] == nil {
+		t.Fatalf("openapi.json: %d", status)
+	}
+	paths := spec["paths"].(map[string]any)
+	for _, route := range []string{"PublicService/GetBlock", "NodeService/GetTransaction", "PublicService/GetBalance", "PublicService/WatchTransaction"} {
+		if paths[GatewayPrefix+route] == nil {
+			t.Errorf("OpenAPI thiếu %s", route)
+		}
+	}
+}
+
+func TestGatewayStreamsNDJSON(t *testing.T) {
+	s, alice := newTestServer(t)
+	s.Blockchain.Events = domain.NewEventBus()
+	testutil.MineBlock(t, s.Blockchain, alice)
+	api := startTestGateway(t, s)
+
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+	res, err := http.Get(api + "PublicService/WatchTransaction?tx_id=" + hex.EncodeToString(coinbase.ID) + "&confirmations=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if ct := res.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Fatalf("Content-Type %q, muốn application/x-ndjson", ct)
+	}
+
+	var lines []map[string]any
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("dòng không phải JSON: %q", scanner.Text())
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 1 || lines[0]["status"] != txStatusConfirmed || lines[0]["confirmations"] != float64(2) {
+		t.Fatalf("luồng WatchTransaction: %v", lines)
+	}
+}
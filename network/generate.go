@@ -0,0 +1,41 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+const MaxGenerateBlocks = 1000
+
+func (s *Server) Generate(ctx context.Context, req *proto.GenerateRequest) (*proto.GenerateResponse, error) {
+	params := domain.ActiveParams()
+	if !params.OnDemandMining {
+		return nil, fmt.Errorf("%w: mạng %s không hỗ trợ generate, chỉ dùng trên regtest", ErrUnsupportedNetwork, params.Name)
+	}
+	if req.Count <= 0 || req.Count > MaxGenerateBlocks {
+		return nil, fmt.Errorf("%w: số block phải nằm trong khoảng 1-%d", ErrInvalidArgument, MaxGenerateBlocks)
+	}
+	if !domain.ValidateAddress(req.Address) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidAddress, req.Address)
+	}
+
+	res := &proto.GenerateResponse{}
+	for i := int32(0); i < req.Count; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block, err := MineBlock(ctx, s.Blockchain, s.Mempool, req.Address, true)
+		if err != nil {
+			return nil, err
+		}
+		res.BlockHashes = append(res.BlockHashes, block.Hash)
+		res.Height = block.Height
+	}
+
+	log.Printf("Generate: Đã đào %d block cho %s, chiều cao hiện tại: %d", req.Count, req.Address, res.Height)
+	return res, nil
+}
@@ -0,0 +1,79 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+func TestGenerateMinesMempoolOnDemand(t *testing.T) {
+	ctx := context.Background()
+	s, alice := newTestServer(t)
+	miner := testutil.NewWallet(t)
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+	tx := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, alice.GetAddress(), 90))
+	if ack, err := s.acceptTransaction(ctx, tx); err != nil || !ack.Success {
+		t.Fatalf("giao dịch bị từ chối: %v %v", ack, err)
+	}
+
+	res, err := s.Generate(ctx, &proto.GenerateRequest{Count: 3, Address: miner.GetAddress()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Height != 3 || len(res.BlockHashes) != 3 || !bytes.Equal(res.BlockHashes[2], s.Blockchain.LastHash) {
+		t.Fatalf("Generate 3 block: height %d, %d hash", res.Height, len(res.BlockHashes))
+	}
+
+	first, err := s.Blockchain.GetBlock(res.BlockHashes[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Transactions) != 2 || !bytes.Equal(first.Transactions[1].ID, tx.ID) {
+		t.Fatalf("block đầu tiên phải chứa giao dịch trong mempool, có %d giao dịch", len(first.Transactions))
+	}
+	if entries, err := s.Mempool.Entries(ctx); err != nil || len(entries) != 0 {
+		t.Fatalf("mempool phải trống sau khi generate: %d mục (%v)", len(entries), err)
+	}
+	for _, hash := range res.BlockHashes[1:] {
+		block, err := s.Blockchain.GetBlock(hash)
+		if err != nil || len(block.Transactions) != 1 {
+			t.Fatalf("block rỗng phải chỉ có coinbase: %v", err)
+		}
+	}
+
+	balance, err := s.GetBalance(ctx, &proto.GetBalanceRequest{Address: miner.GetAddress()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := s.Blockchain.Emission.SupplyAt(3) - s.Blockchain.Emission.SupplyAt(0) + 10; balance.Confirmed != want {
+		t.Fatalf("miner nhận %d, muốn phần thưởng 3 block cộng phí 10 = %d", balance.Confirmed, want)
+	}
+}
+
+func TestGenerateRejectsBadRequests(t *testing.T) {
+	ctx := context.Background()
+	s, alice := newTestServer(t)
+
+	for _, count := range []int32{0, -1, MaxGenerateBlocks + 1} {
+		if _, err := s.Generate(ctx, &proto.GenerateRequest{Count: count, Address: alice.GetAddress()}); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("count %d: lỗi %v, muốn ErrInvalidArgument", count, err)
+		}
+	}
+	if _, err := s.Generate(ctx, &proto.GenerateRequest{Count: 1, Address: "sai"}); !errors.Is(err, domain.ErrInvalidAddress) {
+		t.Errorf("địa chỉ sai: lỗi %v, muốn ErrInvalidAddress", err)
+	}
+
+	domain.SelectParams(&domain.TestNetParams)
+	t.Cleanup(func() { domain.SelectParams(&domain.RegTestParams) })
+	if _, err := s.Generate(ctx, &proto.GenerateRequest{Count: 1, Address: alice.GetAddress()}); !errors.Is(err, ErrUnsupportedNetwork) {
+		t.Fatalf("generate ngoài regtest: lỗi %v, muốn ErrUnsupportedNetwork", err)
+	}
+	if s.Blockchain.GetBestHeight() != 0 {
+		t.Fatal("yêu cầu bị từ chối không được đào block nào")
+	}
+}
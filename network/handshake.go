@@ -0,0 +1,74 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+const networkMagicHeader = "gochain-network-magic"
+
+func networkMagic() string {
+	return fmt.Sprintf("%08x", domain.ActiveParams().Magic)
+}
+
+func Dial(target string) (*grpc.ClientConn, error) {
+	return grpc.Dial(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(withNetworkMagic(ctx), method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(withNetworkMagic(ctx), desc, cc, method, opts...)
+		}),
+	)
+}
+
+func withNetworkMagic(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, networkMagicHeader, networkMagic())
+}
+
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(recoverUnary, func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := checkNetworkMagic(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(recoverStream, func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := checkNetworkMagic(ss.Context(), info.FullMethod); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	}
+}
+
+// PublicService phục vụ cả DApp qua gRPC-Web (không gửi được header magic), nên chỉ
+// NodeService bắt buộc có magic; magic sai thì luôn bị từ chối.
+func checkNetworkMagic(ctx context.Context, method string) error {
+	var values []string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		values = md.Get(networkMagicHeader)
+	}
+	if len(values) == 0 {
+		if strings.HasPrefix(method, "/"+proto.NodeService_ServiceDesc.ServiceName+"/") {
+			return status.Errorf(codes.FailedPrecondition, "thiếu header %s: node chạy %s (magic %s) chỉ nhận RPC P2P kèm magic của mạng", networkMagicHeader, domain.ActiveParams().Name, networkMagic())
+		}
+		return nil
+	}
+	if values[0] != networkMagic() {
+		return status.Errorf(codes.FailedPrecondition, "sai mạng: peer dùng magic %s, node chạy %s (magic %s)", values[0], domain.ActiveParams().Name, networkMagic())
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package network
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+func startTestGRPC(t *testing.T) string {
+	t.Helper()
+	s, _ := newTestServer(t)
+	return serveTestGRPC(t, s)
+}
+
+func serveTestGRPC(t *testing.T, s *Server) string {
+	t.Helper()
+	server := grpc.NewServer(ServerOptions()...)
+	proto.RegisterNodeServiceServer(server, s)
+	proto.RegisterPublicServiceServer(server, &PublicServer{Blockchain: s.Blockchain, Mempool: s.Mempool})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func dialWithoutMagic(t *testing.T, addr string) *grpc.ClientConn {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestNodeServiceRequiresNetworkMagic(t *testing.T) {
+	addr := startTestGRPC(t)
+	conn := dialWithoutMagic(t, addr)
+	ctx := context.Background()
+
+	_, err := proto.NewNodeServiceClient(conn).GetSupply(ctx, &proto.EmptyRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("RPC NodeService thiếu magic phải bị từ chối với FailedPrecondition, nhận %v", err)
+	}
+
+	stream, err := proto.NewNodeServiceClient(conn).GetBlocks(ctx, &proto.GetBlocksRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("stream NodeService thiếu magic phải bị từ chối, nhận %v", err)
+	}
+
+	if _, err := proto.NewPublicServiceClient(conn).GetChainInfo(ctx, &proto.EmptyRequest{}); err != nil {
+		t.Fatalf("PublicService phải nhận request không có magic (gRPC-Web): %v", err)
+	}
+}
+
+func TestNetworkMagicMismatchRejected(t *testing.T) {
+	addr := startTestGRPC(t)
+	conn := dialWithoutMagic(t, addr)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), networkMagicHeader, "47434d4e")
+
+	if _, err := proto.NewNodeServiceClient(conn).GetSupply(ctx, &proto.EmptyRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("magic mainnet gửi tới node regtest phải bị từ chối, nhận %v", err)
+	}
+	if _, err := proto.NewPublicServiceClient(conn).GetChainInfo(ctx, &proto.EmptyRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("PublicService cũng phải từ chối magic sai, nhận %v", err)
+	}
+}
+
+func TestDialSendsNetworkMagic(t *testing.T) {
+	addr := startTestGRPC(t)
+	conn, err := Dial(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	res, err := proto.NewNodeServiceClient(conn).GetSupply(context.Background(), &proto.EmptyRequest{})
+	if err != nil {
+		t.Fatalf("client của node phải gửi magic và được chấp nhận: %v", err)
+	}
+	if res.MaxSupply != testutil.Emission.MaxSupply {
+		t.Fatalf("max supply %d, cần %d", res.MaxSupply, testutil.Emission.MaxSupply)
+	}
+
+	peers := NewPeerSet([]string{addr})
+	defer peers.Close()
+	client, err := peers.client(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetSupply(context.Background(), &proto.EmptyRequest{}); err != nil {
+		t.Fatalf("PeerSet phải gửi magic: %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+)
+
+func newTestServer(t *testing.T) (*Server, *domain.Wallet) {
+	t.Helper()
+	bc, w := testutil.NewChain(t, nil)
+	return &Server{Blockchain: bc, Mempool: NewMemoryMempool()}, w
+}
+
+func buildBlock(t *testing.T, bc *domain.Blockchain, to *domain.Wallet, timestamp int64, txs ...*domain.Transaction) *domain.Block {
+	t.Helper()
+	block := &domain.Block{
+		Timestamp:     timestamp,
+		PrevBlockHash: bc.LastHash,
+		Transactions:  append([]*domain.Transaction{testutil.Coinbase(t, bc, to, txs...)}, txs...),
+		Height:        bc.GetBestHeight() + 1,
+	}
+	block.Nonce, block.Hash = domain.NewProofOfWork(block).Run()
+	return block
+}
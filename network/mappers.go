@@ -13,6 +13,8 @@ func MapDomainTransactionToProto(tx *domain.Transaction) *proto.Transaction {
 			VoutIndex: int32(in.VoutIndex),
 			Signature: in.Signature,
 			PublicKey: in.PublicKey,
+			Witness:   in.Witness,
+			Sequence:  in.Sequence,
 		}
 	}
 
@@ -21,15 +23,17 @@ func MapDomainTransactionToProto(tx *domain.Transaction) *proto.Transaction {
 		vout[i] = &proto.TxOutput{
 			Value:      out.Value,
 			PubKeyHash: out.PubKeyHash,
+			Script:     out.Script,
 		}
 	}
 
 	return &proto.Transaction{
-		Id:      tx.ID,
-		Vin:     vin,
-		Vout:    vout,
-		Type:    int32(tx.Type),
-		Payload: tx.Payload,
+		Id:       tx.ID,
+		Vin:      vin,
+		Vout:     vout,
+		Type:     int32(tx.Type),
+		Payload:  tx.Payload,
+		LockTime: tx.LockTime,
 	}
 }
 
@@ -45,6 +49,7 @@ func MapDomainBlockToProto(b *domain.Block) *proto.Block {
 		Hash:          b.Hash,
 		Transactions:  txs,
 		Nonce:         b.Nonce,
+		Height:        b.Height,
 	}
 }
 
@@ -56,6 +61,8 @@ func MapProtoTransactionToDomain(tx *proto.Transaction) *domain.Transaction {
 			VoutIndex: int(in.VoutIndex),
 			Signature: in.Signature,
 			PublicKey: in.PublicKey,
+			Witness:   in.Witness,
+			Sequence:  in.Sequence,
 		}
 	}
 
@@ -64,15 +71,17 @@ func MapProtoTransactionToDomain(tx *proto.Transaction) *domain.Transaction {
 		vout[i] = domain.TxOutput{
 			Value:      out.Value,
 			PubKeyHash: out.PubKeyHash,
+			Script:     out.Script,
 		}
 	}
 
 	return &domain.Transaction{
-		ID:      tx.Id,
-		Vin:     vin,
-		Vout:    vout,
-		Type:    domain.TxType(tx.Type),
-		Payload: tx.Payload,
+		ID:       tx.Id,
+		Vin:      vin,
+		Vout:     vout,
+		Type:     domain.TxType(tx.Type),
+		Payload:  tx.Payload,
+		LockTime: tx.LockTime,
 	}
 }
 
@@ -88,5 +97,6 @@ func MapProtoBlockToDomain(b *proto.Block) *domain.Block {
 		Hash:          b.Hash,
 		Transactions:  txs,
 		Nonce:         b.Nonce,
+		Height:        b.Height,
 	}
 }
@@ -0,0 +1,380 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/khoahotran/gochain-ledger/domain"
+)
+
+const (
+	DefaultDustThreshold int64 = 1
+)
+
+var (
+	ErrMempoolConflict  = errors.New("xung đột với giao dịch trong mempool")
+	ErrMempoolDuplicate = errors.New("giao dịch đã có trong mempool")
+)
+
+type MempoolEntry struct {
+	Tx      *domain.Transaction
+	Fee     int64
+	AddedAt int64
+}
+
+type Mempool struct {
+	DustThreshold int64
+	Events        *domain.EventBus
+
+	store mempoolStore
+	mu    sync.Mutex
+}
+
+type mempoolStore interface {
+	getTx(ctx context.Context, txKey string) ([]byte, error)
+	allTxs(ctx context.Context) (map[string][]byte, error)
+	getSpend(ctx context.Context, outpoint string) (string, error)
+	spendKeys(ctx context.Context) ([]string, error)
+	commit(ctx context.Context, remove []*MempoolEntry, add *MempoolEntry) error
+}
+
+func NewMempool(client *redis.Client) *Mempool {
+	mp := &Mempool{DustThreshold: DefaultDustThreshold, store: &redisMempoolStore{client: client}}
+	mp.migrateLegacy(context.Background(), client)
+	return mp
+}
+
+func NewMemoryMempool() *Mempool {
+	return &Mempool{DustThreshold: DefaultDustThreshold, store: newMemoryMempoolStore()}
+}
+
+func (mp *Mempool) Close() error {
+	if closer, ok := mp.store.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (e *MempoolEntry) serialize() []byte {
+	data := make([]byte, 16)
+	binary.BigEndian.PutUint64(data[:8], uint64(e.Fee))
+	binary.BigEndian.PutUint64(data[8:], uint64(e.AddedAt))
+	return append(data, e.Tx.Serialize()...)
+}
+
+func deserializeMempoolEntry(data []byte) (*MempoolEntry, error) {
+	if len(data) < 16 {
+		return nil, errors.New("dữ liệu mempool không hợp lệ")
+	}
+	tx, err := domain.DeserializeTransaction(data[16:])
+	if err != nil {
+		return nil, err
+	}
+	return &MempoolEntry{
+		Tx:      tx,
+		Fee:     int64(binary.BigEndian.Uint64(data[:8])),
+		AddedAt: int64(binary.BigEndian.Uint64(data[8:16])),
+	}, nil
+}
+
+func (mp *Mempool) Add(ctx context.Context, tx *domain.Transaction, fee int64) ([][]byte, error) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	txKey := hex.EncodeToString(tx.ID)
+	existing, err := mp.store.getTx(ctx, txKey)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %x", ErrMempoolDuplicate, tx.ID)
+	}
+
+	conflicts, err := mp.conflicts(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var replaced []*MempoolEntry
+	if len(conflicts) > 0 {
+		replaced, err = mp.withDescendants(ctx, conflicts)
+		if err != nil {
+			return nil, err
+		}
+		var conflictFee int64
+		for _, entry := range replaced {
+			for _, vin := range tx.Vin {
+				if bytes.Equal(vin.TxID, entry.Tx.ID) {
+					return nil, fmt.Errorf("%w: giao dịch thay thế tiêu output của %x", ErrMempoolConflict, entry.Tx.ID)
+				}
+			}
+			conflictFee += entry.Fee
+		}
+		if fee <= conflictFee {
+			return nil, fmt.Errorf("%w: phí %d phải lớn hơn %d của giao dịch bị thay thế", ErrMempoolConflict, fee, conflictFee)
+		}
+	}
+
+	entry := &MempoolEntry{Tx: tx, Fee: fee, AddedAt: time.Now().Unix()}
+	if err := mp.store.commit(ctx, replaced, entry); err != nil {
+		return nil, err
+	}
+
+	var replacedIDs [][]byte
+	for _, old := range replaced {
+		log.Printf("Mempool: TX %x bị thay thế bởi %x (RBF)", old.Tx.ID, tx.ID)
+		replacedIDs = append(replacedIDs, old.Tx.ID)
+		mp.Events.Publish(domain.ChainEvent{Kind: domain.EventTxReplaced, Tx: old.Tx, Fee: old.Fee, ReplacedBy: tx.ID})
+	}
+	mp.Events.Publish(domain.ChainEvent{Kind: domain.EventTxAccepted, Tx: tx, Fee: fee})
+	return replacedIDs, nil
+}
+
+func (mp *Mempool) Get(ctx context.Context, txID []byte) (*MempoolEntry, error) {
+	return mp.get(ctx, hex.EncodeToString(txID))
+}
+
+func (mp *Mempool) get(ctx context.Context, txKey string) (*MempoolEntry, error) {
+	data, err := mp.store.getTx(ctx, txKey)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	return deserializeMempoolEntry(data)
+}
+
+func (mp *Mempool) Entries(ctx context.Context) ([]*MempoolEntry, error) {
+	values, err := mp.store.allTxs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*MempoolEntry, 0, len(values))
+	for txKey, data := range values {
+		entry, err := deserializeMempoolEntry(data)
+		if err != nil {
+			log.Printf("Mempool: Bỏ qua TX %s không giải mã được: %v", txKey, err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Fee != entries[j].Fee {
+			return entries[i].Fee > entries[j].Fee
+		}
+		return entries[i].AddedAt < entries[j].AddedAt
+	})
+	return parentsFirst(entries), nil
+}
+
+func parentsFirst(entries []*MempoolEntry) []*MempoolEntry {
+	byID := make(map[string]*MempoolEntry, len(entries))
+	for _, entry := range entries {
+		byID[string(entry.Tx.ID)] = entry
+	}
+
+	ordered := make([]*MempoolEntry, 0, len(entries))
+	visited := make(map[string]bool, len(entries))
+	var visit func(entry *MempoolEntry)
+	visit = func(entry *MempoolEntry) {
+		if visited[string(entry.Tx.ID)] {
+			return
+		}
+		visited[string(entry.Tx.ID)] = true
+		for _, vin := range entry.Tx.Vin {
+			if parent, ok := byID[string(vin.TxID)]; ok {
+				visit(parent)
+			}
+		}
+		ordered = append(ordered, entry)
+	}
+	for _, entry := range entries {
+		visit(entry)
+	}
+	return ordered
+}
+
+func (mp *Mempool) Parents(ctx context.Context, tx *domain.Transaction) (map[string]*domain.Transaction, error) {
+	parents := make(map[string]*domain.Transaction)
+	for _, vin := range tx.Vin {
+		if _, ok := parents[string(vin.TxID)]; ok {
+			continue
+		}
+		entry, err := mp.Get(ctx, vin.TxID)
+		if err != nil {
+			return nil, err
+		}
+		if entry != nil {
+			parents[string(vin.TxID)] = entry.Tx
+		}
+	}
+	return parents, nil
+}
+
+func (mp *Mempool) SpentOutpoints(ctx context.Context) (map[string]bool, error) {
+	keys, err := mp.store.spendKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	spent := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		spent[key] = true
+	}
+	return spent, nil
+}
+
+func (mp *Mempool) UnspentOutputs(ctx context.Context, pubKeyHash []byte) ([]domain.SpendableUTXOData, error) {
+	entries, err := mp.Entries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	spent, err := mp.SpentOutpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var utxos []domain.SpendableUTXOData
+	for _, entry := range entries {
+		for outIdx, out := range entry.Tx.Vout {
+			if !out.IsLockedWithKey(pubKeyHash) || spent[domain.OutpointKey(entry.Tx.ID, outIdx)] {
+				continue
+			}
+			utxos = append(utxos, domain.SpendableUTXOData{
+				TxID:        entry.Tx.ID,
+				VoutIndex:   outIdx,
+				Amount:      out.Value,
+				PubKeyHash:  out.PubKeyHash,
+				Unconfirmed: true,
+			})
+		}
+	}
+	return utxos, nil
+}
+
+func (mp *Mempool) Remove(ctx context.Context, txIDs ...[]byte) error {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	var entries []*MempoolEntry
+	for _, txID := range txIDs {
+		entry, err := mp.Get(ctx, txID)
+		if err != nil {
+			return err
+		}
+		if entry != nil {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return mp.store.commit(ctx, entries, nil)
+}
+
+func (mp *Mempool) RemoveBlock(ctx context.Context, block *domain.Block) error {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	var entries []*MempoolEntry
+	var conflicting []string
+	for _, tx := range block.Transactions {
+		entry, err := mp.Get(ctx, tx.ID)
+		if err != nil {
+			return err
+		}
+		if entry != nil {
+			entries = append(entries, entry)
+			continue
+		}
+		if tx.IsCoinbase() {
+			continue
+		}
+		conflicts, err := mp.conflicts(ctx, tx)
+		if err != nil {
+			return err
+		}
+		conflicting = append(conflicting, conflicts...)
+	}
+
+	evicted, err := mp.withDescendants(ctx, conflicting)
+	if err != nil {
+		return err
+	}
+	for _, entry := range evicted {
+		log.Printf("Mempool: Loại TX %x vì xung đột với block %x", entry.Tx.ID, block.Hash)
+	}
+	entries = append(entries, evicted...)
+	if len(entries) == 0 {
+		return nil
+	}
+	return mp.store.commit(ctx, entries, nil)
+}
+
+func (mp *Mempool) SpentBy(ctx context.Context, txID []byte, voutIndex int) ([]byte, error) {
+	txKey, err := mp.store.getSpend(ctx, domain.OutpointKey(txID, voutIndex))
+	if err != nil || txKey == "" {
+		return nil, err
+	}
+	return hex.DecodeString(txKey)
+}
+
+func (mp *Mempool) conflicts(ctx context.Context, tx *domain.Transaction) ([]string, error) {
+	seen := make(map[string]bool)
+	var conflicts []string
+	for _, vin := range tx.Vin {
+		txKey, err := mp.store.getSpend(ctx, domain.OutpointKey(vin.TxID, vin.VoutIndex))
+		if err != nil {
+			return nil, err
+		}
+		if txKey != "" && !seen[txKey] {
+			seen[txKey] = true
+			conflicts = append(conflicts, txKey)
+		}
+	}
+	return conflicts, nil
+}
+
+func (mp *Mempool) withDescendants(ctx context.Context, txKeys []string) ([]*MempoolEntry, error) {
+	var entries []*MempoolEntry
+	seen := make(map[string]bool)
+	queue := append([]string{}, txKeys...)
+	for len(queue) > 0 {
+		txKey := queue[0]
+		queue = queue[1:]
+		if seen[txKey] {
+			continue
+		}
+		seen[txKey] = true
+
+		entry, err := mp.get(ctx, txKey)
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil {
+			continue
+		}
+		entries = append(entries, entry)
+
+		for outIdx := range entry.Tx.Vout {
+			child, err := mp.store.getSpend(ctx, domain.OutpointKey(entry.Tx.ID, outIdx))
+			if err != nil {
+				return nil, err
+			}
+			if child != "" {
+				queue = append(queue, child)
+			}
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,210 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+const (
+	mempoolRecordAdd    byte = 0x01
+	mempoolRecordRemove byte = 0x02
+
+	mempoolCompactMinRecords = 1000
+	maxMempoolRecord         = 4 << 20
+)
+
+type fileMempoolStore struct {
+	*memoryMempoolStore
+
+	path    string
+	file    *os.File
+	records int
+}
+
+func NewFileMempool(path string) (*Mempool, error) {
+	store, err := openFileMempoolStore(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Mempool{DustThreshold: DefaultDustThreshold, store: store}, nil
+}
+
+func openFileMempoolStore(path string) (*fileMempoolStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	s := &fileMempoolStore{memoryMempoolStore: newMemoryMempoolStore(), path: path}
+	if err := s.replay(); err != nil {
+		return nil, fmt.Errorf("không thể đọc mempool %s: %v", path, err)
+	}
+	if err := s.compact(); err != nil {
+		return nil, fmt.Errorf("không thể ghi mempool %s: %v", path, err)
+	}
+	log.Printf("Mempool: Đã nạp %d TX từ %s", len(s.txs), path)
+	return s, nil
+}
+
+func (s *fileMempoolStore) replay() error {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		op, payload, err := readMempoolRecord(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			log.Printf("Mempool: Bỏ phần cuối hỏng của %s: %v", s.path, err)
+			return nil
+		}
+
+		switch op {
+		case mempoolRecordAdd:
+			entry, err := deserializeMempoolEntry(payload)
+			if err != nil {
+				log.Printf("Mempool: Bỏ qua bản ghi không giải mã được: %v", err)
+				continue
+			}
+			s.apply(nil, entry)
+		case mempoolRecordRemove:
+			data, ok := s.txs[hex.EncodeToString(payload)]
+			if !ok {
+				continue
+			}
+			entry, err := deserializeMempoolEntry(data)
+			if err != nil {
+				delete(s.txs, hex.EncodeToString(payload))
+				continue
+			}
+			s.apply([]*MempoolEntry{entry}, nil)
+		default:
+			log.Printf("Mempool: Bỏ phần cuối hỏng của %s: loại bản ghi không hợp lệ %d", s.path, op)
+			return nil
+		}
+	}
+}
+
+func (s *fileMempoolStore) compact() error {
+	tmpPath := s.path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	for _, data := range s.txs {
+		if err := writeMempoolRecord(w, mempoolRecordAdd, data); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return err
+	}
+
+	if s.file != nil {
+		s.file.Close()
+	}
+	s.file, err = os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0644)
+	s.records = len(s.txs)
+	return err
+}
+
+func (s *fileMempoolStore) commit(ctx context.Context, remove []*MempoolEntry, add *MempoolEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var buf []byte
+	for _, entry := range remove {
+		buf = appendMempoolRecord(buf, mempoolRecordRemove, entry.Tx.ID)
+	}
+	if add != nil {
+		buf = appendMempoolRecord(buf, mempoolRecordAdd, add.serialize())
+	}
+	if _, err := s.file.Write(buf); err != nil {
+		return err
+	}
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+
+	s.apply(remove, add)
+	s.records += len(remove)
+	if add != nil {
+		s.records++
+	}
+
+	if s.records >= mempoolCompactMinRecords && s.records > 2*len(s.txs) {
+		if err := s.compact(); err != nil {
+			log.Printf("Mempool: Không thể thu gọn %s: %v", s.path, err)
+		}
+	}
+	return nil
+}
+
+func (s *fileMempoolStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func appendMempoolRecord(buf []byte, op byte, payload []byte) []byte {
+	buf = append(buf, op)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(payload)))
+	return append(buf, payload...)
+}
+
+func writeMempoolRecord(w io.Writer, op byte, payload []byte) error {
+	_, err := w.Write(appendMempoolRecord(nil, op, payload))
+	return err
+}
+
+func readMempoolRecord(r io.Reader) (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:1]); err != nil {
+		return 0, nil, err
+	}
+	if _, err := io.ReadFull(r, header[1:]); err != nil {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+	n := binary.BigEndian.Uint32(header[1:])
+	if n > maxMempoolRecord {
+		return 0, nil, fmt.Errorf("bản ghi dài %d byte, vượt giới hạn %d", n, maxMempoolRecord)
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+	return header[0], payload, nil
+}
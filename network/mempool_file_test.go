@@ -0,0 +1,126 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+)
+
+func reopenFileMempool(t *testing.T, path string) *Mempool {
+	t.Helper()
+	mp, err := NewFileMempool(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { mp.Close() })
+	return mp
+}
+
+func TestFileMempoolSurvivesReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "mempool", "mempool.dat")
+	funding := []byte("funding")
+
+	mp, err := NewFileMempool(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept := mempoolTx(1, outpoint(funding, 0))
+	removed := mempoolTx(2, outpoint(funding, 1))
+	replaced := mempoolTx(3, outpoint(funding, 2))
+	for _, add := range []struct {
+		tx  *domain.Transaction
+		fee int64
+	}{{kept, 7}, {removed, 5}, {replaced, 5}} {
+		if _, err := mp.Add(ctx, add.tx, add.fee); err != nil {
+			t.Fatal(err)
+		}
+	}
+	replacement := mempoolTx(4, outpoint(funding, 2))
+	if _, err := mp.Add(ctx, replacement, 20); err != nil {
+		t.Fatal(err)
+	}
+	if err := mp.Remove(ctx, removed.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := mp.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mp = reopenFileMempool(t, path)
+	ids := mempoolIDs(t, mp)
+	if len(ids) != 2 || !ids[string(kept.ID)] || !ids[string(replacement.ID)] {
+		t.Fatalf("sau khi mở lại mempool còn %d TX, muốn đúng kept và replacement", len(ids))
+	}
+	entry, err := mp.Get(ctx, kept.ID)
+	if err != nil || entry == nil || entry.Fee != 7 {
+		t.Fatalf("phí của TX phải được giữ nguyên: %+v %v", entry, err)
+	}
+	if spender, err := mp.SpentBy(ctx, funding, 2); err != nil || !bytes.Equal(spender, replacement.ID) {
+		t.Fatalf("chỉ mục outpoint phải được dựng lại: %x %v", spender, err)
+	}
+	if spender, _ := mp.SpentBy(ctx, funding, 1); spender != nil {
+		t.Fatal("outpoint của TX đã xóa vẫn còn sau khi mở lại")
+	}
+}
+
+func TestFileMempoolDropsCorruptTail(t *testing.T) {
+	tails := map[string][]byte{
+		// node chết khi đang ghi: bản ghi khai báo 100 byte nhưng chỉ có 3
+		"bản ghi bị cắt": {mempoolRecordAdd, 0, 0, 0, 100, 1, 2, 3},
+		// header hỏng khai báo gần 4 GiB, không được cấp phát theo
+		"độ dài quá lớn":  {mempoolRecordAdd, 0xff, 0xff, 0xff, 0xf0},
+		"loại bản ghi lạ": {0x7f, 0, 0, 0, 1, 0},
+	}
+	for name, tail := range tails {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "mempool.dat")
+
+			mp, err := NewFileMempool(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			first := mempoolTx(1, outpoint([]byte("a"), 0))
+			if _, err := mp.Add(ctx, first, 1); err != nil {
+				t.Fatal(err)
+			}
+			if err := mp.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := f.Write(tail); err != nil {
+				t.Fatal(err)
+			}
+			f.Close()
+
+			mp, err = NewFileMempool(path)
+			if err != nil {
+				t.Fatalf("phần cuối hỏng không được làm hỏng cả mempool: %v", err)
+			}
+			if ids := mempoolIDs(t, mp); len(ids) != 1 || !ids[string(first.ID)] {
+				t.Fatalf("TX đã ghi trọn vẹn phải còn sau khi mở lại, có %d TX", len(ids))
+			}
+			second := mempoolTx(2, outpoint([]byte("b"), 0))
+			if _, err := mp.Add(ctx, second, 1); err != nil {
+				t.Fatal(err)
+			}
+			if err := mp.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			mp = reopenFileMempool(t, path)
+			if ids := mempoolIDs(t, mp); len(ids) != 2 || !ids[string(second.ID)] {
+				t.Fatalf("TX thêm sau khi phục hồi phải được đọc lại, có %d TX", len(ids))
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package network
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+)
+
+type memoryMempoolStore struct {
+	mu     sync.RWMutex
+	txs    map[string][]byte
+	spends map[string]string
+}
+
+func newMemoryMempoolStore() *memoryMempoolStore {
+	return &memoryMempoolStore{
+		txs:    make(map[string][]byte),
+		spends: make(map[string]string),
+	}
+}
+
+func (s *memoryMempoolStore) getTx(ctx context.Context, txKey string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.txs[txKey], nil
+}
+
+func (s *memoryMempoolStore) allTxs(ctx context.Context) (map[string][]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	txs := make(map[string][]byte, len(s.txs))
+	for txKey, data := range s.txs {
+		txs[txKey] = data
+	}
+	return txs, nil
+}
+
+func (s *memoryMempoolStore) getSpend(ctx context.Context, outpoint string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.spends[outpoint], nil
+}
+
+func (s *memoryMempoolStore) spendKeys(ctx context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]string, 0, len(s.spends))
+	for key := range s.spends {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s *memoryMempoolStore) commit(ctx context.Context, remove []*MempoolEntry, add *MempoolEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apply(remove, add)
+	return nil
+}
+
+func (s *memoryMempoolStore) apply(remove []*MempoolEntry, add *MempoolEntry) {
+	for _, entry := range remove {
+		delete(s.txs, hex.EncodeToString(entry.Tx.ID))
+		for _, vin := range entry.Tx.Vin {
+			delete(s.spends, domain.OutpointKey(vin.TxID, vin.VoutIndex))
+		}
+	}
+	if add != nil {
+		txKey := hex.EncodeToString(add.Tx.ID)
+		s.txs[txKey] = add.serialize()
+		for _, vin := range add.Tx.Vin {
+			s.spends[domain.OutpointKey(vin.TxID, vin.VoutIndex)] = txKey
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package network
+
+import (
+	"context"
+	"encoding/hex"
+	"log"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/khoahotran/gochain-ledger/domain"
+)
+
+const (
+	mempoolKey       = "gochain:mempool"
+	mempoolTxsKey    = "gochain:mempool:txs"
+	mempoolSpendsKey = "gochain:mempool:spends"
+)
+
+type redisMempoolStore struct {
+	client *redis.Client
+}
+
+func (s *redisMempoolStore) getTx(ctx context.Context, txKey string) ([]byte, error) {
+	data, err := s.client.HGet(ctx, mempoolTxsKey, txKey).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	return data, err
+}
+
+func (s *redisMempoolStore) allTxs(ctx context.Context) (map[string][]byte, error) {
+	values, err := s.client.HGetAll(ctx, mempoolTxsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	txs := make(map[string][]byte, len(values))
+	for txKey, data := range values {
+		txs[txKey] = []byte(data)
+	}
+	return txs, nil
+}
+
+func (s *redisMempoolStore) getSpend(ctx context.Context, outpoint string) (string, error) {
+	txKey, err := s.client.HGet(ctx, mempoolSpendsKey, outpoint).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return txKey, err
+}
+
+func (s *redisMempoolStore) spendKeys(ctx context.Context) ([]string, error) {
+	return s.client.HKeys(ctx, mempoolSpendsKey).Result()
+}
+
+func (s *redisMempoolStore) commit(ctx context.Context, remove []*MempoolEntry, add *MempoolEntry) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, entry := range remove {
+			pipe.HDel(ctx, mempoolTxsKey, hex.EncodeToString(entry.Tx.ID))
+			for _, vin := range entry.Tx.Vin {
+				pipe.HDel(ctx, mempoolSpendsKey, domain.OutpointKey(vin.TxID, vin.VoutIndex))
+			}
+		}
+		if add != nil {
+			txKey := hex.EncodeToString(add.Tx.ID)
+			pipe.HSet(ctx, mempoolTxsKey, txKey, add.serialize())
+			for _, vin := range add.Tx.Vin {
+				pipe.HSet(ctx, mempoolSpendsKey, domain.OutpointKey(vin.TxID, vin.VoutIndex), txKey)
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+func (mp *Mempool) migrateLegacy(ctx context.Context, client *redis.Client) {
+	legacy, err := client.SMembers(ctx, mempoolKey).Result()
+	if err != nil || len(legacy) == 0 {
+		return
+	}
+
+	log.Printf("Mempool: Chuyển %d TX từ định dạng cũ...", len(legacy))
+	for _, data := range legacy {
+		tx, err := domain.DeserializeTransaction([]byte(data))
+		if err != nil {
+			continue
+		}
+		if _, err := mp.Add(ctx, tx, 0); err != nil {
+			log.Printf("Mempool: Bỏ TX %x: %v", tx.ID, err)
+		}
+	}
+	client.Del(ctx, mempoolKey)
+}
@@ -0,0 +1,205 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+)
+
+// mempoolTx tạo giao dịch (không ký) tiêu các outpoint cho trước; value giúp các giao dịch khác ID.
+func mempoolTx(value int64, inputs ...domain.TxInput) *domain.Transaction {
+	tx := &domain.Transaction{
+		Vin:  inputs,
+		Vout: []domain.TxOutput{{Value: value, PubKeyHash: bytes.Repeat([]byte{0x01}, 32)}},
+	}
+	tx.SetID()
+	return tx
+}
+
+func outpoint(txID []byte, vout int) domain.TxInput {
+	return domain.TxInput{TxID: txID, VoutIndex: vout, Sequence: domain.SequenceFinal}
+}
+
+func mempoolIDs(t *testing.T, mp *Mempool) map[string]bool {
+	t.Helper()
+	entries, err := mp.Entries(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		ids[string(entry.Tx.ID)] = true
+	}
+	return ids
+}
+
+func TestMempoolRejectsDuplicate(t *testing.T) {
+	ctx := context.Background()
+	mp := NewMemoryMempool()
+	tx := mempoolTx(1, outpoint([]byte("a"), 0))
+	if _, err := mp.Add(ctx, tx, 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mp.Add(ctx, tx, 20); !errors.Is(err, ErrMempoolDuplicate) {
+		t.Fatalf("thêm lại cùng TX: lỗi %v, muốn ErrMempoolDuplicate", err)
+	}
+}
+
+func TestMempoolReplaceByFee(t *testing.T) {
+	ctx := context.Background()
+	mp := NewMemoryMempool()
+	funding := []byte("funding")
+
+	original := mempoolTx(1, outpoint(funding, 0))
+	child := mempoolTx(2, outpoint(original.ID, 0))
+	unrelated := mempoolTx(3, outpoint(funding, 1))
+	for _, add := range []struct {
+		tx  *domain.Transaction
+		fee int64
+	}{{original, 10}, {child, 10}, {unrelated, 5}} {
+		if _, err := mp.Add(ctx, add.tx, add.fee); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Phí phải lớn hơn tổng phí của TX bị thay thế và mọi TX con của nó (10 + 10).
+	for _, fee := range []int64{10, 20} {
+		low := mempoolTx(4+fee, outpoint(funding, 0))
+		if _, err := mp.Add(ctx, low, fee); !errors.Is(err, ErrMempoolConflict) {
+			t.Fatalf("thay thế với phí %d: lỗi %v, muốn ErrMempoolConflict", fee, err)
+		}
+	}
+
+	selfSpend := mempoolTx(5, outpoint(funding, 0), outpoint(original.ID, 1))
+	if _, err := mp.Add(ctx, selfSpend, 100); !errors.Is(err, ErrMempoolConflict) {
+		t.Fatalf("TX thay thế tiêu output của TX bị thay thế: lỗi %v, muốn ErrMempoolConflict", err)
+	}
+
+	replacement := mempoolTx(6, outpoint(funding, 0))
+	replaced, err := mp.Add(ctx, replacement, 21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replaced) != 2 {
+		t.Fatalf("phải thay thế TX gốc và TX con, nhận %d", len(replaced))
+	}
+
+	ids := mempoolIDs(t, mp)
+	if ids[string(original.ID)] || ids[string(child.ID)] || !ids[string(replacement.ID)] || !ids[string(unrelated.ID)] || len(ids) != 2 {
+		t.Fatalf("mempool sau RBF sai: %d TX", len(ids))
+	}
+	if spender, err := mp.SpentBy(ctx, funding, 0); err != nil || !bytes.Equal(spender, replacement.ID) {
+		t.Fatalf("outpoint phải thuộc TX thay thế, nhận %x (%v)", spender, err)
+	}
+	if spender, _ := mp.SpentBy(ctx, original.ID, 0); spender != nil {
+		t.Fatal("outpoint của TX con bị loại vẫn còn trong mempool")
+	}
+}
+
+func TestMempoolRemoveBlockEvictsConflicts(t *testing.T) {
+	ctx := context.Background()
+	mp := NewMemoryMempool()
+	funding := []byte("funding")
+
+	included := mempoolTx(1, outpoint(funding, 0))
+	loser := mempoolTx(2, outpoint(funding, 1))
+	loserChild := mempoolTx(3, outpoint(loser.ID, 0))
+	other := mempoolTx(4, outpoint(funding, 2))
+	for _, tx := range []*domain.Transaction{included, loser, loserChild, other} {
+		if _, err := mp.Add(ctx, tx, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Block chứa TX đã có trong mempool và một TX khác tiêu cùng outpoint với loser.
+	winner := mempoolTx(5, outpoint(funding, 1))
+	block := &domain.Block{Transactions: []*domain.Transaction{included, winner}}
+	if err := mp.RemoveBlock(ctx, block); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := mempoolIDs(t, mp)
+	if len(ids) != 1 || !ids[string(other.ID)] {
+		t.Fatalf("sau block chỉ TX không liên quan được ở lại, còn %d TX", len(ids))
+	}
+}
+
+func TestMempoolEntriesParentsFirst(t *testing.T) {
+	ctx := context.Background()
+	mp := NewMemoryMempool()
+	parent := mempoolTx(1, outpoint([]byte("a"), 0))
+	child := mempoolTx(2, outpoint(parent.ID, 0))
+	rich := mempoolTx(3, outpoint([]byte("b"), 0))
+	if _, err := mp.Add(ctx, parent, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mp.Add(ctx, child, 100); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mp.Add(ctx, rich, 50); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := mp.Entries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, entry := range entries {
+		switch {
+		case bytes.Equal(entry.Tx.ID, parent.ID):
+			order = append(order, "parent")
+		case bytes.Equal(entry.Tx.ID, child.ID):
+			order = append(order, "child")
+		default:
+			order = append(order, "rich")
+		}
+	}
+	if len(order) != 3 || order[0] != "parent" || order[1] != "child" || order[2] != "rich" {
+		t.Fatalf("thứ tự %v, muốn cha trước con và theo phí", order)
+	}
+}
+
+func TestAcceptTransactionReplaceByFee(t *testing.T) {
+	ctx := context.Background()
+	s, alice := newTestServer(t)
+	bob := testutil.NewWallet(t)
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+
+	original := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, bob.GetAddress(), 90))
+	if ack, err := s.acceptTransaction(ctx, original); err != nil || !ack.Success {
+		t.Fatalf("giao dịch gốc bị từ chối: %v %v", ack, err)
+	}
+
+	child := &domain.Transaction{
+		Vin:  []domain.TxInput{{TxID: original.ID, VoutIndex: 0, PublicKey: bob.PublicKey, Sequence: domain.SequenceFinal}},
+		Vout: []domain.TxOutput{testutil.PayTo(t, bob.GetAddress(), 80)},
+	}
+	child.SetID()
+	if err := child.Sign(bob.PrivateKey, map[string]domain.Transaction{string(original.ID): *original}); err != nil {
+		t.Fatal(err)
+	}
+	if ack, err := s.acceptTransaction(ctx, child); err != nil || !ack.Success {
+		t.Fatalf("giao dịch con chưa xác nhận bị từ chối: %v %v", ack, err)
+	}
+
+	tooCheap := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, alice.GetAddress(), 85))
+	if ack, err := s.acceptTransaction(ctx, tooCheap); err != nil || ack.Success {
+		t.Fatalf("phí 15 không vượt tổng phí 20 của TX gốc và TX con nhưng vẫn được nhận: %v %v", ack, err)
+	}
+
+	replacement := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, alice.GetAddress(), 70))
+	ack, err := s.acceptTransaction(ctx, replacement)
+	if err != nil || !ack.Success {
+		t.Fatalf("giao dịch thay thế hợp lệ bị từ chối: %v %v", ack, err)
+	}
+	for _, gone := range []*domain.Transaction{original, child} {
+		if entry, _ := s.Mempool.Get(ctx, gone.ID); entry != nil {
+			t.Fatalf("TX %x phải bị loại khỏi mempool sau RBF", gone.ID)
+		}
+	}
+}
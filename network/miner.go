@@ -1,202 +1,249 @@
 package network
 
 import (
-	"bytes"
 	"context"
-	"encoding/gob"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"log"
+	"sync"
 	"time"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/khoahotran/gochain-ledger/domain"
 	"github.com/khoahotran/gochain-ledger/vm"
 	lua "github.com/yuin/gopher-lua"
 )
 
 const (
-	miningInterval = 10 * time.Second
-	rewardAmount   = 100
+	DefaultMiningInterval = 10 * time.Second
 )
 
-func StartMiningLoop(bc *domain.Blockchain, rdb *redis.Client, minerAddress string) {
-	ctx := context.Background()
+var chainMu sync.Mutex
 
-	ticker := time.NewTicker(miningInterval)
+func StartMiningLoop(ctx context.Context, bc *domain.Blockchain, mempool *Mempool, minerAddress string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Miner: Đã dừng.")
+			return
+		case <-ticker.C:
+			if _, err := MineBlock(ctx, bc, mempool, minerAddress, false); err != nil {
+				log.Printf("Miner: Lỗi khi đào block: %v", err)
+			}
+		}
+	}
+}
+
+func CloseBlockchain(bc *domain.Blockchain) error {
+	chainMu.Lock()
+	defer chainMu.Unlock()
+	return bc.Close()
+}
+
+func MineBlock(ctx context.Context, bc *domain.Blockchain, mempool *Mempool, minerAddress string, allowEmpty bool) (*domain.Block, error) {
+	chainMu.Lock()
+	defer chainMu.Unlock()
+
+	var entries []*MempoolEntry
+	if mempool != nil {
 		log.Println("Miner: Đang kiểm tra Mempool...")
 
-		txsData, err := rdb.SMembers(ctx, mempoolKey).Result()
+		var err error
+		entries, err = mempool.Entries(ctx)
 		if err != nil {
-			log.Printf("Miner: Lỗi khi đọc mempool: %v", err)
-			continue
+			return nil, fmt.Errorf("lỗi khi đọc mempool: %v", err)
 		}
+	}
 
-		if len(txsData) == 0 {
-			log.Println("Miner: Mempool trống. Đang chờ...")
-			continue
-		}
+	if len(entries) == 0 && !allowEmpty {
+		log.Println("Miner: Mempool trống. Đang chờ...")
+		return nil, nil
+	}
 
-		log.Printf("Miner: Tìm thấy %d giao dịch! Bắt đầu đào...", len(txsData))
+	if len(entries) > 0 {
+		log.Printf("Miner: Tìm thấy %d giao dịch! Bắt đầu đào...", len(entries))
+	}
+
+	var validTxs []*domain.Transaction
+	var processedTxIDs [][]byte
+	var totalFees int64
+
+	verifyCtx := bc.NextVerifyContext()
+	verifyCtx.Pending = make(map[string]*domain.Transaction)
+	spentOutpoints := make(map[string]bool)
+	state := domain.NewStateBatch(bc)
+	inMempool := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		inMempool[string(entry.Tx.ID)] = true
+	}
 
-		var validTxs []*domain.Transaction
-		var processedTxsData [][]byte
+	for _, entry := range entries {
+		tx := *entry.Tx
 
-		for _, data := range txsData {
-			var tx domain.Transaction
-			dec := gob.NewDecoder(bytes.NewReader([]byte(data)))
-			if err := dec.Decode(&tx); err != nil {
-				log.Printf("Miner: Lỗi giải mã TX: %v. Bỏ qua.", err)
+		if err := bc.CheckLocks(&tx, verifyCtx); err != nil {
+			log.Printf("Miner: TX %x chưa thể đưa vào block: %v. Giữ lại trong Mempool.", tx.ID, err)
+			continue
+		}
+
+		fee, err := bc.CheckInputs(&tx, verifyCtx, spentOutpoints)
+		if err != nil {
+			if errors.Is(err, domain.ErrImmatureCoinbase) || waitsForParent(&tx, inMempool, verifyCtx.Pending) {
+				log.Printf("Miner: TX %x chưa thể đưa vào block: %v. Giữ lại trong Mempool.", tx.ID, err)
 				continue
 			}
+			log.Printf("Miner: TX %x có input không hợp lệ: %v. Loại bỏ.", tx.ID, err)
+			processedTxIDs = append(processedTxIDs, tx.ID)
+			continue
+		}
 
-			processedTxsData = append(processedTxsData, []byte(data))
-
-			prevTxs := bc.FindReferencedTxs(&tx)
+		prevTxs, err := bc.ReferencedOutputs(&tx, verifyCtx.Pending)
+		if err != nil {
+			log.Printf("Miner: Lỗi đọc UTXO Set cho TX %x: %v. Giữ lại trong Mempool.", tx.ID, err)
+			continue
+		}
 
-			switch tx.Type {
-			case domain.TxTypeTransfer:
+		processedTxIDs = append(processedTxIDs, tx.ID)
+		validCount := len(validTxs)
 
-				if tx.Verify(prevTxs) {
-					log.Printf("Miner: TX Transfer hợp lệ: %x", tx.ID)
-					validTxs = append(validTxs, &tx)
-				} else {
-					log.Printf("Miner: Phát hiện TX Transfer không hợp lệ: %x", tx.ID)
-				}
+		switch tx.Type {
+		case domain.TxTypeTransfer:
 
-			case domain.TxTypeContractDeploy:
+			if err := tx.Verify(prevTxs, verifyCtx); err == nil {
+				log.Printf("Miner: TX Transfer hợp lệ: %x", tx.ID)
+				validTxs = append(validTxs, &tx)
+			} else {
+				log.Printf("Miner: Phát hiện TX Transfer không hợp lệ: %x: %v", tx.ID, err)
+			}
 
-				if tx.Verify(prevTxs) {
-					log.Printf("Miner: TX Deploy hợp lệ: %x", tx.ID)
+		case domain.TxTypeContractDeploy:
 
-					senderPubKeyHash := domain.HashPubKey(tx.Vin[0].PublicKey)
-					contractAddress := tx.ID
+			if err := tx.Verify(prevTxs, verifyCtx); err == nil {
+				log.Printf("Miner: TX Deploy hợp lệ: %x", tx.ID)
 
-					err := executeVM(bc, tx.Payload, contractAddress, senderPubKeyHash, "", nil)
+				senderPubKeyHash := domain.HashPubKey(tx.Vin[0].PublicKey)
+				contractAddress := tx.ID
 
-					if err != nil {
+				txState := state.Fork()
+				err := executeVM(txState, tx.Payload, contractAddress, senderPubKeyHash, "", nil)
 
-						log.Printf("Miner: LỖI VM (Deploy %x): %v. Giao dịch bị TỪ CHỐI.", tx.ID, err)
+				if err != nil {
 
-					} else {
+					log.Printf("Miner: LỖI VM (Deploy %x): %v. Giao dịch bị TỪ CHỐI.", tx.ID, err)
 
-						log.Println("Miner: VM Deploy thành công. Đang lưu code...")
+				} else {
 
-						if err := bc.SetContractCode(tx.ID, tx.Payload); err != nil {
-							log.Printf("Miner: LỖI không lưu được code: %v. Bỏ qua TX.", err)
-						} else {
+					log.Println("Miner: VM Deploy thành công. Đang lưu code...")
 
-							validTxs = append(validTxs, &tx)
-						}
-					}
-				} else {
-					log.Printf("Miner: Phát hiện TX Deploy không hợp lệ: %x", tx.ID)
+					txState.SetContractCode(tx.ID, tx.Payload)
+					txState.Commit()
+					validTxs = append(validTxs, &tx)
 				}
+			} else {
+				log.Printf("Miner: Phát hiện TX Deploy không hợp lệ: %x: %v", tx.ID, err)
+			}
 
-			case domain.TxTypeContractCall:
-
-				if tx.Verify(prevTxs) {
-					log.Printf("Miner: TX Call hợp lệ: %x", tx.ID)
+		case domain.TxTypeContractCall:
 
-					payload, err := vm.ParseCallPayload(tx.Payload)
-					if err != nil {
-						log.Printf("Miner: LỖI Payload Call: %v. Từ chối TX.", err)
-						continue
-					}
+			if err := tx.Verify(prevTxs, verifyCtx); err == nil {
+				log.Printf("Miner: TX Call hợp lệ: %x", tx.ID)
 
-					contractAddressBytes, err := hex.DecodeString(payload.ContractAddress)
-					if err != nil {
-						log.Printf("Miner: LỖI Địa chỉ Contract: %v. Từ chối TX.", err)
-						continue
-					}
+				payload, err := vm.ParseCallPayload(tx.Payload)
+				if err != nil {
+					log.Printf("Miner: LỖI Payload Call: %v. Từ chối TX.", err)
+					continue
+				}
 
-					code, err := bc.GetContractCode(contractAddressBytes)
-					if err != nil {
-						log.Printf("Miner: LỖI không tìm thấy code contract: %v. Từ chối TX.", err)
-						continue
-					}
+				contractAddressBytes, err := hex.DecodeString(payload.ContractAddress)
+				if err != nil {
+					log.Printf("Miner: LỖI Địa chỉ Contract: %v. Từ chối TX.", err)
+					continue
+				}
 
-					senderPubKeyHash := domain.HashPubKey(tx.Vin[0].PublicKey)
+				code, err := state.GetContractCode(contractAddressBytes)
+				if err != nil {
+					log.Printf("Miner: LỖI không tìm thấy code contract: %v. Từ chối TX.", err)
+					continue
+				}
 
-					luaArgs := vm.ConvertArgsToLValues(payload.Args)
+				senderPubKeyHash := domain.HashPubKey(tx.Vin[0].PublicKey)
 
-					err = executeVM(bc, code, contractAddressBytes, senderPubKeyHash, payload.FunctionName, luaArgs)
+				luaArgs := vm.ConvertArgsToLValues(payload.Args)
 
-					if err != nil {
+				txState := state.Fork()
+				err = executeVM(txState, code, contractAddressBytes, senderPubKeyHash, payload.FunctionName, luaArgs)
 
-						log.Printf("Miner: LỖI VM (Call %x): %v. Giao dịch bị TỪ CHỐI.", tx.ID, err)
-					} else {
+				if err != nil {
 
-						log.Printf("Miner: VM Call (%s) thành công.", payload.FunctionName)
-						validTxs = append(validTxs, &tx)
-					}
+					log.Printf("Miner: LỖI VM (Call %x): %v. Giao dịch bị TỪ CHỐI.", tx.ID, err)
 				} else {
-					log.Printf("Miner: Phát hiện TX Call không hợp lệ: %x", tx.ID)
+
+					log.Printf("Miner: VM Call (%s) thành công.", payload.FunctionName)
+					txState.Commit()
+					validTxs = append(validTxs, &tx)
 				}
+			} else {
+				log.Printf("Miner: Phát hiện TX Call không hợp lệ: %x: %v", tx.ID, err)
 			}
-
 		}
 
-		coinbaseTx := domain.NewCoinbaseTransaction(minerAddress, rewardAmount)
-
-		allTxs := validTxs
-
-		if len(allTxs) == 0 {
-			log.Println("Miner: Không có TX hợp lệ để đào.")
-
-			continue
+		if len(validTxs) > validCount {
+			totalFees += fee
+			verifyCtx.Pending[string(tx.ID)] = validTxs[len(validTxs)-1]
 		}
+	}
 
-		allTxs = append([]*domain.Transaction{coinbaseTx}, allTxs...)
+	reward := bc.Emission.BlockReward(verifyCtx.Height) + totalFees
+	coinbaseTx, err := domain.NewCoinbaseTransaction(minerAddress, reward, verifyCtx.Height)
+	if err != nil {
+		return nil, err
+	}
 
-		for _, tx := range allTxs {
+	allTxs := validTxs
 
-			senderPubKeyHash := domain.HashPubKey(tx.Vin[0].PublicKey)
+	if len(allTxs) == 0 && !allowEmpty {
+		log.Println("Miner: Không có TX hợp lệ để đào.")
 
-			if tx.Type == domain.TxTypeContractDeploy {
+		return nil, nil
+	}
 
-				contractAddress := tx.ID
-				err := executeVM(bc, tx.Payload, contractAddress, senderPubKeyHash, "", nil)
-				if err != nil {
-					log.Printf("Miner: LỖI VM (Deploy %x): %v", tx.ID, err)
+	allTxs = append([]*domain.Transaction{coinbaseTx}, allTxs...)
 
-				}
-			}
+	block, err := bc.AddBlock(allTxs, state)
+	if err != nil {
+		return nil, err
+	}
 
-			if tx.Type == domain.TxTypeContractCall {
+	log.Printf("Miner: === 🚀 ĐÀO THÀNH CÔNG BLOCK MỚI! ===")
 
-				log.Println("Miner: (TODO) Xử lý Contract Call")
-			}
+	if len(processedTxIDs) > 0 {
+		if err := mempool.Remove(ctx, processedTxIDs...); err != nil {
+			log.Printf("Miner: Lỗi dọn dẹp mempool: %v", err)
 		}
+		log.Printf("Miner: Đã dọn dẹp %d TX khỏi Mempool.", len(processedTxIDs))
+	}
+	return block, nil
+}
 
-		bc.AddBlock(allTxs)
-
-		log.Printf("Miner: === 🚀 ĐÀO THÀNH CÔNG BLOCK MỚI! ===")
-
-		if len(processedTxsData) > 0 {
-			pipe := rdb.Pipeline()
-			for _, data := range processedTxsData {
-				pipe.SRem(ctx, mempoolKey, data)
-			}
-			_, err = pipe.Exec(ctx)
-			if err != nil {
-				log.Printf("Miner: Lỗi dọn dẹp mempool: %v", err)
-			}
-			log.Printf("Miner: Đã dọn dẹp %d TX khỏi Mempool.", len(processedTxsData))
+func waitsForParent(tx *domain.Transaction, inMempool map[string]bool, included map[string]*domain.Transaction) bool {
+	for _, vin := range tx.Vin {
+		if _, ok := included[string(vin.TxID)]; !ok && inMempool[string(vin.TxID)] {
+			return true
 		}
 	}
+	return false
 }
 
-func executeVM(bc *domain.Blockchain, code []byte, contractAddress []byte, senderAddress []byte, functionName string, args []lua.LValue) error {
+func executeVM(state vm.StateStore, code []byte, contractAddress []byte, senderAddress []byte, functionName string, args []lua.LValue) error {
 
 	v := vm.NewVM()
 	defer v.Close()
 
 	v.RegisterBridgeFunctions()
 
-	v.SetContext(bc, contractAddress, senderAddress)
+	v.SetContext(state, contractAddress, senderAddress)
 
 	if functionName == "" {
 
@@ -0,0 +1,139 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func (g *Gateway) serveOpenAPI(w http.ResponseWriter) {
+	g.specOnce.Do(func() {
+		spec, err := json.MarshalIndent(g.openAPISpec(), "", "  ")
+		if err != nil {
+			log.Printf("Gateway: lỗi tạo OpenAPI: %v", err)
+			return
+		}
+		g.spec = spec
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(g.spec)
+}
+
+func (g *Gateway) openAPISpec() map[string]any {
+	schemas := make(map[string]any)
+	paths := make(map[string]any)
+
+	routes := make([]string, 0, len(g.methods))
+	for route := range g.methods {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+
+	for _, route := range routes {
+		md := g.methods[route].desc
+		addSchema(schemas, md.Input())
+		addSchema(schemas, md.Output())
+
+		contentType := "application/json"
+		description := "Kết quả RPC"
+		if md.IsStreamingServer() {
+			contentType = "application/x-ndjson"
+			description = "Luồng kết quả, mỗi dòng là một JSON object"
+		}
+		errorResponse := map[string]any{
+			"description": "Lỗi từ node",
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": schemaRef("GatewayError")},
+			},
+		}
+
+		paths[GatewayPrefix+route] = map[string]any{
+			"post": map[string]any{
+				"operationId": fmt.Sprintf("%s_%s", md.Parent().Name(), md.Name()),
+				"tags":        []string{string(md.Parent().Name())},
+				"summary":     fmt.Sprintf("%s (cũng nhận GET với tham số trên query string)", md.FullName()),
+				"requestBody": map[string]any{
+					"content": map[string]any{
+						"application/json": map[string]any{"schema": schemaRef(string(md.Input().Name()))},
+					},
+				},
+				"responses": map[string]any{
+					"200": map[string]any{
+						"description": description,
+						"content": map[string]any{
+							contentType: map[string]any{"schema": schemaRef(string(md.Output().Name()))},
+						},
+					},
+					"default": errorResponse,
+				},
+			},
+		}
+	}
+
+	schemas["GatewayError"] = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"error": map[string]any{"type": "string"},
+			"code":  map[string]any{"type": "string"},
+		},
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "GoChain Ledger REST API",
+			"version":     "1",
+			"description": "REST/JSON cho mọi RPC của NodeService và PublicService. Trường bytes được mã hóa hex, địa chỉ ví dùng Base58Check.",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+func schemaRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func addSchema(schemas map[string]any, md protoreflect.MessageDescriptor) {
+	name := string(md.Name())
+	if _, ok := schemas[name]; ok {
+		return
+	}
+
+	properties := make(map[string]any)
+	schemas[name] = map[string]any{"type": "object", "properties": properties}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		schema := fieldSchema(schemas, fd)
+		if fd.IsList() {
+			schema = map[string]any{"type": "array", "items": schema}
+		}
+		properties[string(fd.Name())] = schema
+	}
+}
+
+func fieldSchema(schemas map[string]any, fd protoreflect.FieldDescriptor) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		addSchema(schemas, fd.Message())
+		return schemaRef(string(fd.Message().Name()))
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "hex"}
+	case protoreflect.StringKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32", "minimum": 0}
+	}
+	return map[string]any{"type": "integer", "format": "int64"}
+}
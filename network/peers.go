@@ -0,0 +1,144 @@
+package network
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+const (
+	relayTimeout   = 5 * time.Second
+	relayQueueSize = 256
+)
+
+type PeerSet struct {
+	addrs []string
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func NewPeerSet(addrs []string) *PeerSet {
+	return &PeerSet{addrs: addrs, conns: make(map[string]*grpc.ClientConn)}
+}
+
+func ParsePeers(list string) []string {
+	var addrs []string
+	for _, addr := range strings.Split(list, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+func (p *PeerSet) Addresses() []string {
+	if p == nil {
+		return []string{}
+	}
+	return append([]string{}, p.addrs...)
+}
+
+func (p *PeerSet) client(addr string) (proto.NodeServiceClient, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conn, ok := p.conns[addr]
+	if !ok {
+		var err error
+		conn, err = Dial(addr)
+		if err != nil {
+			return nil, err
+		}
+		p.conns[addr] = conn
+	}
+	return proto.NewNodeServiceClient(conn), nil
+}
+
+func (p *PeerSet) Relay(bus *domain.EventBus) {
+	if p == nil || len(p.addrs) == 0 {
+		return
+	}
+
+	queues := make([]chan relayCall, len(p.addrs))
+	for i, addr := range p.addrs {
+		queues[i] = make(chan relayCall, relayQueueSize)
+		go p.relayTo(addr, queues[i])
+	}
+
+	for {
+		events, _ := bus.Subscribe()
+		for event := range events {
+			p.dispatch(queues, event)
+		}
+		// Bus ngắt subscriber không đọc kịp sau một loạt sự kiện; relay phải đăng ký lại thay vì dừng hẳn.
+		log.Printf("Relay: bị event bus ngắt vì xử lý chậm, đăng ký lại")
+	}
+}
+
+func (p *PeerSet) dispatch(queues []chan relayCall, event domain.ChainEvent) {
+	var call relayCall
+	switch event.Kind {
+	case domain.EventBlockConnected:
+		block := MapDomainBlockToProto(event.Block)
+		call = relayCall{method: "AnnounceBlock", send: func(ctx context.Context, client proto.NodeServiceClient) error {
+			_, err := client.AnnounceBlock(ctx, block)
+			return err
+		}}
+	case domain.EventTxAccepted:
+		tx := MapDomainTransactionToProto(event.Tx)
+		call = relayCall{method: "SendTransaction", send: func(ctx context.Context, client proto.NodeServiceClient) error {
+			_, err := client.SendTransaction(ctx, tx)
+			return err
+		}}
+	default:
+		return
+	}
+
+	for i, queue := range queues {
+		select {
+		case queue <- call:
+		default:
+			log.Printf("Peer %s: hàng đợi đầy, bỏ qua %s", p.addrs[i], call.method)
+		}
+	}
+}
+
+type relayCall struct {
+	method string
+	send   func(ctx context.Context, client proto.NodeServiceClient) error
+}
+
+func (p *PeerSet) relayTo(addr string, queue <-chan relayCall) {
+	for call := range queue {
+		client, err := p.client(addr)
+		if err != nil {
+			log.Printf("Peer %s: không thể kết nối: %v", addr, err)
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), relayTimeout)
+		if err := call.send(ctx, client); err != nil {
+			log.Printf("Peer %s: %s thất bại: %v", addr, call.method, err)
+		}
+		cancel()
+	}
+}
+
+func (p *PeerSet) Close() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for addr, conn := range p.conns {
+		conn.Close()
+		delete(p.conns, addr)
+	}
+}
@@ -0,0 +1,82 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+// relaySink là peer giả chỉ ghi lại ID của các giao dịch được relay tới.
+type relaySink struct {
+	proto.UnimplementedNodeServiceServer
+	got chan string
+}
+
+func (s *relaySink) SendTransaction(ctx context.Context, tx *proto.Transaction) (*proto.Ack, error) {
+	select {
+	case s.got <- string(tx.Id):
+	default:
+	}
+	return &proto.Ack{Success: true}, nil
+}
+
+func startRelaySink(t *testing.T) (*relaySink, string) {
+	t.Helper()
+	sink := &relaySink{got: make(chan string, 4096)}
+	server := grpc.NewServer(ServerOptions()...)
+	proto.RegisterNodeServiceServer(server, sink)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return sink, lis.Addr().String()
+}
+
+// relayed phát lại sự kiện cho tới khi peer nhận được đúng giao dịch id.
+func relayed(t *testing.T, bus *domain.EventBus, sink *relaySink, id string) bool {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	for {
+		bus.Publish(domain.ChainEvent{Kind: domain.EventTxAccepted, Tx: &domain.Transaction{ID: []byte(id)}})
+		select {
+		case got := <-sink.got:
+			if got == id {
+				return true
+			}
+		case <-time.After(50 * time.Millisecond):
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+func TestRelaySurvivesEventBurst(t *testing.T) {
+	sink, addr := startRelaySink(t)
+	bus := domain.NewEventBus()
+	peers := NewPeerSet([]string{addr})
+	t.Cleanup(peers.Close)
+	go peers.Relay(bus)
+
+	if !relayed(t, bus, sink, "trước") {
+		t.Fatal("relay không gửi giao dịch tới peer")
+	}
+
+	// Loạt sự kiện lớn hơn nhiều so với buffer của subscriber khiến bus ngắt relay.
+	for i := 0; i < 4096; i++ {
+		bus.Publish(domain.ChainEvent{Kind: domain.EventTxAccepted, Tx: &domain.Transaction{ID: []byte(fmt.Sprintf("loạt-%d", i))}})
+	}
+
+	if !relayed(t, bus, sink, "sau") {
+		t.Fatal("relay phải tiếp tục gửi sau khi bị event bus ngắt")
+	}
+}
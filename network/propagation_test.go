@@ -0,0 +1,104 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+)
+
+// startDevnet dựng n node trong cùng tiến trình như lệnh devnet: mỗi node có chain riêng cùng genesis,
+// nối với mọi node còn lại và relay block qua gRPC.
+func startDevnet(t *testing.T, n int) []*Server {
+	t.Helper()
+	bc, alice := testutil.NewChain(t, nil)
+	nodes := make([]*Server, n)
+	addrs := make([]string, n)
+	for i := range nodes {
+		if i > 0 {
+			bc = testutil.Fork(t, alice)
+		}
+		bc.Events = domain.NewEventBus()
+		mp := NewMemoryMempool()
+		mp.Events = bc.Events
+		nodes[i] = &Server{Blockchain: bc, Mempool: mp}
+		addrs[i] = serveTestGRPC(t, nodes[i])
+	}
+	for i, node := range nodes {
+		var others []string
+		for j, addr := range addrs {
+			if j != i {
+				others = append(others, addr)
+			}
+		}
+		node.Peers = NewPeerSet(others)
+		t.Cleanup(node.Peers.Close)
+		go node.Peers.Relay(node.Blockchain.Events)
+	}
+	return nodes
+}
+
+// converged cho biết mọi node đã cùng tip; đọc tip dưới chainMu vì sync có thể đang reorg.
+func converged(nodes []*Server) bool {
+	chainMu.Lock()
+	defer chainMu.Unlock()
+	for _, node := range nodes[1:] {
+		if !bytes.Equal(node.Blockchain.LastHash, nodes[0].Blockchain.LastHash) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDevnetMinersConverge(t *testing.T) {
+	ctx := context.Background()
+	nodes := startDevnet(t, 3)
+
+	// Hai miner đào song song nên sẽ có block cạnh tranh ở cùng chiều cao.
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for _, miner := range nodes[:2] {
+		wg.Add(1)
+		go func(s *Server) {
+			defer wg.Done()
+			addr := testutil.NewWallet(t).GetAddress()
+			for i := 0; i < 3; i++ {
+				if _, err := MineBlock(ctx, s.Blockchain, s.Mempool, addr, true); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(miner)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	// Tiếp tục đào trên node0 tới khi nhánh của nó nặng hơn và mọi node reorg về cùng tip.
+	addr := testutil.NewWallet(t).GetAddress()
+	deadline := time.Now().Add(15 * time.Second)
+	for !converged(nodes) {
+		if time.Now().After(deadline) {
+			for i, node := range nodes {
+				t.Logf("node%d: tip %x, chiều cao %d", i, node.Blockchain.LastHash, node.Blockchain.GetBestHeight())
+			}
+			t.Fatal("các node không hội tụ về cùng tip")
+		}
+		if _, err := MineBlock(ctx, nodes[0].Blockchain, nodes[0].Mempool, addr, true); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	for i, node := range nodes {
+		if report, err := node.Blockchain.Verify(domain.VerifyOptions{Full: true}); err != nil || !report.OK() {
+			t.Fatalf("node%d không nhất quán sau khi hội tụ: %v %v", i, report, err)
+		}
+	}
+}
@@ -3,20 +3,19 @@ package network
 import (
 	"context"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/khoahotran/gochain-ledger/domain"
 	"github.com/khoahotran/gochain-ledger/proto"
 )
 
 type PublicServer struct {
 	proto.UnimplementedPublicServiceServer
-	Blockchain  *domain.Blockchain
-	RedisClient *redis.Client
+	Blockchain *domain.Blockchain
+	Mempool    *Mempool
 }
 
 func (s *PublicServer) GetBalance(ctx context.Context, req *proto.GetBalanceRequest) (*proto.GetBalanceResponse, error) {
 
-	gs := &Server{Blockchain: s.Blockchain}
+	gs := &Server{Blockchain: s.Blockchain, Mempool: s.Mempool}
 	return gs.GetBalance(ctx, req)
 }
 
@@ -28,12 +27,66 @@ func (s *PublicServer) GetContractState(ctx context.Context, req *proto.GetContr
 
 func (s *PublicServer) SubmitTransaction(ctx context.Context, req *proto.Transaction) (*proto.Ack, error) {
 
-	gs := &Server{Blockchain: s.Blockchain, RedisClient: s.RedisClient}
+	gs := &Server{Blockchain: s.Blockchain, Mempool: s.Mempool}
 	return gs.SendTransaction(ctx, req)
 }
 
+func (s *PublicServer) SubmitRawTransaction(ctx context.Context, req *proto.RawTransaction) (*proto.Ack, error) {
+
+	gs := &Server{Blockchain: s.Blockchain, Mempool: s.Mempool}
+	return gs.SendRawTransaction(ctx, req)
+}
+
 func (s *PublicServer) FindSpendableUTXOs(ctx context.Context, req *proto.FindSpendableUTXOsRequest) (*proto.FindSpendableUTXOsResponse, error) {
 
-	gs := &Server{Blockchain: s.Blockchain}
+	gs := &Server{Blockchain: s.Blockchain, Mempool: s.Mempool}
 	return gs.FindSpendableUTXOs(ctx, req)
 }
+
+func (s *PublicServer) GetSupply(ctx context.Context, req *proto.EmptyRequest) (*proto.GetSupplyResponse, error) {
+
+	gs := &Server{Blockchain: s.Blockchain}
+	return gs.GetSupply(ctx, req)
+}
+
+func (s *PublicServer) ListUnspent(ctx context.Context, req *proto.ListUnspentRequest) (*proto.ListUnspentResponse, error) {
+
+	gs := &Server{Blockchain: s.Blockchain, Mempool: s.Mempool}
+	return gs.ListUnspent(ctx, req)
+}
+
+func (s *PublicServer) GetAddressHistory(ctx context.Context, req *proto.GetAddressHistoryRequest) (*proto.GetAddressHistoryResponse, error) {
+
+	gs := &Server{Blockchain: s.Blockchain}
+	return gs.GetAddressHistory(ctx, req)
+}
+
+func (s *PublicServer) GetChainInfo(ctx context.Context, req *proto.EmptyRequest) (*proto.GetChainInfoResponse, error) {
+
+	gs := &Server{Blockchain: s.Blockchain, Mempool: s.Mempool}
+	return gs.GetChainInfo(ctx, req)
+}
+
+func (s *PublicServer) GetBlock(ctx context.Context, req *proto.GetBlockRequest) (*proto.GetBlockResponse, error) {
+
+	gs := &Server{Blockchain: s.Blockchain}
+	return gs.GetBlock(ctx, req)
+}
+
+func (s *PublicServer) ListBlocks(ctx context.Context, req *proto.ListBlocksRequest) (*proto.ListBlocksResponse, error) {
+
+	gs := &Server{Blockchain: s.Blockchain}
+	return gs.ListBlocks(ctx, req)
+}
+
+func (s *PublicServer) GetTransaction(ctx context.Context, req *proto.GetTransactionRequest) (*proto.GetTransactionResponse, error) {
+
+	gs := &Server{Blockchain: s.Blockchain, Mempool: s.Mempool}
+	return gs.GetTransaction(ctx, req)
+}
+
+func (s *PublicServer) GetMempool(ctx context.Context, req *proto.EmptyRequest) (*proto.GetMempoolResponse, error) {
+
+	gs := &Server{Blockchain: s.Blockchain, Mempool: s.Mempool}
+	return gs.GetMempool(ctx, req)
+}
@@ -3,74 +3,106 @@ package network
 import (
 	"bytes"
 	"context"
-	"encoding/gob"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
-	"net"
+	"math"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/khoahotran/gochain-ledger/domain"
 	"github.com/khoahotran/gochain-ledger/proto"
-	"google.golang.org/grpc"
 )
 
 type Server struct {
 	proto.UnimplementedNodeServiceServer
-	Blockchain  *domain.Blockchain
-	RedisClient *redis.Client
+	Blockchain *domain.Blockchain
+	Mempool    *Mempool
+	Peers      *PeerSet
 }
 
-const (
-	mempoolKey = "gochain:mempool"
-)
+func (s *Server) SendTransaction(ctx context.Context, req *proto.Transaction) (*proto.Ack, error) {
+	log.Printf("Nhận được giao dịch mới: %x", req.Id)
 
-func StartServer(port string, bc *domain.Blockchain, rdb *redis.Client) {
+	tx := MapProtoTransactionToDomain(req)
+	return s.acceptTransaction(ctx, tx)
+}
 
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+func (s *Server) SendRawTransaction(ctx context.Context, req *proto.RawTransaction) (*proto.Ack, error) {
+	tx, err := domain.DecodeRawTransaction(req.Hex)
 	if err != nil {
-		log.Fatalf("Không thể lắng nghe trên cổng %s: %v", port, err)
+		log.Printf("Từ chối raw TX: %v", err)
+		return &proto.Ack{Success: false, Message: fmt.Sprintf("Raw TX không hợp lệ: %v", err)}, nil
 	}
+	log.Printf("Nhận được raw TX mới: %x", tx.ID)
 
-	s := grpc.NewServer()
+	return s.acceptTransaction(ctx, tx)
+}
 
-	proto.RegisterNodeServiceServer(s, &Server{
-		Blockchain:  bc,
-		RedisClient: rdb,
-	})
+func (s *Server) acceptTransaction(ctx context.Context, tx *domain.Transaction) (*proto.Ack, error) {
+	if !bytes.Equal(tx.ID, tx.ComputeID()) {
+		log.Printf("Từ chối TX %x: ID không khớp với nội dung", tx.ID)
+		return &proto.Ack{Success: false, Message: "ID giao dịch không khớp với nội dung"}, nil
+	}
 
-	log.Printf("gRPC Server đang lắng nghe tại %v", lis.Addr())
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("gRPC Server thất bại: %v", err)
+	if !tx.IsStandard() {
+		log.Printf("Từ chối TX %x: chứa locking script không chuẩn", tx.ID)
+		return &proto.Ack{Success: false, Message: "Locking script không chuẩn"}, nil
 	}
-}
 
-func (s *Server) SendTransaction(ctx context.Context, req *proto.Transaction) (*proto.Ack, error) {
-	log.Printf("Nhận được giao dịch mới: %x", req.Id)
+	if dust := tx.DustOutputs(s.Mempool.DustThreshold); len(dust) > 0 {
+		log.Printf("Từ chối TX %x: output %v nhỏ hơn ngưỡng dust %d", tx.ID, dust, s.Mempool.DustThreshold)
+		return &proto.Ack{Success: false, Message: fmt.Sprintf("Output %v nhỏ hơn ngưỡng dust %d", dust, s.Mempool.DustThreshold)}, nil
+	}
 
-	tx := MapProtoTransactionToDomain(req)
+	verifyCtx := s.Blockchain.NextVerifyContext()
+	pending, err := s.Mempool.Parents(ctx, tx)
+	if err != nil {
+		log.Printf("Lỗi đọc mempool: %v", err)
+		return &proto.Ack{Success: false, Message: "Lỗi mempool"}, err
+	}
+	verifyCtx.Pending = pending
+
+	if err := s.Blockchain.CheckLocks(tx, verifyCtx); err != nil {
+		log.Printf("Từ chối TX %x: %v", tx.ID, err)
+		return &proto.Ack{Success: false, Message: fmt.Sprintf("Giao dịch chưa hợp lệ: %v", err)}, nil
+	}
 
-	var txData bytes.Buffer
-	enc := gob.NewEncoder(&txData)
-	err := enc.Encode(tx)
+	fee, err := s.Blockchain.CheckInputs(tx, verifyCtx, nil)
 	if err != nil {
-		log.Printf("Lỗi serialize tx: %v", err)
-		return &proto.Ack{Success: false, Message: "Lỗi xử lý"}, err
+		log.Printf("Từ chối TX %x: %v", tx.ID, err)
+		return &proto.Ack{Success: false, Message: fmt.Sprintf("Input không hợp lệ: %v", err)}, nil
 	}
 
-	err = s.RedisClient.SAdd(ctx, mempoolKey, txData.Bytes()).Err()
+	prevTxs, err := s.Blockchain.ReferencedOutputs(tx, pending)
+	if err != nil {
+		log.Printf("Lỗi đọc UTXO Set: %v", err)
+		return &proto.Ack{Success: false, Message: "Lỗi đọc UTXO Set"}, err
+	}
+	if err := tx.Verify(prevTxs, verifyCtx); err != nil {
+		log.Printf("Từ chối TX %x: %v", tx.ID, err)
+		return &proto.Ack{Success: false, Message: fmt.Sprintf("Chữ ký không hợp lệ: %v", err)}, nil
+	}
+
+	replaced, err := s.Mempool.Add(ctx, tx, fee)
+	if errors.Is(err, ErrMempoolConflict) || errors.Is(err, ErrMempoolDuplicate) {
+		log.Printf("Từ chối TX %x: %v", tx.ID, err)
+		return &proto.Ack{Success: false, Message: err.Error()}, nil
+	}
 	if err != nil {
 		log.Printf("Lỗi lưu tx vào mempool: %v", err)
 		return &proto.Ack{Success: false, Message: "Lỗi mempool"}, err
 	}
 
-	return &proto.Ack{Success: true, Message: "Đã nhận TX"}, nil
+	if len(replaced) > 0 {
+		return &proto.Ack{Success: true, Message: fmt.Sprintf("Đã nhận TX (phí %d), thay thế %d TX trong mempool", fee, len(replaced))}, nil
+	}
+	return &proto.Ack{Success: true, Message: fmt.Sprintf("Đã nhận TX (phí %d)", fee)}, nil
 }
 
 func (s *Server) AnnounceBlock(ctx context.Context, req *proto.Block) (*proto.Ack, error) {
 	log.Printf("Nhận được thông báo block mới: %x", req.Hash)
 
-	return &proto.Ack{Success: true, Message: "Đã nhận Block"}, nil
+	return s.announcedBlock(ctx, MapProtoBlockToDomain(req))
 }
 
 func (s *Server) GetBlocks(req *proto.GetBlocksRequest, stream proto.NodeService_GetBlocksServer) error {
@@ -79,7 +111,10 @@ func (s *Server) GetBlocks(req *proto.GetBlocksRequest, stream proto.NodeService
 	it := s.Blockchain.Iterator()
 
 	for {
-		block := it.Next()
+		block, err := it.Next()
+		if err != nil {
+			return err
+		}
 
 		protoBlock := MapDomainBlockToProto(block)
 		if err := stream.Send(protoBlock); err != nil {
@@ -95,50 +130,106 @@ func (s *Server) GetBlocks(req *proto.GetBlocksRequest, stream proto.NodeService
 
 func (s *Server) GetKnownNodes(ctx context.Context, req *proto.EmptyRequest) (*proto.KnownNodesResponse, error) {
 
-	return &proto.KnownNodesResponse{Addresses: []string{}}, nil
+	return &proto.KnownNodesResponse{Addresses: s.Peers.Addresses()}, nil
 }
 
 func (s *Server) GetBalance(ctx context.Context, req *proto.GetBalanceRequest) (*proto.GetBalanceResponse, error) {
 	address := req.Address
-	if !domain.ValidateAddress(address) {
-		return nil, fmt.Errorf("địa chỉ ví không hợp lệ")
+	pubKeyHash, err := domain.DecodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.balanceOf(ctx, pubKeyHash)
+	if err != nil {
+		return nil, err
 	}
+	log.Printf("Đã truy vấn số dư cho %s: %d (xác nhận %d, chờ %d, khóa %d)", address, res.Balance, res.Confirmed, res.Unconfirmed, res.Locked)
+	return res, nil
+}
 
-	pubKeyHash := domain.DecodeAddress(address)
+func (s *Server) balanceOf(ctx context.Context, pubKeyHash []byte) (*proto.GetBalanceResponse, error) {
+	spent, err := s.mempoolSpends(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi đọc mempool: %v", err)
+	}
 
 	utxoSet := domain.UTXOSet{Blockchain: s.Blockchain}
-	utxos := utxoSet.FindUTXO(pubKeyHash)
-
-	var balance int64 = 0
-	for _, out := range utxos {
-		balance += out.Value
+	confirmed, locked, err := utxoSet.Balance(pubKeyHash, s.Blockchain.GetBestHeight()+1, spent)
+	if err != nil {
+		return nil, err
 	}
 
-	log.Printf("Đã truy vấn số dư cho %s: %d", address, balance)
+	var unconfirmed int64
+	if s.Mempool != nil {
+		pendingUTXOs, err := s.Mempool.UnspentOutputs(ctx, pubKeyHash)
+		if err != nil {
+			return nil, fmt.Errorf("lỗi đọc mempool: %v", err)
+		}
+		for _, utxo := range pendingUTXOs {
+			if entry, err := utxoSet.GetEntry(utxo.TxID); err == nil && entry != nil {
+				continue
+			}
+			unconfirmed += utxo.Amount
+		}
+	}
 
-	return &proto.GetBalanceResponse{Balance: balance}, nil
+	return &proto.GetBalanceResponse{
+		Balance:     confirmed + unconfirmed + locked,
+		Confirmed:   confirmed,
+		Unconfirmed: unconfirmed,
+		Locked:      locked,
+	}, nil
 }
 
 func (s *Server) FindSpendableUTXOs(ctx context.Context, req *proto.FindSpendableUTXOsRequest) (*proto.FindSpendableUTXOsResponse, error) {
-	if !domain.ValidateAddress(req.Address) {
-		return nil, fmt.Errorf("địa chỉ không hợp lệ")
+	pubKeyHash, err := domain.DecodeAddress(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	spent, err := s.mempoolSpends(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi đọc mempool: %v", err)
+	}
+
+	limit := req.Amount
+	if req.ListAll {
+		limit = math.MaxInt64
 	}
-	pubKeyHash := domain.DecodeAddress(req.Address)
 
 	utxoSet := domain.UTXOSet{Blockchain: s.Blockchain}
-	acc, spendableData := utxoSet.FindSpendableUTXOData(pubKeyHash, req.Amount)
+	acc, spendableData, err := utxoSet.FindSpendableUTXOData(pubKeyHash, limit, s.Blockchain.GetBestHeight()+1, spent)
+	if err != nil {
+		return nil, err
+	}
+
+	if acc < limit && req.IncludeUnconfirmed && s.Mempool != nil {
+		pendingUTXOs, err := s.Mempool.UnspentOutputs(ctx, pubKeyHash)
+		if err != nil {
+			return nil, fmt.Errorf("lỗi đọc mempool: %v", err)
+		}
+		for _, utxo := range pendingUTXOs {
+			if acc >= limit {
+				break
+			}
+			acc += utxo.Amount
+			spendableData = append(spendableData, utxo)
+		}
+	}
 
 	if acc < req.Amount {
-		return nil, fmt.Errorf("không đủ tiền")
+		return nil, fmt.Errorf("%w: có %d, cần %d", domain.ErrInsufficientFunds, acc, req.Amount)
 	}
 
 	var protoUTXOs []*proto.SpendableUTXO
 	for _, utxo := range spendableData {
 		protoUTXOs = append(protoUTXOs, &proto.SpendableUTXO{
-			TxId:       utxo.TxID,
-			VoutIndex:  int32(utxo.VoutIndex),
-			Amount:     utxo.Amount,
-			PubKeyHash: utxo.PubKeyHash,
+			TxId:        utxo.TxID,
+			VoutIndex:   int32(utxo.VoutIndex),
+			Amount:      utxo.Amount,
+			PubKeyHash:  utxo.PubKeyHash,
+			Unconfirmed: utxo.Unconfirmed,
 		})
 	}
 
@@ -148,17 +239,130 @@ func (s *Server) FindSpendableUTXOs(ctx context.Context, req *proto.FindSpendabl
 	}, nil
 }
 
+func (s *Server) ListUnspent(ctx context.Context, req *proto.ListUnspentRequest) (*proto.ListUnspentResponse, error) {
+	if req.Offset < 0 || req.Limit < 0 {
+		return nil, fmt.Errorf("%w: offset và limit không được âm", ErrInvalidArgument)
+	}
+	pubKeyHash, err := domain.DecodeAddress(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	spent, err := s.mempoolSpends(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi đọc mempool: %v", err)
+	}
+
+	bestHeight := s.Blockchain.GetBestHeight()
+	emission := s.Blockchain.Emission
+	utxoSet := domain.UTXOSet{Blockchain: s.Blockchain}
+
+	unspent, err := utxoSet.ListUnspent(pubKeyHash, spent)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []*proto.UnspentOutput
+	for _, utxo := range unspent {
+		all = append(all, &proto.UnspentOutput{
+			TxId:          utxo.TxID,
+			VoutIndex:     int32(utxo.VoutIndex),
+			Amount:        utxo.Output.Value,
+			Height:        utxo.Height,
+			Confirmations: bestHeight - utxo.Height + 1,
+			Coinbase:      utxo.Coinbase,
+			Spendable:     !utxo.Coinbase || emission.IsMature(utxo.Height, bestHeight+1),
+		})
+	}
+
+	if req.IncludeUnconfirmed && s.Mempool != nil {
+		pendingUTXOs, err := s.Mempool.UnspentOutputs(ctx, pubKeyHash)
+		if err != nil {
+			return nil, fmt.Errorf("lỗi đọc mempool: %v", err)
+		}
+		for _, utxo := range pendingUTXOs {
+			all = append(all, &proto.UnspentOutput{
+				TxId:      utxo.TxID,
+				VoutIndex: int32(utxo.VoutIndex),
+				Amount:    utxo.Amount,
+				Spendable: true,
+			})
+		}
+	}
+
+	res := &proto.ListUnspentResponse{Total: int32(len(all))}
+	for _, utxo := range all {
+		res.TotalAmount += utxo.Amount
+	}
+	if s.Mempool != nil {
+		res.DustThreshold = s.Mempool.DustThreshold
+	}
+
+	start := int(req.Offset)
+	if start > len(all) {
+		start = len(all)
+	}
+	end := len(all)
+	if req.Limit > 0 && start+int(req.Limit) < end {
+		end = start + int(req.Limit)
+	}
+	res.Utxos = all[start:end]
+	if end < len(all) {
+		res.NextOffset = int32(end)
+	}
+	return res, nil
+}
+
+func (s *Server) GetAddressHistory(ctx context.Context, req *proto.GetAddressHistoryRequest) (*proto.GetAddressHistoryResponse, error) {
+	if req.Offset < 0 || req.Limit < 0 {
+		return nil, fmt.Errorf("%w: offset và limit không được âm", ErrInvalidArgument)
+	}
+	pubKeyHash, err := domain.DecodeAddress(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, total, err := s.Blockchain.GetAddressHistory(pubKeyHash, int(req.Offset), int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	bestHeight := s.Blockchain.GetBestHeight()
+	res := &proto.GetAddressHistoryResponse{Total: int32(total)}
+	for _, entry := range entries {
+		res.Entries = append(res.Entries, &proto.AddressHistoryEntry{
+			TxId:          entry.TxID,
+			BlockHash:     entry.BlockHash,
+			BlockHeight:   entry.Height,
+			Direction:     entry.Direction.String(),
+			Amount:        entry.Amount,
+			Confirmations: bestHeight - entry.Height + 1,
+		})
+	}
+	if end := int(req.Offset) + len(entries); end < total {
+		res.NextOffset = int32(end)
+	}
+	return res, nil
+}
+
+func (s *Server) mempoolSpends(ctx context.Context) (map[string]bool, error) {
+	if s.Mempool == nil {
+		return nil, nil
+	}
+	return s.Mempool.SpentOutpoints(ctx)
+}
+
 func (s *Server) GetContractState(ctx context.Context, req *proto.GetContractStateRequest) (*proto.GetContractStateResponse, error) {
 	log.Printf("Nhận được yêu cầu GetState cho contract: %s, key: %s", req.ContractAddress, req.Key)
 
 	contractAddressBytes, err := hex.DecodeString(req.ContractAddress)
 	if err != nil {
-		return nil, fmt.Errorf("địa chỉ contract không hợp lệ")
+		return nil, fmt.Errorf("%w: địa chỉ contract %q", domain.ErrInvalidAddress, req.ContractAddress)
 	}
 
 	value, err := s.Blockchain.GetContractState(contractAddressBytes, []byte(req.Key))
 	if err != nil {
-		return nil, fmt.Errorf("lỗi đọc CSDL: %v", err)
+		return nil, fmt.Errorf("lỗi đọc CSDL: %w", err)
 	}
 
 	if value == nil {
@@ -167,3 +371,63 @@ func (s *Server) GetContractState(ctx context.Context, req *proto.GetContractSta
 
 	return &proto.GetContractStateResponse{Value: string(value)}, nil
 }
+
+func (s *Server) GetTransaction(ctx context.Context, req *proto.GetTransactionRequest) (*proto.GetTransactionResponse, error) {
+	tx, block, err := s.Blockchain.FindTransactionWithBlock(req.TxId)
+	if err == nil {
+		return &proto.GetTransactionResponse{
+			Transaction:    MapDomainTransactionToProto(&tx),
+			Pending:        false,
+			BlockHash:      block.Hash,
+			BlockHeight:    block.Height,
+			BlockTimestamp: block.Timestamp,
+			RawHex:         tx.RawHex(),
+			Fee:            s.Blockchain.TransactionFee(&tx),
+			Confirmations:  s.Blockchain.GetBestHeight() - block.Height + 1,
+			Inputs:         s.resolveInputs(&tx, nil),
+		}, nil
+	}
+	if !errors.Is(err, domain.ErrTxNotFound) {
+		return nil, err
+	}
+
+	if s.Mempool != nil {
+		entry, err := s.Mempool.Get(ctx, req.TxId)
+		if err != nil {
+			return nil, fmt.Errorf("lỗi đọc mempool: %v", err)
+		}
+		if entry != nil {
+			parents, err := s.Mempool.Parents(ctx, entry.Tx)
+			if err != nil {
+				return nil, fmt.Errorf("lỗi đọc mempool: %v", err)
+			}
+			return &proto.GetTransactionResponse{
+				Transaction: MapDomainTransactionToProto(entry.Tx),
+				Pending:     true,
+				RawHex:      entry.Tx.RawHex(),
+				Fee:         entry.Fee,
+				Inputs:      s.resolveInputs(entry.Tx, parents),
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %x", domain.ErrTxNotFound, req.TxId)
+}
+
+func (s *Server) GetSupply(ctx context.Context, req *proto.EmptyRequest) (*proto.GetSupplyResponse, error) {
+	emission := s.Blockchain.Emission
+	height := s.Blockchain.GetBestHeight()
+	issued, err := s.Blockchain.GetSupply()
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.GetSupplyResponse{
+		Issued:           issued,
+		MaxSupply:        emission.MaxSupply,
+		Height:           height,
+		NextBlockReward:  emission.BlockReward(height + 1),
+		HalvingInterval:  emission.HalvingInterval,
+		CoinbaseMaturity: emission.CoinbaseMaturity,
+	}, nil
+}
@@ -0,0 +1,114 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+func TestAcceptTransactionRejectsReplacementWithBadSignature(t *testing.T) {
+	ctx := context.Background()
+	s, alice := newTestServer(t)
+	bob := testutil.NewWallet(t)
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+
+	original := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, bob.GetAddress(), 90))
+	ack, err := s.acceptTransaction(ctx, original)
+	if err != nil || !ack.Success {
+		t.Fatalf("giao dịch gốc bị từ chối: %v %v", ack, err)
+	}
+
+	attacker := testutil.NewWallet(t)
+	replacement := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, attacker.GetAddress(), 50))
+	replacement.Vin[0].Signature[len(replacement.Vin[0].Signature)-1] ^= 0xff
+	ack, err = s.acceptTransaction(ctx, replacement)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ack.Success {
+		t.Fatalf("giao dịch thay thế có chữ ký sai vẫn được nhận: %s", ack.Message)
+	}
+
+	entry, err := s.Mempool.Get(ctx, original.ID)
+	if err != nil || entry == nil || !bytes.Equal(entry.Tx.ID, original.ID) {
+		t.Fatalf("giao dịch gốc không còn trong mempool: %v %v", entry, err)
+	}
+	if entry, _ := s.Mempool.Get(ctx, replacement.ID); entry != nil {
+		t.Fatal("giao dịch thay thế có chữ ký sai đã vào mempool")
+	}
+}
+
+func TestAcceptTransactionRejectsUnsignedInput(t *testing.T) {
+	s, alice := newTestServer(t)
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+
+	tx := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, alice.GetAddress(), 90))
+	tx.Vin[0].Signature = nil
+	ack, err := s.acceptTransaction(context.Background(), tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ack.Success {
+		t.Fatal("giao dịch không có chữ ký vẫn được nhận")
+	}
+}
+
+func TestAcceptTransactionRejectsDust(t *testing.T) {
+	ctx := context.Background()
+	s, alice := newTestServer(t)
+	s.Mempool.DustThreshold = 10
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+
+	dusty := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, alice.GetAddress(), 90), testutil.PayTo(t, alice.GetAddress(), 9))
+	if ack, err := s.acceptTransaction(ctx, dusty); err != nil || ack.Success {
+		t.Fatalf("output 9 dưới ngưỡng dust 10 nhưng vẫn được nhận: %v %v", ack, err)
+	}
+	clean := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, alice.GetAddress(), 89), testutil.PayTo(t, alice.GetAddress(), 10))
+	if ack, err := s.acceptTransaction(ctx, clean); err != nil || !ack.Success {
+		t.Fatalf("output đúng bằng ngưỡng dust phải được nhận: %v %v", ack, err)
+	}
+}
+
+func TestListUnspentPages(t *testing.T) {
+	ctx := context.Background()
+	s, alice := newTestServer(t)
+	s.Mempool.DustThreshold = 7
+	for i := 0; i < 4; i++ {
+		testutil.MineBlock(t, s.Blockchain, alice)
+	}
+
+	var seen int
+	req := &proto.ListUnspentRequest{Address: alice.GetAddress(), Limit: 2}
+	for page := 0; ; page++ {
+		res, err := s.ListUnspent(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Total != 5 || res.DustThreshold != 7 {
+			t.Fatalf("trang %d: total %d, dust %d", page, res.Total, res.DustThreshold)
+		}
+		if len(res.Utxos) > 2 {
+			t.Fatalf("trang %d có %d UTXO, vượt limit 2", page, len(res.Utxos))
+		}
+		for _, utxo := range res.Utxos {
+			if utxo.Confirmations != s.Blockchain.GetBestHeight()-utxo.Height+1 || !utxo.Coinbase || !utxo.Spendable {
+				t.Fatalf("UTXO sai: %+v", utxo)
+			}
+		}
+		seen += len(res.Utxos)
+		if res.NextOffset == 0 {
+			break
+		}
+		req.Offset = res.NextOffset
+	}
+	if seen != 5 {
+		t.Fatalf("duyệt được %d UTXO qua các trang, muốn 5", seen)
+	}
+
+	if _, err := s.ListUnspent(ctx, &proto.ListUnspentRequest{Address: "không-phải-địa-chỉ"}); err == nil {
+		t.Fatal("địa chỉ không hợp lệ phải lỗi")
+	}
+}
@@ -0,0 +1,258 @@
+package network
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/proto"
+)
+
+const (
+	txStatusPending   = "pending"
+	txStatusIncluded  = "included"
+	txStatusConfirmed = "confirmed"
+	txStatusReplaced  = "replaced"
+
+	addressEventBalance = "balance"
+)
+
+func (s *PublicServer) subscribe() (<-chan domain.ChainEvent, func(), error) {
+	if s.Blockchain.Events == nil {
+		return nil, nil, ErrEventBusDisabled
+	}
+	events, cancel := s.Blockchain.Events.Subscribe()
+	return events, cancel, nil
+}
+
+func (s *PublicServer) SubscribeNewBlocks(req *proto.EmptyRequest, stream proto.PublicService_SubscribeNewBlocksServer) error {
+	events, cancel, err := s.subscribe()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return domain.ErrSubscriberLagging
+			}
+			if event.Kind != domain.EventBlockConnected {
+				continue
+			}
+			if err := stream.Send(blockSummary(event.Block, event.Block.Height)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *PublicServer) SubscribeAddress(req *proto.SubscribeAddressRequest, stream proto.PublicService_SubscribeAddressServer) error {
+	pubKeyHash, err := domain.DecodeAddress(req.Address)
+	if err != nil {
+		return err
+	}
+
+	events, cancel, err := s.subscribe()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	ctx := stream.Context()
+	gs := &Server{Blockchain: s.Blockchain, Mempool: s.Mempool}
+	balance, err := gs.balanceOf(ctx, pubKeyHash)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&proto.AddressEvent{Direction: addressEventBalance, Balance: balance}); err != nil {
+		return err
+	}
+
+	for {
+		var event domain.ChainEvent
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return domain.ErrSubscriberLagging
+			}
+			event = e
+		}
+
+		var activity []*proto.AddressEvent
+		switch event.Kind {
+		case domain.EventTxAccepted:
+			parents, err := s.Mempool.Parents(ctx, event.Tx)
+			if err != nil {
+				return fmt.Errorf("lỗi đọc mempool: %v", err)
+			}
+			if e := gs.addressActivity(event.Tx, parents, pubKeyHash); e != nil {
+				e.Pending = true
+				activity = append(activity, e)
+			}
+		case domain.EventBlockConnected:
+			for _, tx := range event.Block.Transactions {
+				if e := gs.addressActivity(tx, nil, pubKeyHash); e != nil {
+					e.BlockHeight = event.Block.Height
+					activity = append(activity, e)
+				}
+			}
+		}
+
+		current, err := gs.balanceOf(ctx, pubKeyHash)
+		if err != nil {
+			return err
+		}
+		if len(activity) == 0 && current.Balance == balance.Balance && current.Confirmed == balance.Confirmed &&
+			current.Unconfirmed == balance.Unconfirmed && current.Locked == balance.Locked {
+			continue
+		}
+		balance = current
+
+		if len(activity) == 0 {
+			activity = append(activity, &proto.AddressEvent{Direction: addressEventBalance})
+		}
+		for _, e := range activity {
+			e.Balance = balance
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) addressActivity(tx *domain.Transaction, pending map[string]*domain.Transaction, pubKeyHash []byte) *proto.AddressEvent {
+	touched := false
+	var received, outputTotal int64
+	for _, out := range tx.Vout {
+		outputTotal += out.Value
+		if bytes.Equal(out.PubKeyHash, pubKeyHash) {
+			received += out.Value
+			touched = true
+		}
+	}
+	for _, vin := range tx.Vin {
+		if len(vin.PublicKey) > 0 && bytes.Equal(domain.HashPubKey(vin.PublicKey), pubKeyHash) {
+			touched = true
+		}
+	}
+	if !touched {
+		return nil
+	}
+
+	var sent int64
+	for _, input := range s.resolveInputs(tx, pending) {
+		if bytes.Equal(input.PubKeyHash, pubKeyHash) {
+			sent += input.Amount
+		}
+	}
+
+	direction := domain.HistorySent
+	switch {
+	case sent == 0:
+		direction = domain.HistoryReceived
+	case received == outputTotal:
+		direction = domain.HistorySelf
+	}
+	return &proto.AddressEvent{
+		Direction: direction.String(),
+		TxId:      tx.ID,
+		Amount:    received - sent,
+	}
+}
+
+func (s *PublicServer) WatchTransaction(req *proto.WatchTransactionRequest, stream proto.PublicService_WatchTransactionServer) error {
+	target := int64(req.Confirmations)
+	if target <= 0 {
+		target = 1
+	}
+
+	events, cancel, err := s.subscribe()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	ctx := stream.Context()
+	status := &proto.TransactionStatus{TxId: req.TxId}
+	tx, block, err := s.Blockchain.FindTransactionWithBlock(req.TxId)
+	if err == nil {
+		status.BlockHash = block.Hash
+		status.BlockHeight = block.Height
+		status.Confirmations = s.Blockchain.GetBestHeight() - block.Height + 1
+		status.Fee = s.Blockchain.TransactionFee(&tx)
+	} else {
+		var entry *MempoolEntry
+		if s.Mempool != nil {
+			entry, err = s.Mempool.Get(ctx, req.TxId)
+			if err != nil {
+				return fmt.Errorf("lỗi đọc mempool: %v", err)
+			}
+		}
+		if entry == nil {
+			return fmt.Errorf("không tìm thấy giao dịch: %x", req.TxId)
+		}
+		status.Fee = entry.Fee
+	}
+
+	for {
+		switch {
+		case status.Status == txStatusReplaced:
+		case status.BlockHash == nil:
+			status.Status = txStatusPending
+		case status.Confirmations < target:
+			status.Status = txStatusIncluded
+		default:
+			status.Status = txStatusConfirmed
+		}
+		if err := stream.Send(status); err != nil {
+			return err
+		}
+		if status.Status == txStatusReplaced || status.Status == txStatusConfirmed {
+			return nil
+		}
+
+		changed := false
+		for !changed {
+			select {
+			case <-ctx.Done():
+				return nil
+			case event, ok := <-events:
+				if !ok {
+					return domain.ErrSubscriberLagging
+				}
+				changed = applyTxEvent(status, event)
+			}
+		}
+	}
+}
+
+func applyTxEvent(status *proto.TransactionStatus, event domain.ChainEvent) bool {
+	switch event.Kind {
+	case domain.EventTxReplaced:
+		if status.BlockHash == nil && bytes.Equal(event.Tx.ID, status.TxId) {
+			status.Status = txStatusReplaced
+			status.ReplacedBy = event.ReplacedBy
+			return true
+		}
+	case domain.EventBlockConnected:
+		if status.BlockHash != nil {
+			status.Confirmations = event.Block.Height - status.BlockHeight + 1
+			return true
+		}
+		for _, tx := range event.Block.Transactions {
+			if bytes.Equal(tx.ID, status.TxId) {
+				status.BlockHash = event.Block.Hash
+				status.BlockHeight = event.Block.Height
+				status.Confirmations = 1
+				return true
+			}
+		}
+	}
+	return false
+}
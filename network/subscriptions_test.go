@@ -0,0 +1,210 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+	"github.com/khoahotran/gochain-ledger/proto"
+	"google.golang.org/grpc"
+)
+
+// testStream thay cho stream gRPC phía server: Send đẩy message vào channel để test đọc.
+type testStream[T any] struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *T
+}
+
+func (s *testStream[T]) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testStream[T]) Send(m *T) error {
+	s.sent <- m
+	return nil
+}
+
+func newTestStream[T any](t *testing.T) (*testStream[T], chan error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return &testStream[T]{ctx: ctx, sent: make(chan *T, 16)}, make(chan error, 1)
+}
+
+func recvWithin[T any](t *testing.T, stream *testStream[T], timeout time.Duration) *T {
+	t.Helper()
+	select {
+	case m := <-stream.sent:
+		return m
+	case <-time.After(timeout):
+		return nil
+	}
+}
+
+func recv[T any](t *testing.T, stream *testStream[T]) *T {
+	t.Helper()
+	m := recvWithin(t, stream, 2*time.Second)
+	if m == nil {
+		t.Fatal("hết thời gian chờ message từ stream")
+	}
+	return m
+}
+
+func newEventTestServer(t *testing.T) (*Server, *PublicServer, *domain.Wallet) {
+	t.Helper()
+	s, w := newTestServer(t)
+	bus := domain.NewEventBus()
+	s.Blockchain.Events = bus
+	s.Mempool.Events = bus
+	return s, &PublicServer{Blockchain: s.Blockchain, Mempool: s.Mempool}, w
+}
+
+func TestSubscribeRequiresEventBus(t *testing.T) {
+	s, _ := newTestServer(t)
+	ps := &PublicServer{Blockchain: s.Blockchain, Mempool: s.Mempool}
+	stream, _ := newTestStream[proto.BlockSummary](t)
+	if err := ps.SubscribeNewBlocks(&proto.EmptyRequest{}, stream); !errors.Is(err, ErrEventBusDisabled) {
+		t.Fatalf("chưa có event bus: lỗi %v, muốn ErrEventBusDisabled", err)
+	}
+}
+
+func TestSubscribeNewBlocks(t *testing.T) {
+	s, ps, alice := newEventTestServer(t)
+	stream, done := newTestStream[proto.BlockSummary](t)
+	go func() { done <- ps.SubscribeNewBlocks(&proto.EmptyRequest{}, stream) }()
+
+	// Stream không gửi gì khi mới mở, nên đào block cho tới khi subscriber đã đăng ký xong.
+	var first *proto.BlockSummary
+	for i := 0; i < 20 && first == nil; i++ {
+		testutil.MineBlock(t, s.Blockchain, alice)
+		first = recvWithin(t, stream, 100*time.Millisecond)
+	}
+	if first == nil {
+		t.Fatal("không nhận được block mới nào")
+	}
+
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+	tx := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, alice.GetAddress(), 90))
+	if ack, err := s.acceptTransaction(context.Background(), tx); err != nil || !ack.Success {
+		t.Fatalf("giao dịch bị từ chối: %v %v", ack, err)
+	}
+	block := testutil.MineBlock(t, s.Blockchain, alice, tx)
+
+	next := recv(t, stream)
+	if next.Height != block.Height || !bytes.Equal(next.Hash, block.Hash) || next.TxCount != 2 || next.Confirmations != 1 {
+		t.Fatalf("block mới: %+v, muốn height %d (sự kiện mempool không được gửi lên stream này)", next, block.Height)
+	}
+}
+
+func TestSubscribeAddress(t *testing.T) {
+	ctx := context.Background()
+	s, ps, alice := newEventTestServer(t)
+	bob := testutil.NewWallet(t)
+	stream, done := newTestStream[proto.AddressEvent](t)
+	go func() { done <- ps.SubscribeAddress(&proto.SubscribeAddressRequest{Address: bob.GetAddress()}, stream) }()
+
+	initial := recv(t, stream)
+	if initial.Direction != addressEventBalance || initial.Balance.Balance != 0 {
+		t.Fatalf("sự kiện đầu tiên phải là số dư hiện tại: %+v", initial)
+	}
+
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+	tx := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, bob.GetAddress(), 60), testutil.PayTo(t, alice.GetAddress(), 30))
+	if ack, err := s.acceptTransaction(ctx, tx); err != nil || !ack.Success {
+		t.Fatalf("giao dịch bị từ chối: %v %v", ack, err)
+	}
+	pending := recv(t, stream)
+	if !pending.Pending || pending.Direction != "received" || pending.Amount != 60 || !bytes.Equal(pending.TxId, tx.ID) ||
+		pending.Balance.Unconfirmed != 60 || pending.Balance.Confirmed != 0 {
+		t.Fatalf("khoản nhận chưa xác nhận: %+v", pending)
+	}
+
+	block := testutil.MineBlock(t, s.Blockchain, alice, tx)
+	confirmed := recv(t, stream)
+	if confirmed.Pending || confirmed.BlockHeight != block.Height || confirmed.Amount != 60 || confirmed.Balance.Confirmed != 60 {
+		t.Fatalf("khoản nhận đã vào block: %+v", confirmed)
+	}
+
+	if m := recvWithin(t, stream, 100*time.Millisecond); m != nil {
+		t.Fatalf("không được gửi sự kiện thừa: %+v", m)
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("stream kết thúc sớm: %v", err)
+	default:
+	}
+}
+
+func TestWatchTransactionUntilConfirmed(t *testing.T) {
+	ctx := context.Background()
+	s, ps, alice := newEventTestServer(t)
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+	tx := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, alice.GetAddress(), 90))
+	if ack, err := s.acceptTransaction(ctx, tx); err != nil || !ack.Success {
+		t.Fatalf("giao dịch bị từ chối: %v %v", ack, err)
+	}
+
+	stream, done := newTestStream[proto.TransactionStatus](t)
+	go func() {
+		done <- ps.WatchTransaction(&proto.WatchTransactionRequest{TxId: tx.ID, Confirmations: 2}, stream)
+	}()
+
+	if status := recv(t, stream); status.Status != txStatusPending || status.Fee != 10 {
+		t.Fatalf("trạng thái ban đầu: %+v", status)
+	}
+	block := testutil.MineBlock(t, s.Blockchain, alice, tx)
+	if status := recv(t, stream); status.Status != txStatusIncluded || status.Confirmations != 1 || !bytes.Equal(status.BlockHash, block.Hash) {
+		t.Fatalf("sau block đầu tiên: %+v", status)
+	}
+	testutil.MineBlock(t, s.Blockchain, alice)
+	if status := recv(t, stream); status.Status != txStatusConfirmed || status.Confirmations != 2 {
+		t.Fatalf("sau block thứ hai: %+v", status)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream phải kết thúc khi đủ số xác nhận")
+	}
+}
+
+func TestWatchTransactionReplaced(t *testing.T) {
+	ctx := context.Background()
+	s, ps, alice := newEventTestServer(t)
+	coinbase := testutil.GenesisCoinbase(t, s.Blockchain)
+	original := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, alice.GetAddress(), 90))
+	if ack, err := s.acceptTransaction(ctx, original); err != nil || !ack.Success {
+		t.Fatalf("giao dịch bị từ chối: %v %v", ack, err)
+	}
+
+	stream, done := newTestStream[proto.TransactionStatus](t)
+	go func() { done <- ps.WatchTransaction(&proto.WatchTransactionRequest{TxId: original.ID}, stream) }()
+	if status := recv(t, stream); status.Status != txStatusPending {
+		t.Fatalf("trạng thái ban đầu: %+v", status)
+	}
+
+	replacement := testutil.Spend(t, s.Blockchain, alice, coinbase.ID, 0, testutil.PayTo(t, alice.GetAddress(), 80))
+	if ack, err := s.acceptTransaction(ctx, replacement); err != nil || !ack.Success {
+		t.Fatalf("giao dịch thay thế bị từ chối: %v %v", ack, err)
+	}
+	status := recv(t, stream)
+	if status.Status != txStatusReplaced || !bytes.Equal(status.ReplacedBy, replacement.ID) {
+		t.Fatalf("sau RBF: %+v", status)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	unknown, _ := newTestStream[proto.TransactionStatus](t)
+	if err := ps.WatchTransaction(&proto.WatchTransactionRequest{TxId: []byte("không-có")}, unknown); err == nil {
+		t.Fatal("theo dõi giao dịch không tồn tại phải lỗi")
+	}
+}
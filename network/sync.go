@@ -0,0 +1,298 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/proto"
+	"github.com/khoahotran/gochain-ledger/vm"
+)
+
+func (s *Server) acceptBlock(ctx context.Context, block *domain.Block) error {
+	chainMu.Lock()
+	defer chainMu.Unlock()
+
+	bc := s.Blockchain
+	if bc.HasBlock(block.Hash) {
+		return nil
+	}
+	if err := ConnectBlock(bc, block); err != nil {
+		return err
+	}
+	log.Printf("Sync: Đã nối block %x (chiều cao %d, %d TX)", block.Hash, block.Height, len(block.Transactions))
+
+	if s.Mempool != nil {
+		if err := s.Mempool.RemoveBlock(ctx, block); err != nil {
+			log.Printf("Sync: Lỗi dọn dẹp mempool: %v", err)
+		}
+	}
+	return nil
+}
+
+func ConnectBlock(bc *domain.Blockchain, block *domain.Block) error {
+	if err := bc.CheckBlockHeader(block); err != nil {
+		return err
+	}
+
+	verifyCtx := domain.VerifyContext{
+		Height:  block.Height,
+		Time:    block.Timestamp,
+		Pending: make(map[string]*domain.Transaction),
+	}
+	spentOutpoints := make(map[string]bool)
+	var totalFees int64
+
+	for _, tx := range block.Transactions {
+		if !bytes.Equal(tx.ID, tx.ComputeID()) {
+			return fmt.Errorf("TX %x: ID không khớp với nội dung", tx.ID)
+		}
+		if tx.IsCoinbase() {
+			continue
+		}
+		if err := bc.CheckLocks(tx, verifyCtx); err != nil {
+			return fmt.Errorf("TX %x: %v", tx.ID, err)
+		}
+		fee, err := bc.CheckInputs(tx, verifyCtx, spentOutpoints)
+		if err != nil {
+			return fmt.Errorf("TX %x: %v", tx.ID, err)
+		}
+		prevTxs, err := bc.ReferencedOutputs(tx, verifyCtx.Pending)
+		if err != nil {
+			return err
+		}
+		if err := tx.Verify(prevTxs, verifyCtx); err != nil {
+			return fmt.Errorf("TX %x: %w", tx.ID, err)
+		}
+		totalFees += fee
+		verifyCtx.Pending[string(tx.ID)] = tx
+	}
+
+	if maxReward := bc.Emission.BlockReward(block.Height) + totalFees; block.CoinbaseValue() > maxReward {
+		return fmt.Errorf("coinbase %d vượt quá phần thưởng tối đa %d", block.CoinbaseValue(), maxReward)
+	}
+
+	state := domain.NewStateBatch(bc)
+	for _, tx := range block.Transactions {
+		if err := ApplyContract(state, tx); err != nil {
+			return fmt.Errorf("TX %x: %v", tx.ID, err)
+		}
+	}
+
+	return bc.ConnectBlock(block, state)
+}
+
+func ApplyContract(state *domain.StateBatch, tx *domain.Transaction) error {
+	switch tx.Type {
+	case domain.TxTypeContractDeploy:
+		senderPubKeyHash := domain.HashPubKey(tx.Vin[0].PublicKey)
+		if err := executeVM(state, tx.Payload, tx.ID, senderPubKeyHash, "", nil); err != nil {
+			return err
+		}
+		state.SetContractCode(tx.ID, tx.Payload)
+		return nil
+
+	case domain.TxTypeContractCall:
+		payload, err := vm.ParseCallPayload(tx.Payload)
+		if err != nil {
+			return err
+		}
+		contractAddress, err := hex.DecodeString(payload.ContractAddress)
+		if err != nil {
+			return err
+		}
+		code, err := state.GetContractCode(contractAddress)
+		if err != nil {
+			return err
+		}
+		senderPubKeyHash := domain.HashPubKey(tx.Vin[0].PublicKey)
+		return executeVM(state, code, contractAddress, senderPubKeyHash, payload.FunctionName, vm.ConvertArgsToLValues(payload.Args))
+	}
+	return nil
+}
+
+func (s *Server) Sync(ctx context.Context) {
+	for _, addr := range s.Peers.Addresses() {
+		if err := s.syncFrom(ctx, addr); err != nil {
+			log.Printf("Sync: Không thể đồng bộ từ %s: %v", addr, err)
+		}
+	}
+}
+
+func (s *Server) syncFrom(ctx context.Context, addr string) error {
+	client, err := s.Peers.client(addr)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := client.GetBlocks(ctx, &proto.GetBlocksRequest{})
+	if err != nil {
+		return err
+	}
+
+	var missing []*domain.Block
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return fmt.Errorf("chain của peer không chung genesis")
+		}
+		if err != nil {
+			return err
+		}
+		block := MapProtoBlockToDomain(res)
+		known, err := s.Blockchain.InMainChain(block.Hash)
+		if err != nil {
+			return err
+		}
+		if known {
+			break
+		}
+		missing = append(missing, block)
+	}
+	cancel()
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	log.Printf("Sync: Tải %d block từ %s", len(missing), addr)
+	branch := make([]*domain.Block, 0, len(missing))
+	for i := len(missing) - 1; i >= 0; i-- {
+		branch = append(branch, missing[i])
+	}
+	return s.acceptBranch(ctx, branch)
+}
+
+// acceptBranch nối các block tải từ peer (xếp từ cũ tới mới, block đầu nối vào một block của chain chính).
+// Nếu điểm rẽ nhánh không phải tip, node chỉ chuyển sang nhánh mới khi nó nặng hơn chain hiện tại;
+// mọi block dùng cùng độ khó nên nhánh nặng hơn là nhánh cao hơn.
+func (s *Server) acceptBranch(ctx context.Context, branch []*domain.Block) error {
+	chainMu.Lock()
+	bc := s.Blockchain
+	if tip := branch[len(branch)-1]; tip.Height <= bc.GetBestHeight() {
+		chainMu.Unlock()
+		log.Printf("Sync: Nhánh tới block %x (chiều cao %d) không nặng hơn chain hiện tại, giữ tip %x", tip.Hash, tip.Height, bc.LastHash)
+		return nil
+	}
+	disconnected, connected, err := reorganize(bc, branch)
+	chainMu.Unlock()
+
+	if len(disconnected) > 0 {
+		log.Printf("Sync: Reorg: gỡ %d block, nối %d block, tip mới %x (chiều cao %d)", len(disconnected), len(connected), bc.LastHash, bc.GetBestHeight())
+	}
+	if s.Mempool != nil {
+		s.resyncMempool(ctx, disconnected, connected)
+	}
+	return err
+}
+
+// reorganize gỡ chain chính về điểm rẽ nhánh rồi nối branch. Nếu một block của branch không hợp lệ,
+// phần đã nối chỉ được giữ khi nó vẫn nặng hơn chain cũ; ngược lại chain cũ được nối lại.
+func reorganize(bc *domain.Blockchain, branch []*domain.Block) (disconnected, connected []*domain.Block, err error) {
+	fork := branch[0].PrevBlockHash
+	oldHeight := bc.GetBestHeight()
+
+	disconnected, err = disconnectTo(bc, fork)
+	if err != nil {
+		return nil, nil, restoreChain(bc, bc.LastHash, disconnected, err)
+	}
+	for _, block := range branch {
+		if err = ConnectBlock(bc, block); err != nil {
+			err = fmt.Errorf("block %x không hợp lệ: %v", block.Hash, err)
+			break
+		}
+		log.Printf("Sync: Đã nối block %x (chiều cao %d, %d TX)", block.Hash, block.Height, len(block.Transactions))
+		connected = append(connected, block)
+	}
+	if err == nil || bc.GetBestHeight() > oldHeight {
+		return disconnected, connected, err
+	}
+	return nil, nil, restoreChain(bc, fork, disconnected, err)
+}
+
+// disconnectTo gỡ các block ở tip cho tới khi tip là fork và trả về các block đã gỡ, mới nhất trước.
+func disconnectTo(bc *domain.Blockchain, fork []byte) ([]*domain.Block, error) {
+	onMain, err := bc.InMainChain(fork)
+	if err != nil {
+		return nil, err
+	}
+	if !onMain {
+		return nil, fmt.Errorf("%w: điểm rẽ nhánh %x không thuộc chain chính", domain.ErrBlockNotOnTip, fork)
+	}
+
+	var disconnected []*domain.Block
+	for !bytes.Equal(bc.LastHash, fork) {
+		block, err := bc.DisconnectTip()
+		if err != nil {
+			return disconnected, err
+		}
+		disconnected = append(disconnected, block)
+	}
+	return disconnected, nil
+}
+
+// restoreChain đưa tip về base rồi nối lại các block đã gỡ của chain cũ, trả về cause kèm lỗi khôi phục nếu có.
+func restoreChain(bc *domain.Blockchain, base []byte, disconnected []*domain.Block, cause error) error {
+	if _, err := disconnectTo(bc, base); err != nil {
+		return fmt.Errorf("%v; không thể khôi phục chain cũ: %v", cause, err)
+	}
+	for i := len(disconnected) - 1; i >= 0; i-- {
+		if err := ConnectBlock(bc, disconnected[i]); err != nil {
+			return fmt.Errorf("%v; không thể nối lại block %x: %v", cause, disconnected[i].Hash, err)
+		}
+	}
+	return cause
+}
+
+// resyncMempool bỏ các giao dịch đã vào block mới khỏi mempool và đưa giao dịch của các block bị gỡ
+// (trừ coinbase và giao dịch đã có trong nhánh mới) trở lại mempool.
+func (s *Server) resyncMempool(ctx context.Context, disconnected, connected []*domain.Block) {
+	confirmed := make(map[string]bool)
+	for _, block := range connected {
+		for _, tx := range block.Transactions {
+			confirmed[string(tx.ID)] = true
+		}
+		if err := s.Mempool.RemoveBlock(ctx, block); err != nil {
+			log.Printf("Sync: Lỗi dọn dẹp mempool: %v", err)
+		}
+	}
+	for i := len(disconnected) - 1; i >= 0; i-- {
+		for _, tx := range disconnected[i].Transactions {
+			if tx.IsCoinbase() || confirmed[string(tx.ID)] {
+				continue
+			}
+			if _, err := s.acceptTransaction(ctx, tx); err != nil {
+				log.Printf("Sync: Không thể đưa TX %x của block bị gỡ trở lại mempool: %v", tx.ID, err)
+			}
+		}
+	}
+}
+
+func (s *Server) announcedBlock(ctx context.Context, block *domain.Block) (*proto.Ack, error) {
+	if s.Blockchain.HasBlock(block.Hash) {
+		return &proto.Ack{Success: true, Message: "Block đã có"}, nil
+	}
+
+	err := s.acceptBlock(ctx, block)
+	if errors.Is(err, domain.ErrBlockNotOnTip) {
+		if block.Height > s.Blockchain.GetBestHeight() {
+			log.Printf("Block %x (chiều cao %d) chưa nối được vào tip, đồng bộ lại từ các peer...", block.Hash, block.Height)
+			go s.Sync(context.Background())
+			return &proto.Ack{Success: true, Message: "Đang đồng bộ"}, nil
+		}
+		log.Printf("Bỏ qua block %x ở nhánh rẽ (chiều cao %d)", block.Hash, block.Height)
+		return &proto.Ack{Success: false, Message: "Block thuộc nhánh rẽ"}, nil
+	}
+	if err != nil {
+		log.Printf("Từ chối block %x: %v", block.Hash, err)
+		return &proto.Ack{Success: false, Message: fmt.Sprintf("Block không hợp lệ: %v", err)}, nil
+	}
+	return &proto.Ack{Success: true, Message: "Đã nối Block"}, nil
+}
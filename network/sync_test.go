@@ -0,0 +1,200 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/internal/testutil"
+)
+
+func TestConnectBlockUsesBlockTimeForLockTime(t *testing.T) {
+	bc, alice := testutil.NewChain(t, nil)
+	coinbase := testutil.GenesisCoinbase(t, bc)
+
+	lockTime := time.Now().Add(-time.Hour).Unix()
+	tx := &domain.Transaction{
+		Vin:      []domain.TxInput{{TxID: coinbase.ID, VoutIndex: 0, PublicKey: alice.PublicKey, Sequence: domain.SequenceFinal - 1}},
+		Vout:     []domain.TxOutput{testutil.PayTo(t, alice.GetAddress(), 90)},
+		LockTime: lockTime,
+	}
+	tx.SetID()
+	prevTxs, err := bc.FindReferencedTxs(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Sign(alice.PrivateKey, prevTxs); err != nil {
+		t.Fatal(err)
+	}
+
+	early := buildBlock(t, bc, alice, lockTime-600, tx)
+	if err := ConnectBlock(bc, early); err == nil {
+		t.Fatal("block có timestamp trước locktime vẫn được nối dù giờ hệ thống đã qua locktime")
+	}
+
+	onTime := buildBlock(t, bc, alice, lockTime, tx)
+	if err := ConnectBlock(bc, onTime); err != nil {
+		t.Fatalf("block có timestamp bằng locktime bị từ chối: %v", err)
+	}
+}
+
+func TestConnectBlockRejectsBadTimestamps(t *testing.T) {
+	bc, alice := testutil.NewChain(t, nil)
+	tip, err := bc.GetBlock(bc.LastHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	future := buildBlock(t, bc, alice, time.Now().Add(3*time.Hour).Unix())
+	if err := ConnectBlock(bc, future); !errors.Is(err, domain.ErrInvalidBlock) {
+		t.Fatalf("block ở tương lai xa: lỗi %v, muốn ErrInvalidBlock", err)
+	}
+
+	beforeParent := buildBlock(t, bc, alice, tip.Timestamp-1)
+	if err := ConnectBlock(bc, beforeParent); !errors.Is(err, domain.ErrInvalidBlock) {
+		t.Fatalf("block sớm hơn block cha: lỗi %v, muốn ErrInvalidBlock", err)
+	}
+}
+
+func TestAnnouncedCompetingBlockKeepsCurrentTip(t *testing.T) {
+	s, alice := newTestServer(t)
+	bob := testutil.NewWallet(t)
+
+	now := time.Now().Unix()
+	ours := buildBlock(t, s.Blockchain, alice, now)
+	theirs := buildBlock(t, s.Blockchain, bob, now)
+	if err := ConnectBlock(s.Blockchain, ours); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ConnectBlock(s.Blockchain, theirs); !errors.Is(err, domain.ErrBlockNotOnTip) {
+		t.Fatalf("block cạnh tranh: lỗi %v, muốn ErrBlockNotOnTip", err)
+	}
+	ack, err := s.announcedBlock(context.Background(), theirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ack.Success {
+		t.Fatalf("block ở nhánh rẽ được chấp nhận: %s", ack.Message)
+	}
+	if !bytes.Equal(s.Blockchain.LastHash, ours.Hash) || s.Blockchain.HasBlock(theirs.Hash) {
+		t.Fatal("tip thay đổi sau khi nhận block cạnh tranh")
+	}
+}
+
+func TestConnectBlockRejectsExcessCoinbase(t *testing.T) {
+	bc, alice := testutil.NewChain(t, nil)
+	height := bc.GetBestHeight() + 1
+
+	for _, extra := range []int64{1, 0} {
+		coinbase, err := domain.NewCoinbaseTransaction(alice.GetAddress(), bc.Emission.BlockReward(height)+extra, height)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block := &domain.Block{
+			Timestamp:     time.Now().Unix(),
+			PrevBlockHash: bc.LastHash,
+			Transactions:  []*domain.Transaction{coinbase},
+			Height:        height,
+		}
+		block.Nonce, block.Hash = domain.NewProofOfWork(block).Run()
+
+		err = ConnectBlock(bc, block)
+		if extra > 0 && err == nil {
+			t.Fatal("coinbase vượt phần thưởng (không có phí) phải bị từ chối")
+		}
+		if extra == 0 && err != nil {
+			t.Fatalf("coinbase đúng phần thưởng bị từ chối: %v", err)
+		}
+	}
+}
+
+// invalidBlock dựng block kế tiếp của bc có coinbase vượt phần thưởng nhưng vẫn đủ PoW.
+func invalidBlock(t *testing.T, bc *domain.Blockchain, to *domain.Wallet) *domain.Block {
+	t.Helper()
+	block := buildBlock(t, bc, to, time.Now().Unix())
+	coinbase := block.Transactions[0]
+	coinbase.Vout[0].Value++
+	coinbase.SetID()
+	block.Nonce, block.Hash = domain.NewProofOfWork(block).Run()
+	return block
+}
+
+func TestAcceptBranchReorganizesToHeavierBranch(t *testing.T) {
+	ctx := context.Background()
+	s, alice := newTestServer(t)
+	bob := testutil.NewWallet(t)
+	other := testutil.Fork(t, alice)
+
+	genesis := testutil.GenesisCoinbase(t, s.Blockchain)
+	tx := testutil.Spend(t, s.Blockchain, alice, genesis.ID, 0, testutil.PayTo(t, bob.GetAddress(), 60))
+	ours := testutil.MineBlock(t, s.Blockchain, alice, tx)
+	b1 := testutil.MineBlock(t, other, bob)
+	b2 := testutil.MineBlock(t, other, bob)
+
+	if err := s.acceptBranch(ctx, []*domain.Block{b1}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(s.Blockchain.LastHash, ours.Hash) {
+		t.Fatal("nhánh cao bằng chain hiện tại không được thay tip")
+	}
+
+	if err := s.acceptBranch(ctx, []*domain.Block{b1, b2}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(s.Blockchain.LastHash, b2.Hash) || s.Blockchain.GetBestHeight() != 2 {
+		t.Fatalf("tip %x (chiều cao %d), muốn nhánh nặng hơn %x", s.Blockchain.LastHash, s.Blockchain.GetBestHeight(), b2.Hash)
+	}
+	if onMain, _ := s.Blockchain.InMainChain(ours.Hash); onMain {
+		t.Fatal("block của nhánh bị gỡ vẫn thuộc chain chính")
+	}
+	if entry, err := s.Mempool.Get(ctx, tx.ID); err != nil || entry == nil {
+		t.Fatalf("TX của block bị gỡ phải trở lại mempool: %v", err)
+	}
+	if report, err := s.Blockchain.Verify(domain.VerifyOptions{Full: true}); err != nil || !report.OK() {
+		t.Fatalf("chain sau reorg không nhất quán: %v %v", report, err)
+	}
+}
+
+func TestAcceptBranchWithInvalidBlock(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nhánh hợp lệ còn lại không nặng hơn", func(t *testing.T) {
+		s, alice := newTestServer(t)
+		other := testutil.Fork(t, alice)
+		testutil.MineBlock(t, s.Blockchain, alice)
+		tip := testutil.MineBlock(t, s.Blockchain, alice)
+
+		b1 := testutil.MineBlock(t, other, alice)
+		bad := invalidBlock(t, other, alice)
+		after := &domain.Block{Height: bad.Height + 1, PrevBlockHash: bad.Hash}
+		if err := s.acceptBranch(ctx, []*domain.Block{b1, bad, after}); err == nil {
+			t.Fatal("nhánh chứa block không hợp lệ phải báo lỗi")
+		}
+		if !bytes.Equal(s.Blockchain.LastHash, tip.Hash) || s.Blockchain.GetBestHeight() != 2 {
+			t.Fatalf("chain cũ phải được khôi phục, tip %x", s.Blockchain.LastHash)
+		}
+		if report, err := s.Blockchain.Verify(domain.VerifyOptions{Full: true}); err != nil || !report.OK() {
+			t.Fatalf("chain sau khi khôi phục không nhất quán: %v %v", report, err)
+		}
+	})
+
+	t.Run("nhánh hợp lệ còn lại nặng hơn", func(t *testing.T) {
+		s, alice := newTestServer(t)
+		other := testutil.Fork(t, alice)
+		testutil.MineBlock(t, s.Blockchain, alice)
+
+		b1 := testutil.MineBlock(t, other, alice)
+		b2 := testutil.MineBlock(t, other, alice)
+		bad := invalidBlock(t, other, alice)
+		if err := s.acceptBranch(ctx, []*domain.Block{b1, b2, bad}); err == nil {
+			t.Fatal("nhánh chứa block không hợp lệ phải báo lỗi")
+		}
+		if !bytes.Equal(s.Blockchain.LastHash, b2.Hash) {
+			t.Fatalf("phần hợp lệ nặng hơn phải được giữ, tip %x", s.Blockchain.LastHash)
+		}
+	})
+}
@@ -21,6 +21,8 @@ type TxInput struct {
 	VoutIndex     int32                  `protobuf:"varint,2,opt,name=vout_index,json=voutIndex,proto3" json:"vout_index,omitempty"`
 	Signature     []byte                 `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
 	PublicKey     []byte                 `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Witness       [][]byte               `protobuf:"bytes,5,rep,name=witness,proto3" json:"witness,omitempty"`
+	Sequence      uint32                 `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,10 +84,25 @@ func (x *TxInput) GetPublicKey() []byte {
 	return nil
 }
 
+func (x *TxInput) GetWitness() [][]byte {
+	if x != nil {
+		return x.Witness
+	}
+	return nil
+}
+
+func (x *TxInput) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
 type TxOutput struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Value         int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
 	PubKeyHash    []byte                 `protobuf:"bytes,2,opt,name=pub_key_hash,json=pubKeyHash,proto3" json:"pub_key_hash,omitempty"`
+	Script        []byte                 `protobuf:"bytes,3,opt,name=script,proto3" json:"script,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -133,6 +150,13 @@ func (x *TxOutput) GetPubKeyHash() []byte {
 	return nil
 }
 
+func (x *TxOutput) GetScript() []byte {
+	if x != nil {
+		return x.Script
+	}
+	return nil
+}
+
 type Transaction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -140,6 +164,7 @@ type Transaction struct {
 	Vout          []*TxOutput            `protobuf:"bytes,3,rep,name=vout,proto3" json:"vout,omitempty"`
 	Type          int32                  `protobuf:"varint,4,opt,name=type,proto3" json:"type,omitempty"`
 	Payload       []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	LockTime      int64                  `protobuf:"varint,6,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -208,6 +233,13 @@ func (x *Transaction) GetPayload() []byte {
 	return nil
 }
 
+func (x *Transaction) GetLockTime() int64 {
+	if x != nil {
+		return x.LockTime
+	}
+	return 0
+}
+
 type Block struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
@@ -215,6 +247,7 @@ type Block struct {
 	Hash          []byte                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
 	Transactions  []*Transaction         `protobuf:"bytes,4,rep,name=transactions,proto3" json:"transactions,omitempty"`
 	Nonce         int64                  `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Height        int64                  `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -283,12 +316,21 @@ func (x *Block) GetNonce() int64 {
 	return 0
 }
 
+func (x *Block) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
 type FindSpendableUTXOsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Address            string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Amount             int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	IncludeUnconfirmed bool                   `protobuf:"varint,3,opt,name=include_unconfirmed,json=includeUnconfirmed,proto3" json:"include_unconfirmed,omitempty"`
+	ListAll            bool                   `protobuf:"varint,4,opt,name=list_all,json=listAll,proto3" json:"list_all,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *FindSpendableUTXOsRequest) Reset() {
@@ -334,12 +376,27 @@ func (x *FindSpendableUTXOsRequest) GetAmount() int64 {
 	return 0
 }
 
+func (x *FindSpendableUTXOsRequest) GetIncludeUnconfirmed() bool {
+	if x != nil {
+		return x.IncludeUnconfirmed
+	}
+	return false
+}
+
+func (x *FindSpendableUTXOsRequest) GetListAll() bool {
+	if x != nil {
+		return x.ListAll
+	}
+	return false
+}
+
 type SpendableUTXO struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TxId          []byte                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
 	VoutIndex     int32                  `protobuf:"varint,2,opt,name=vout_index,json=voutIndex,proto3" json:"vout_index,omitempty"`
 	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
 	PubKeyHash    []byte                 `protobuf:"bytes,4,opt,name=pub_key_hash,json=pubKeyHash,proto3" json:"pub_key_hash,omitempty"`
+	Unconfirmed   bool                   `protobuf:"varint,5,opt,name=unconfirmed,proto3" json:"unconfirmed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -401,6 +458,13 @@ func (x *SpendableUTXO) GetPubKeyHash() []byte {
 	return nil
 }
 
+func (x *SpendableUTXO) GetUnconfirmed() bool {
+	if x != nil {
+		return x.Unconfirmed
+	}
+	return false
+}
+
 type FindSpendableUTXOsResponse struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	AccumulatedAmount int64                  `protobuf:"varint,1,opt,name=accumulated_amount,json=accumulatedAmount,proto3" json:"accumulated_amount,omitempty"`
@@ -671,6 +735,9 @@ func (x *GetBalanceRequest) GetAddress() string {
 type GetBalanceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Balance       int64                  `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	Confirmed     int64                  `protobuf:"varint,2,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	Unconfirmed   int64                  `protobuf:"varint,3,opt,name=unconfirmed,proto3" json:"unconfirmed,omitempty"`
+	Locked        int64                  `protobuf:"varint,4,opt,name=locked,proto3" json:"locked,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -711,6 +778,27 @@ func (x *GetBalanceResponse) GetBalance() int64 {
 	return 0
 }
 
+func (x *GetBalanceResponse) GetConfirmed() int64 {
+	if x != nil {
+		return x.Confirmed
+	}
+	return 0
+}
+
+func (x *GetBalanceResponse) GetUnconfirmed() int64 {
+	if x != nil {
+		return x.Unconfirmed
+	}
+	return 0
+}
+
+func (x *GetBalanceResponse) GetLocked() int64 {
+	if x != nil {
+		return x.Locked
+	}
+	return 0
+}
+
 type GetContractStateRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	ContractAddress string                 `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
@@ -805,129 +893,2092 @@ func (x *GetContractStateResponse) GetValue() string {
 	return ""
 }
 
-var File_proto_blockchain_proto protoreflect.FileDescriptor
+type GetTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          []byte                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_blockchain_proto_rawDesc = "" +
-	"\n" +
-	"\x16proto/blockchain.proto\x12\x05proto\"z\n" +
-	"\aTxInput\x12\x13\n" +
-	"\x05tx_id\x18\x01 \x01(\fR\x04txId\x12\x1d\n" +
-	"\n" +
-	"vout_index\x18\x02 \x01(\x05R\tvoutIndex\x12\x1c\n" +
-	"\tsignature\x18\x03 \x01(\fR\tsignature\x12\x1d\n" +
-	"\n" +
-	"public_key\x18\x04 \x01(\fR\tpublicKey\"B\n" +
-	"\bTxOutput\x12\x14\n" +
-	"\x05value\x18\x01 \x01(\x03R\x05value\x12 \n" +
-	"\fpub_key_hash\x18\x02 \x01(\fR\n" +
-	"pubKeyHash\"\x92\x01\n" +
-	"\vTransaction\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\fR\x02id\x12 \n" +
-	"\x03vin\x18\x02 \x03(\v2\x0e.proto.TxInputR\x03vin\x12#\n" +
-	"\x04vout\x18\x03 \x03(\v2\x0f.proto.TxOutputR\x04vout\x12\x12\n" +
-	"\x04type\x18\x04 \x01(\x05R\x04type\x12\x18\n" +
-	"\apayload\x18\x05 \x01(\fR\apayload\"\xaf\x01\n" +
-	"\x05Block\x12\x1c\n" +
-	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12&\n" +
-	"\x0fprev_block_hash\x18\x02 \x01(\fR\rprevBlockHash\x12\x12\n" +
-	"\x04hash\x18\x03 \x01(\fR\x04hash\x126\n" +
-	"\ftransactions\x18\x04 \x03(\v2\x12.proto.TransactionR\ftransactions\x12\x14\n" +
-	"\x05nonce\x18\x05 \x01(\x03R\x05nonce\"M\n" +
-	"\x19FindSpendableUTXOsRequest\x12\x18\n" +
-	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x03R\x06amount\"}\n" +
-	"\rSpendableUTXO\x12\x13\n" +
-	"\x05tx_id\x18\x01 \x01(\fR\x04txId\x12\x1d\n" +
-	"\n" +
-	"vout_index\x18\x02 \x01(\x05R\tvoutIndex\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12 \n" +
-	"\fpub_key_hash\x18\x04 \x01(\fR\n" +
-	"pubKeyHash\"w\n" +
-	"\x1aFindSpendableUTXOsResponse\x12-\n" +
-	"\x12accumulated_amount\x18\x01 \x01(\x03R\x11accumulatedAmount\x12*\n" +
-	"\x05utxos\x18\x02 \x03(\v2\x14.proto.SpendableUTXOR\x05utxos\"9\n" +
-	"\x03Ack\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"/\n" +
-	"\x10GetBlocksRequest\x12\x1b\n" +
-	"\tfrom_hash\x18\x01 \x01(\fR\bfromHash\"\x0e\n" +
-	"\fEmptyRequest\"2\n" +
-	"\x12KnownNodesResponse\x12\x1c\n" +
-	"\taddresses\x18\x01 \x03(\tR\taddresses\"-\n" +
-	"\x11GetBalanceRequest\x12\x18\n" +
-	"\aaddress\x18\x01 \x01(\tR\aaddress\".\n" +
-	"\x12GetBalanceResponse\x12\x18\n" +
-	"\abalance\x18\x01 \x01(\x03R\abalance\"V\n" +
-	"\x17GetContractStateRequest\x12)\n" +
-	"\x10contract_address\x18\x01 \x01(\tR\x0fcontractAddress\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\tR\x03key\"0\n" +
-	"\x18GetContractStateResponse\x12\x14\n" +
-	"\x05value\x18\x01 \x01(\tR\x05value2\xd5\x03\n" +
-	"\vNodeService\x121\n" +
-	"\x0fSendTransaction\x12\x12.proto.Transaction\x1a\n" +
-	".proto.Ack\x12)\n" +
-	"\rAnnounceBlock\x12\f.proto.Block\x1a\n" +
-	".proto.Ack\x124\n" +
-	"\tGetBlocks\x12\x17.proto.GetBlocksRequest\x1a\f.proto.Block0\x01\x12?\n" +
-	"\rGetKnownNodes\x12\x13.proto.EmptyRequest\x1a\x19.proto.KnownNodesResponse\x12A\n" +
-	"\n" +
-	"GetBalance\x12\x18.proto.GetBalanceRequest\x1a\x19.proto.GetBalanceResponse\x12Y\n" +
-	"\x12FindSpendableUTXOs\x12 .proto.FindSpendableUTXOsRequest\x1a!.proto.FindSpendableUTXOsResponse\x12S\n" +
-	"\x10GetContractState\x12\x1e.proto.GetContractStateRequest\x1a\x1f.proto.GetContractStateResponseB\tZ\a./protob\x06proto3"
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_proto_blockchain_proto_rawDescOnce sync.Once
-	file_proto_blockchain_proto_rawDescData []byte
-)
+func (x *GetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_proto_blockchain_proto_rawDescGZIP() []byte {
-	file_proto_blockchain_proto_rawDescOnce.Do(func() {
-		file_proto_blockchain_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_blockchain_proto_rawDesc), len(file_proto_blockchain_proto_rawDesc)))
-	})
-	return file_proto_blockchain_proto_rawDescData
+func (*GetTransactionRequest) ProtoMessage() {}
+
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_proto_blockchain_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
-var file_proto_blockchain_proto_goTypes = []any{
-	(*TxInput)(nil),
-	(*TxOutput)(nil),
-	(*Transaction)(nil),
-	(*Block)(nil),
-	(*FindSpendableUTXOsRequest)(nil),
-	(*SpendableUTXO)(nil),
-	(*FindSpendableUTXOsResponse)(nil),
-	(*Ack)(nil),
-	(*GetBlocksRequest)(nil),
-	(*EmptyRequest)(nil),
-	(*KnownNodesResponse)(nil),
-	(*GetBalanceRequest)(nil),
-	(*GetBalanceResponse)(nil),
-	(*GetContractStateRequest)(nil),
-	(*GetContractStateResponse)(nil),
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{15}
 }
-var file_proto_blockchain_proto_depIdxs = []int32{
-	0,
-	1,
-	2,
-	5,
-	2,
-	3,
-	8,
-	9,
-	11,
-	4,
-	13,
-	7,
-	7,
-	3,
-	10,
-	12,
-	6,
-	14,
-	11,
-	4,
-	4,
-	4,
+
+func (x *GetTransactionRequest) GetTxId() []byte {
+	if x != nil {
+		return x.TxId
+	}
+	return nil
+}
+
+type GetTransactionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Transaction    *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Pending        bool                   `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"`
+	BlockHash      []byte                 `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockHeight    int64                  `protobuf:"varint,4,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	RawHex         string                 `protobuf:"bytes,5,opt,name=raw_hex,json=rawHex,proto3" json:"raw_hex,omitempty"`
+	Fee            int64                  `protobuf:"varint,6,opt,name=fee,proto3" json:"fee,omitempty"`
+	Confirmations  int64                  `protobuf:"varint,7,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Inputs         []*ResolvedInput       `protobuf:"bytes,8,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	BlockTimestamp int64                  `protobuf:"varint,9,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetTransactionResponse) Reset() {
+	*x = GetTransactionResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionResponse) ProtoMessage() {}
+
+func (x *GetTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*GetTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetTransactionResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *GetTransactionResponse) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *GetTransactionResponse) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *GetTransactionResponse) GetBlockHeight() int64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *GetTransactionResponse) GetRawHex() string {
+	if x != nil {
+		return x.RawHex
+	}
+	return ""
+}
+
+func (x *GetTransactionResponse) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *GetTransactionResponse) GetConfirmations() int64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *GetTransactionResponse) GetInputs() []*ResolvedInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *GetTransactionResponse) GetBlockTimestamp() int64 {
+	if x != nil {
+		return x.BlockTimestamp
+	}
+	return 0
+}
+
+type ResolvedInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          []byte                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	VoutIndex     int32                  `protobuf:"varint,2,opt,name=vout_index,json=voutIndex,proto3" json:"vout_index,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	PubKeyHash    []byte                 `protobuf:"bytes,4,opt,name=pub_key_hash,json=pubKeyHash,proto3" json:"pub_key_hash,omitempty"`
+	Address       string                 `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvedInput) Reset() {
+	*x = ResolvedInput{}
+	mi := &file_proto_blockchain_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvedInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvedInput) ProtoMessage() {}
+
+func (x *ResolvedInput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*ResolvedInput) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ResolvedInput) GetTxId() []byte {
+	if x != nil {
+		return x.TxId
+	}
+	return nil
+}
+
+func (x *ResolvedInput) GetVoutIndex() int32 {
+	if x != nil {
+		return x.VoutIndex
+	}
+	return 0
+}
+
+func (x *ResolvedInput) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ResolvedInput) GetPubKeyHash() []byte {
+	if x != nil {
+		return x.PubKeyHash
+	}
+	return nil
+}
+
+func (x *ResolvedInput) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type GetSupplyResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Issued           int64                  `protobuf:"varint,1,opt,name=issued,proto3" json:"issued,omitempty"`
+	MaxSupply        int64                  `protobuf:"varint,2,opt,name=max_supply,json=maxSupply,proto3" json:"max_supply,omitempty"`
+	Height           int64                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	NextBlockReward  int64                  `protobuf:"varint,4,opt,name=next_block_reward,json=nextBlockReward,proto3" json:"next_block_reward,omitempty"`
+	HalvingInterval  int64                  `protobuf:"varint,5,opt,name=halving_interval,json=halvingInterval,proto3" json:"halving_interval,omitempty"`
+	CoinbaseMaturity int64                  `protobuf:"varint,6,opt,name=coinbase_maturity,json=coinbaseMaturity,proto3" json:"coinbase_maturity,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetSupplyResponse) Reset() {
+	*x = GetSupplyResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSupplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSupplyResponse) ProtoMessage() {}
+
+func (x *GetSupplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*GetSupplyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetSupplyResponse) GetIssued() int64 {
+	if x != nil {
+		return x.Issued
+	}
+	return 0
+}
+
+func (x *GetSupplyResponse) GetMaxSupply() int64 {
+	if x != nil {
+		return x.MaxSupply
+	}
+	return 0
+}
+
+func (x *GetSupplyResponse) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetSupplyResponse) GetNextBlockReward() int64 {
+	if x != nil {
+		return x.NextBlockReward
+	}
+	return 0
+}
+
+func (x *GetSupplyResponse) GetHalvingInterval() int64 {
+	if x != nil {
+		return x.HalvingInterval
+	}
+	return 0
+}
+
+func (x *GetSupplyResponse) GetCoinbaseMaturity() int64 {
+	if x != nil {
+		return x.CoinbaseMaturity
+	}
+	return 0
+}
+
+type RawTransaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hex           string                 `protobuf:"bytes,1,opt,name=hex,proto3" json:"hex,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RawTransaction) Reset() {
+	*x = RawTransaction{}
+	mi := &file_proto_blockchain_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RawTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RawTransaction) ProtoMessage() {}
+
+func (x *RawTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*RawTransaction) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RawTransaction) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+type ListUnspentRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Address            string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Offset             int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit              int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	IncludeUnconfirmed bool                   `protobuf:"varint,4,opt,name=include_unconfirmed,json=includeUnconfirmed,proto3" json:"include_unconfirmed,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListUnspentRequest) Reset() {
+	*x = ListUnspentRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUnspentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUnspentRequest) ProtoMessage() {}
+
+func (x *ListUnspentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*ListUnspentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListUnspentRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListUnspentRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListUnspentRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUnspentRequest) GetIncludeUnconfirmed() bool {
+	if x != nil {
+		return x.IncludeUnconfirmed
+	}
+	return false
+}
+
+type UnspentOutput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          []byte                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	VoutIndex     int32                  `protobuf:"varint,2,opt,name=vout_index,json=voutIndex,proto3" json:"vout_index,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Height        int64                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Confirmations int64                  `protobuf:"varint,5,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Coinbase      bool                   `protobuf:"varint,6,opt,name=coinbase,proto3" json:"coinbase,omitempty"`
+	Spendable     bool                   `protobuf:"varint,7,opt,name=spendable,proto3" json:"spendable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnspentOutput) Reset() {
+	*x = UnspentOutput{}
+	mi := &file_proto_blockchain_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnspentOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnspentOutput) ProtoMessage() {}
+
+func (x *UnspentOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*UnspentOutput) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UnspentOutput) GetTxId() []byte {
+	if x != nil {
+		return x.TxId
+	}
+	return nil
+}
+
+func (x *UnspentOutput) GetVoutIndex() int32 {
+	if x != nil {
+		return x.VoutIndex
+	}
+	return 0
+}
+
+func (x *UnspentOutput) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *UnspentOutput) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *UnspentOutput) GetConfirmations() int64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *UnspentOutput) GetCoinbase() bool {
+	if x != nil {
+		return x.Coinbase
+	}
+	return false
+}
+
+func (x *UnspentOutput) GetSpendable() bool {
+	if x != nil {
+		return x.Spendable
+	}
+	return false
+}
+
+type ListUnspentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Utxos         []*UnspentOutput       `protobuf:"bytes,1,rep,name=utxos,proto3" json:"utxos,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	TotalAmount   int64                  `protobuf:"varint,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	NextOffset    int32                  `protobuf:"varint,4,opt,name=next_offset,json=nextOffset,proto3" json:"next_offset,omitempty"`
+	DustThreshold int64                  `protobuf:"varint,5,opt,name=dust_threshold,json=dustThreshold,proto3" json:"dust_threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUnspentResponse) Reset() {
+	*x = ListUnspentResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUnspentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUnspentResponse) ProtoMessage() {}
+
+func (x *ListUnspentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*ListUnspentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListUnspentResponse) GetUtxos() []*UnspentOutput {
+	if x != nil {
+		return x.Utxos
+	}
+	return nil
+}
+
+func (x *ListUnspentResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListUnspentResponse) GetTotalAmount() int64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *ListUnspentResponse) GetNextOffset() int32 {
+	if x != nil {
+		return x.NextOffset
+	}
+	return 0
+}
+
+func (x *ListUnspentResponse) GetDustThreshold() int64 {
+	if x != nil {
+		return x.DustThreshold
+	}
+	return 0
+}
+
+type GetAddressHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressHistoryRequest) Reset() {
+	*x = GetAddressHistoryRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressHistoryRequest) ProtoMessage() {}
+
+func (x *GetAddressHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*GetAddressHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetAddressHistoryRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetAddressHistoryRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetAddressHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AddressHistoryEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          []byte                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	BlockHash     []byte                 `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockHeight   int64                  `protobuf:"varint,3,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Direction     string                 `protobuf:"bytes,4,opt,name=direction,proto3" json:"direction,omitempty"`
+	Amount        int64                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Confirmations int64                  `protobuf:"varint,6,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressHistoryEntry) Reset() {
+	*x = AddressHistoryEntry{}
+	mi := &file_proto_blockchain_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressHistoryEntry) ProtoMessage() {}
+
+func (x *AddressHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*AddressHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AddressHistoryEntry) GetTxId() []byte {
+	if x != nil {
+		return x.TxId
+	}
+	return nil
+}
+
+func (x *AddressHistoryEntry) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *AddressHistoryEntry) GetBlockHeight() int64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *AddressHistoryEntry) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *AddressHistoryEntry) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AddressHistoryEntry) GetConfirmations() int64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+type GetAddressHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AddressHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextOffset    int32                  `protobuf:"varint,3,opt,name=next_offset,json=nextOffset,proto3" json:"next_offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressHistoryResponse) Reset() {
+	*x = GetAddressHistoryResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressHistoryResponse) ProtoMessage() {}
+
+func (x *GetAddressHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*GetAddressHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetAddressHistoryResponse) GetEntries() []*AddressHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetAddressHistoryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetAddressHistoryResponse) GetNextOffset() int32 {
+	if x != nil {
+		return x.NextOffset
+	}
+	return 0
+}
+
+type GetChainInfoResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TipHash         []byte                 `protobuf:"bytes,1,opt,name=tip_hash,json=tipHash,proto3" json:"tip_hash,omitempty"`
+	Height          int64                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	TipTimestamp    int64                  `protobuf:"varint,3,opt,name=tip_timestamp,json=tipTimestamp,proto3" json:"tip_timestamp,omitempty"`
+	Difficulty      int64                  `protobuf:"varint,4,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Issued          int64                  `protobuf:"varint,5,opt,name=issued,proto3" json:"issued,omitempty"`
+	MaxSupply       int64                  `protobuf:"varint,6,opt,name=max_supply,json=maxSupply,proto3" json:"max_supply,omitempty"`
+	NextBlockReward int64                  `protobuf:"varint,7,opt,name=next_block_reward,json=nextBlockReward,proto3" json:"next_block_reward,omitempty"`
+	MempoolSize     int32                  `protobuf:"varint,8,opt,name=mempool_size,json=mempoolSize,proto3" json:"mempool_size,omitempty"`
+	TxIndex         bool                   `protobuf:"varint,9,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	Network         string                 `protobuf:"bytes,10,opt,name=network,proto3" json:"network,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetChainInfoResponse) Reset() {
+	*x = GetChainInfoResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChainInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChainInfoResponse) ProtoMessage() {}
+
+func (x *GetChainInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*GetChainInfoResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetChainInfoResponse) GetTipHash() []byte {
+	if x != nil {
+		return x.TipHash
+	}
+	return nil
+}
+
+func (x *GetChainInfoResponse) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetChainInfoResponse) GetTipTimestamp() int64 {
+	if x != nil {
+		return x.TipTimestamp
+	}
+	return 0
+}
+
+func (x *GetChainInfoResponse) GetDifficulty() int64 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
+func (x *GetChainInfoResponse) GetIssued() int64 {
+	if x != nil {
+		return x.Issued
+	}
+	return 0
+}
+
+func (x *GetChainInfoResponse) GetMaxSupply() int64 {
+	if x != nil {
+		return x.MaxSupply
+	}
+	return 0
+}
+
+func (x *GetChainInfoResponse) GetNextBlockReward() int64 {
+	if x != nil {
+		return x.NextBlockReward
+	}
+	return 0
+}
+
+func (x *GetChainInfoResponse) GetMempoolSize() int32 {
+	if x != nil {
+		return x.MempoolSize
+	}
+	return 0
+}
+
+func (x *GetChainInfoResponse) GetTxIndex() bool {
+	if x != nil {
+		return x.TxIndex
+	}
+	return false
+}
+
+func (x *GetChainInfoResponse) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+type BlockSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          []byte                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	PrevBlockHash []byte                 `protobuf:"bytes,2,opt,name=prev_block_hash,json=prevBlockHash,proto3" json:"prev_block_hash,omitempty"`
+	Height        int64                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Nonce         int64                  `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	TxCount       int32                  `protobuf:"varint,6,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
+	Size          int32                  `protobuf:"varint,7,opt,name=size,proto3" json:"size,omitempty"`
+	Confirmations int64                  `protobuf:"varint,8,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Reward        int64                  `protobuf:"varint,9,opt,name=reward,proto3" json:"reward,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockSummary) Reset() {
+	*x = BlockSummary{}
+	mi := &file_proto_blockchain_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockSummary) ProtoMessage() {}
+
+func (x *BlockSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*BlockSummary) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BlockSummary) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *BlockSummary) GetPrevBlockHash() []byte {
+	if x != nil {
+		return x.PrevBlockHash
+	}
+	return nil
+}
+
+func (x *BlockSummary) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *BlockSummary) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *BlockSummary) GetNonce() int64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *BlockSummary) GetTxCount() int32 {
+	if x != nil {
+		return x.TxCount
+	}
+	return 0
+}
+
+func (x *BlockSummary) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BlockSummary) GetConfirmations() int64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *BlockSummary) GetReward() int64 {
+	if x != nil {
+		return x.Reward
+	}
+	return 0
+}
+
+type GetBlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          []byte                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height        int64                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockRequest) Reset() {
+	*x = GetBlockRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRequest) ProtoMessage() {}
+
+func (x *GetBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetBlockRequest) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *GetBlockRequest) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type GetBlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summary       *BlockSummary          `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	Block         *Block                 `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockResponse) Reset() {
+	*x = GetBlockResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockResponse) ProtoMessage() {}
+
+func (x *GetBlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*GetBlockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetBlockResponse) GetSummary() *BlockSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *GetBlockResponse) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+type ListBlocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        int32                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlocksRequest) Reset() {
+	*x = ListBlocksRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlocksRequest) ProtoMessage() {}
+
+func (x *ListBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*ListBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListBlocksRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListBlocksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListBlocksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        []*BlockSummary        `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextOffset    int32                  `protobuf:"varint,3,opt,name=next_offset,json=nextOffset,proto3" json:"next_offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlocksResponse) Reset() {
+	*x = ListBlocksResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlocksResponse) ProtoMessage() {}
+
+func (x *ListBlocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*ListBlocksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListBlocksResponse) GetBlocks() []*BlockSummary {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *ListBlocksResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListBlocksResponse) GetNextOffset() int32 {
+	if x != nil {
+		return x.NextOffset
+	}
+	return 0
+}
+
+type MempoolTransaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          []byte                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Fee           int64                  `protobuf:"varint,2,opt,name=fee,proto3" json:"fee,omitempty"`
+	AddedAt       int64                  `protobuf:"varint,3,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	Size          int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	InputCount    int32                  `protobuf:"varint,5,opt,name=input_count,json=inputCount,proto3" json:"input_count,omitempty"`
+	OutputCount   int32                  `protobuf:"varint,6,opt,name=output_count,json=outputCount,proto3" json:"output_count,omitempty"`
+	OutputTotal   int64                  `protobuf:"varint,7,opt,name=output_total,json=outputTotal,proto3" json:"output_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MempoolTransaction) Reset() {
+	*x = MempoolTransaction{}
+	mi := &file_proto_blockchain_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MempoolTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MempoolTransaction) ProtoMessage() {}
+
+func (x *MempoolTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*MempoolTransaction) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MempoolTransaction) GetTxId() []byte {
+	if x != nil {
+		return x.TxId
+	}
+	return nil
+}
+
+func (x *MempoolTransaction) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *MempoolTransaction) GetAddedAt() int64 {
+	if x != nil {
+		return x.AddedAt
+	}
+	return 0
+}
+
+func (x *MempoolTransaction) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *MempoolTransaction) GetInputCount() int32 {
+	if x != nil {
+		return x.InputCount
+	}
+	return 0
+}
+
+func (x *MempoolTransaction) GetOutputCount() int32 {
+	if x != nil {
+		return x.OutputCount
+	}
+	return 0
+}
+
+func (x *MempoolTransaction) GetOutputTotal() int64 {
+	if x != nil {
+		return x.OutputTotal
+	}
+	return 0
+}
+
+type GetMempoolResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*MempoolTransaction  `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	TotalFees     int64                  `protobuf:"varint,3,opt,name=total_fees,json=totalFees,proto3" json:"total_fees,omitempty"`
+	TotalSize     int64                  `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMempoolResponse) Reset() {
+	*x = GetMempoolResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMempoolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMempoolResponse) ProtoMessage() {}
+
+func (x *GetMempoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*GetMempoolResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetMempoolResponse) GetTransactions() []*MempoolTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *GetMempoolResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetMempoolResponse) GetTotalFees() int64 {
+	if x != nil {
+		return x.TotalFees
+	}
+	return 0
+}
+
+func (x *GetMempoolResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type SubscribeAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeAddressRequest) Reset() {
+	*x = SubscribeAddressRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeAddressRequest) ProtoMessage() {}
+
+func (x *SubscribeAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*SubscribeAddressRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SubscribeAddressRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type AddressEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Direction     string                 `protobuf:"bytes,1,opt,name=direction,proto3" json:"direction,omitempty"`
+	TxId          []byte                 `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Pending       bool                   `protobuf:"varint,4,opt,name=pending,proto3" json:"pending,omitempty"`
+	BlockHeight   int64                  `protobuf:"varint,5,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Balance       *GetBalanceResponse    `protobuf:"bytes,6,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressEvent) Reset() {
+	*x = AddressEvent{}
+	mi := &file_proto_blockchain_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressEvent) ProtoMessage() {}
+
+func (x *AddressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*AddressEvent) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AddressEvent) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *AddressEvent) GetTxId() []byte {
+	if x != nil {
+		return x.TxId
+	}
+	return nil
+}
+
+func (x *AddressEvent) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AddressEvent) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *AddressEvent) GetBlockHeight() int64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *AddressEvent) GetBalance() *GetBalanceResponse {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+type WatchTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          []byte                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Confirmations int32                  `protobuf:"varint,2,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTransactionRequest) Reset() {
+	*x = WatchTransactionRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTransactionRequest) ProtoMessage() {}
+
+func (x *WatchTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*WatchTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *WatchTransactionRequest) GetTxId() []byte {
+	if x != nil {
+		return x.TxId
+	}
+	return nil
+}
+
+func (x *WatchTransactionRequest) GetConfirmations() int32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+type TransactionStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          []byte                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	BlockHash     []byte                 `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockHeight   int64                  `protobuf:"varint,4,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Confirmations int64                  `protobuf:"varint,5,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Fee           int64                  `protobuf:"varint,6,opt,name=fee,proto3" json:"fee,omitempty"`
+	ReplacedBy    []byte                 `protobuf:"bytes,7,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionStatus) Reset() {
+	*x = TransactionStatus{}
+	mi := &file_proto_blockchain_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionStatus) ProtoMessage() {}
+
+func (x *TransactionStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*TransactionStatus) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *TransactionStatus) GetTxId() []byte {
+	if x != nil {
+		return x.TxId
+	}
+	return nil
+}
+
+func (x *TransactionStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TransactionStatus) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *TransactionStatus) GetBlockHeight() int64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *TransactionStatus) GetConfirmations() int64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *TransactionStatus) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *TransactionStatus) GetReplacedBy() []byte {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return nil
+}
+
+type GenerateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateRequest) Reset() {
+	*x = GenerateRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateRequest) ProtoMessage() {}
+
+func (x *GenerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*GenerateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GenerateRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GenerateRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type GenerateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockHashes   [][]byte               `protobuf:"bytes,1,rep,name=block_hashes,json=blockHashes,proto3" json:"block_hashes,omitempty"`
+	Height        int64                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateResponse) Reset() {
+	*x = GenerateResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponse) ProtoMessage() {}
+
+func (x *GenerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (*GenerateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GenerateResponse) GetBlockHashes() [][]byte {
+	if x != nil {
+		return x.BlockHashes
+	}
+	return nil
+}
+
+func (x *GenerateResponse) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+var File_proto_blockchain_proto protoreflect.FileDescriptor
+
+const file_proto_blockchain_proto_rawDesc = "" +
+	"\n" +
+	"\x16proto/blockchain.proto\x12\x05proto\"\xb0\x01\n" +
+	"\aTxInput\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\fR\x04txId\x12\x1d\n" +
+	"\n" +
+	"vout_index\x18\x02 \x01(\x05R\tvoutIndex\x12\x1c\n" +
+	"\tsignature\x18\x03 \x01(\fR\tsignature\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x04 \x01(\fR\tpublicKey\x12\x18\n" +
+	"\awitness\x18\x05 \x03(\fR\awitness\x12\x1a\n" +
+	"\bsequence\x18\x06 \x01(\rR\bsequence\"Z\n" +
+	"\bTxOutput\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x03R\x05value\x12 \n" +
+	"\fpub_key_hash\x18\x02 \x01(\fR\n" +
+	"pubKeyHash\x12\x16\n" +
+	"\x06script\x18\x03 \x01(\fR\x06script\"\xaf\x01\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12 \n" +
+	"\x03vin\x18\x02 \x03(\v2\x0e.proto.TxInputR\x03vin\x12#\n" +
+	"\x04vout\x18\x03 \x03(\v2\x0f.proto.TxOutputR\x04vout\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\x05R\x04type\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\fR\apayload\x12\x1b\n" +
+	"\tlock_time\x18\x06 \x01(\x03R\blockTime\"\xc7\x01\n" +
+	"\x05Block\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12&\n" +
+	"\x0fprev_block_hash\x18\x02 \x01(\fR\rprevBlockHash\x12\x12\n" +
+	"\x04hash\x18\x03 \x01(\fR\x04hash\x126\n" +
+	"\ftransactions\x18\x04 \x03(\v2\x12.proto.TransactionR\ftransactions\x12\x14\n" +
+	"\x05nonce\x18\x05 \x01(\x03R\x05nonce\x12\x16\n" +
+	"\x06height\x18\x06 \x01(\x03R\x06height\"\x99\x01\n" +
+	"\x19FindSpendableUTXOsRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12/\n" +
+	"\x13include_unconfirmed\x18\x03 \x01(\bR\x12includeUnconfirmed\x12\x19\n" +
+	"\blist_all\x18\x04 \x01(\bR\alistAll\"\x9f\x01\n" +
+	"\rSpendableUTXO\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\fR\x04txId\x12\x1d\n" +
+	"\n" +
+	"vout_index\x18\x02 \x01(\x05R\tvoutIndex\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12 \n" +
+	"\fpub_key_hash\x18\x04 \x01(\fR\n" +
+	"pubKeyHash\x12 \n" +
+	"\vunconfirmed\x18\x05 \x01(\bR\vunconfirmed\"w\n" +
+	"\x1aFindSpendableUTXOsResponse\x12-\n" +
+	"\x12accumulated_amount\x18\x01 \x01(\x03R\x11accumulatedAmount\x12*\n" +
+	"\x05utxos\x18\x02 \x03(\v2\x14.proto.SpendableUTXOR\x05utxos\"9\n" +
+	"\x03Ack\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"/\n" +
+	"\x10GetBlocksRequest\x12\x1b\n" +
+	"\tfrom_hash\x18\x01 \x01(\fR\bfromHash\"\x0e\n" +
+	"\fEmptyRequest\"2\n" +
+	"\x12KnownNodesResponse\x12\x1c\n" +
+	"\taddresses\x18\x01 \x03(\tR\taddresses\"-\n" +
+	"\x11GetBalanceRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"\x86\x01\n" +
+	"\x12GetBalanceResponse\x12\x18\n" +
+	"\abalance\x18\x01 \x01(\x03R\abalance\x12\x1c\n" +
+	"\tconfirmed\x18\x02 \x01(\x03R\tconfirmed\x12 \n" +
+	"\vunconfirmed\x18\x03 \x01(\x03R\vunconfirmed\x12\x16\n" +
+	"\x06locked\x18\x04 \x01(\x03R\x06locked\"V\n" +
+	"\x17GetContractStateRequest\x12)\n" +
+	"\x10contract_address\x18\x01 \x01(\tR\x0fcontractAddress\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"0\n" +
+	"\x18GetContractStateResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\",\n" +
+	"\x15GetTransactionRequest\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\fR\x04txId\"\xd2\x02\n" +
+	"\x16GetTransactionResponse\x124\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x12.proto.TransactionR\vtransaction\x12\x18\n" +
+	"\apending\x18\x02 \x01(\bR\apending\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x03 \x01(\fR\tblockHash\x12!\n" +
+	"\fblock_height\x18\x04 \x01(\x03R\vblockHeight\x12\x17\n" +
+	"\araw_hex\x18\x05 \x01(\tR\x06rawHex\x12\x10\n" +
+	"\x03fee\x18\x06 \x01(\x03R\x03fee\x12$\n" +
+	"\rconfirmations\x18\a \x01(\x03R\rconfirmations\x12,\n" +
+	"\x06inputs\x18\b \x03(\v2\x14.proto.ResolvedInputR\x06inputs\x12'\n" +
+	"\x0fblock_timestamp\x18\t \x01(\x03R\x0eblockTimestamp\"\x97\x01\n" +
+	"\rResolvedInput\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\fR\x04txId\x12\x1d\n" +
+	"\n" +
+	"vout_index\x18\x02 \x01(\x05R\tvoutIndex\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12 \n" +
+	"\fpub_key_hash\x18\x04 \x01(\fR\n" +
+	"pubKeyHash\x12\x18\n" +
+	"\aaddress\x18\x05 \x01(\tR\aaddress\"\xe6\x01\n" +
+	"\x11GetSupplyResponse\x12\x16\n" +
+	"\x06issued\x18\x01 \x01(\x03R\x06issued\x12\x1d\n" +
+	"\n" +
+	"max_supply\x18\x02 \x01(\x03R\tmaxSupply\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x03R\x06height\x12*\n" +
+	"\x11next_block_reward\x18\x04 \x01(\x03R\x0fnextBlockReward\x12)\n" +
+	"\x10halving_interval\x18\x05 \x01(\x03R\x0fhalvingInterval\x12+\n" +
+	"\x11coinbase_maturity\x18\x06 \x01(\x03R\x10coinbaseMaturity\"\"\n" +
+	"\x0eRawTransaction\x12\x10\n" +
+	"\x03hex\x18\x01 \x01(\tR\x03hex\"\x8d\x01\n" +
+	"\x12ListUnspentRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12/\n" +
+	"\x13include_unconfirmed\x18\x04 \x01(\bR\x12includeUnconfirmed\"\xd3\x01\n" +
+	"\rUnspentOutput\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\fR\x04txId\x12\x1d\n" +
+	"\n" +
+	"vout_index\x18\x02 \x01(\x05R\tvoutIndex\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x03R\x06height\x12$\n" +
+	"\rconfirmations\x18\x05 \x01(\x03R\rconfirmations\x12\x1a\n" +
+	"\bcoinbase\x18\x06 \x01(\bR\bcoinbase\x12\x1c\n" +
+	"\tspendable\x18\a \x01(\bR\tspendable\"\xc2\x01\n" +
+	"\x13ListUnspentResponse\x12*\n" +
+	"\x05utxos\x18\x01 \x03(\v2\x14.proto.UnspentOutputR\x05utxos\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12!\n" +
+	"\ftotal_amount\x18\x03 \x01(\x03R\vtotalAmount\x12\x1f\n" +
+	"\vnext_offset\x18\x04 \x01(\x05R\n" +
+	"nextOffset\x12%\n" +
+	"\x0edust_threshold\x18\x05 \x01(\x03R\rdustThreshold\"b\n" +
+	"\x18GetAddressHistoryRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xc8\x01\n" +
+	"\x13AddressHistoryEntry\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\fR\x04txId\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x02 \x01(\fR\tblockHash\x12!\n" +
+	"\fblock_height\x18\x03 \x01(\x03R\vblockHeight\x12\x1c\n" +
+	"\tdirection\x18\x04 \x01(\tR\tdirection\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x03R\x06amount\x12$\n" +
+	"\rconfirmations\x18\x06 \x01(\x03R\rconfirmations\"\x88\x01\n" +
+	"\x19GetAddressHistoryResponse\x124\n" +
+	"\aentries\x18\x01 \x03(\v2\x1a.proto.AddressHistoryEntryR\aentries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x1f\n" +
+	"\vnext_offset\x18\x03 \x01(\x05R\n" +
+	"nextOffset\"\xc9\x02\n" +
+	"\x14GetChainInfoResponse\x12\x19\n" +
+	"\btip_hash\x18\x01 \x01(\fR\atipHash\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x03R\x06height\x12#\n" +
+	"\rtip_timestamp\x18\x03 \x01(\x03R\ftipTimestamp\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x04 \x01(\x03R\n" +
+	"difficulty\x12\x16\n" +
+	"\x06issued\x18\x05 \x01(\x03R\x06issued\x12\x1d\n" +
+	"\n" +
+	"max_supply\x18\x06 \x01(\x03R\tmaxSupply\x12*\n" +
+	"\x11next_block_reward\x18\a \x01(\x03R\x0fnextBlockReward\x12!\n" +
+	"\fmempool_size\x18\b \x01(\x05R\vmempoolSize\x12\x19\n" +
+	"\btx_index\x18\t \x01(\bR\atxIndex\x12\x18\n" +
+	"\anetwork\x18\n" +
+	" \x01(\tR\anetwork\"\x83\x02\n" +
+	"\fBlockSummary\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\fR\x04hash\x12&\n" +
+	"\x0fprev_block_hash\x18\x02 \x01(\fR\rprevBlockHash\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x03R\x06height\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12\x14\n" +
+	"\x05nonce\x18\x05 \x01(\x03R\x05nonce\x12\x19\n" +
+	"\btx_count\x18\x06 \x01(\x05R\atxCount\x12\x12\n" +
+	"\x04size\x18\a \x01(\x05R\x04size\x12$\n" +
+	"\rconfirmations\x18\b \x01(\x03R\rconfirmations\x12\x16\n" +
+	"\x06reward\x18\t \x01(\x03R\x06reward\"=\n" +
+	"\x0fGetBlockRequest\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\fR\x04hash\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x03R\x06height\"e\n" +
+	"\x10GetBlockResponse\x12-\n" +
+	"\asummary\x18\x01 \x01(\v2\x13.proto.BlockSummaryR\asummary\x12\"\n" +
+	"\x05block\x18\x02 \x01(\v2\f.proto.BlockR\x05block\"A\n" +
+	"\x11ListBlocksRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"x\n" +
+	"\x12ListBlocksResponse\x12+\n" +
+	"\x06blocks\x18\x01 \x03(\v2\x13.proto.BlockSummaryR\x06blocks\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x1f\n" +
+	"\vnext_offset\x18\x03 \x01(\x05R\n" +
+	"nextOffset\"\xd1\x01\n" +
+	"\x12MempoolTransaction\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\fR\x04txId\x12\x10\n" +
+	"\x03fee\x18\x02 \x01(\x03R\x03fee\x12\x19\n" +
+	"\badded_at\x18\x03 \x01(\x03R\aaddedAt\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x05R\x04size\x12\x1f\n" +
+	"\vinput_count\x18\x05 \x01(\x05R\n" +
+	"inputCount\x12!\n" +
+	"\foutput_count\x18\x06 \x01(\x05R\voutputCount\x12!\n" +
+	"\foutput_total\x18\a \x01(\x03R\voutputTotal\"\xa7\x01\n" +
+	"\x12GetMempoolResponse\x12=\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x19.proto.MempoolTransactionR\ftransactions\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1d\n" +
+	"\n" +
+	"total_fees\x18\x03 \x01(\x03R\ttotalFees\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x04 \x01(\x03R\ttotalSize\"3\n" +
+	"\x17SubscribeAddressRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"\xcb\x01\n" +
+	"\fAddressEvent\x12\x1c\n" +
+	"\tdirection\x18\x01 \x01(\tR\tdirection\x12\x13\n" +
+	"\x05tx_id\x18\x02 \x01(\fR\x04txId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x18\n" +
+	"\apending\x18\x04 \x01(\bR\apending\x12!\n" +
+	"\fblock_height\x18\x05 \x01(\x03R\vblockHeight\x123\n" +
+	"\abalance\x18\x06 \x01(\v2\x19.proto.GetBalanceResponseR\abalance\"T\n" +
+	"\x17WatchTransactionRequest\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\fR\x04txId\x12$\n" +
+	"\rconfirmations\x18\x02 \x01(\x05R\rconfirmations\"\xdb\x01\n" +
+	"\x11TransactionStatus\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\fR\x04txId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x03 \x01(\fR\tblockHash\x12!\n" +
+	"\fblock_height\x18\x04 \x01(\x03R\vblockHeight\x12$\n" +
+	"\rconfirmations\x18\x05 \x01(\x03R\rconfirmations\x12\x10\n" +
+	"\x03fee\x18\x06 \x01(\x03R\x03fee\x12\x1f\n" +
+	"\vreplaced_by\x18\a \x01(\fR\n" +
+	"replacedBy\"A\n" +
+	"\x0fGenerateRequest\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\"M\n" +
+	"\x10GenerateResponse\x12!\n" +
+	"\fblock_hashes\x18\x01 \x03(\fR\vblockHashes\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x03R\x06height2\xf4\x06\n" +
+	"\vNodeService\x121\n" +
+	"\x0fSendTransaction\x12\x12.proto.Transaction\x1a\n" +
+	".proto.Ack\x12)\n" +
+	"\rAnnounceBlock\x12\f.proto.Block\x1a\n" +
+	".proto.Ack\x124\n" +
+	"\tGetBlocks\x12\x17.proto.GetBlocksRequest\x1a\f.proto.Block0\x01\x12?\n" +
+	"\rGetKnownNodes\x12\x13.proto.EmptyRequest\x1a\x19.proto.KnownNodesResponse\x12A\n" +
+	"\n" +
+	"GetBalance\x12\x18.proto.GetBalanceRequest\x1a\x19.proto.GetBalanceResponse\x12Y\n" +
+	"\x12FindSpendableUTXOs\x12 .proto.FindSpendableUTXOsRequest\x1a!.proto.FindSpendableUTXOsResponse\x12S\n" +
+	"\x10GetContractState\x12\x1e.proto.GetContractStateRequest\x1a\x1f.proto.GetContractStateResponse\x12M\n" +
+	"\x0eGetTransaction\x12\x1c.proto.GetTransactionRequest\x1a\x1d.proto.GetTransactionResponse\x12:\n" +
+	"\tGetSupply\x12\x13.proto.EmptyRequest\x1a\x18.proto.GetSupplyResponse\x127\n" +
+	"\x12SendRawTransaction\x12\x15.proto.RawTransaction\x1a\n" +
+	".proto.Ack\x12D\n" +
+	"\vListUnspent\x12\x19.proto.ListUnspentRequest\x1a\x1a.proto.ListUnspentResponse\x12V\n" +
+	"\x11GetAddressHistory\x12\x1f.proto.GetAddressHistoryRequest\x1a .proto.GetAddressHistoryResponse\x12;\n" +
+	"\bGenerate\x12\x16.proto.GenerateRequest\x1a\x17.proto.GenerateResponseB\tZ\a./protob\x06proto3"
+
+var (
+	file_proto_blockchain_proto_rawDescOnce sync.Once
+	file_proto_blockchain_proto_rawDescData []byte
+)
+
+func file_proto_blockchain_proto_rawDescGZIP() []byte {
+	file_proto_blockchain_proto_rawDescOnce.Do(func() {
+		file_proto_blockchain_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_blockchain_proto_rawDesc), len(file_proto_blockchain_proto_rawDesc)))
+	})
+	return file_proto_blockchain_proto_rawDescData
+}
+
+var file_proto_blockchain_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
+var file_proto_blockchain_proto_goTypes = []any{
+	(*TxInput)(nil),
+	(*TxOutput)(nil),
+	(*Transaction)(nil),
+	(*Block)(nil),
+	(*FindSpendableUTXOsRequest)(nil),
+	(*SpendableUTXO)(nil),
+	(*FindSpendableUTXOsResponse)(nil),
+	(*Ack)(nil),
+	(*GetBlocksRequest)(nil),
+	(*EmptyRequest)(nil),
+	(*KnownNodesResponse)(nil),
+	(*GetBalanceRequest)(nil),
+	(*GetBalanceResponse)(nil),
+	(*GetContractStateRequest)(nil),
+	(*GetContractStateResponse)(nil),
+	(*GetTransactionRequest)(nil),
+	(*GetTransactionResponse)(nil),
+	(*ResolvedInput)(nil),
+	(*GetSupplyResponse)(nil),
+	(*RawTransaction)(nil),
+	(*ListUnspentRequest)(nil),
+	(*UnspentOutput)(nil),
+	(*ListUnspentResponse)(nil),
+	(*GetAddressHistoryRequest)(nil),
+	(*AddressHistoryEntry)(nil),
+	(*GetAddressHistoryResponse)(nil),
+	(*GetChainInfoResponse)(nil),
+	(*BlockSummary)(nil),
+	(*GetBlockRequest)(nil),
+	(*GetBlockResponse)(nil),
+	(*ListBlocksRequest)(nil),
+	(*ListBlocksResponse)(nil),
+	(*MempoolTransaction)(nil),
+	(*GetMempoolResponse)(nil),
+	(*SubscribeAddressRequest)(nil),
+	(*AddressEvent)(nil),
+	(*WatchTransactionRequest)(nil),
+	(*TransactionStatus)(nil),
+	(*GenerateRequest)(nil),
+	(*GenerateResponse)(nil),
+}
+var file_proto_blockchain_proto_depIdxs = []int32{
+	0,
+	1,
+	2,
+	5,
+	2,
+	17,
+	21,
+	24,
+	27,
+	3,
+	27,
+	32,
+	12,
+	2,
+	3,
+	8,
+	9,
+	11,
+	4,
+	13,
+	15,
+	9,
+	19,
+	20,
+	23,
+	38,
+	7,
+	7,
+	3,
+	10,
+	12,
+	6,
+	14,
+	16,
+	18,
+	7,
+	22,
+	25,
+	39,
+	26,
+	13,
+	13,
+	13,
 	0,
 }
 
@@ -942,7 +2993,7 @@ func file_proto_blockchain_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_blockchain_proto_rawDesc), len(file_proto_blockchain_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -18,6 +18,12 @@ const (
 	NodeService_GetBalance_FullMethodName         = "/proto.NodeService/GetBalance"
 	NodeService_FindSpendableUTXOs_FullMethodName = "/proto.NodeService/FindSpendableUTXOs"
 	NodeService_GetContractState_FullMethodName   = "/proto.NodeService/GetContractState"
+	NodeService_GetTransaction_FullMethodName     = "/proto.NodeService/GetTransaction"
+	NodeService_GetSupply_FullMethodName          = "/proto.NodeService/GetSupply"
+	NodeService_SendRawTransaction_FullMethodName = "/proto.NodeService/SendRawTransaction"
+	NodeService_ListUnspent_FullMethodName        = "/proto.NodeService/ListUnspent"
+	NodeService_GetAddressHistory_FullMethodName  = "/proto.NodeService/GetAddressHistory"
+	NodeService_Generate_FullMethodName           = "/proto.NodeService/Generate"
 )
 
 type NodeServiceClient interface {
@@ -34,6 +40,18 @@ type NodeServiceClient interface {
 	FindSpendableUTXOs(ctx context.Context, in *FindSpendableUTXOsRequest, opts ...grpc.CallOption) (*FindSpendableUTXOsResponse, error)
 
 	GetContractState(ctx context.Context, in *GetContractStateRequest, opts ...grpc.CallOption) (*GetContractStateResponse, error)
+
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+
+	GetSupply(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetSupplyResponse, error)
+
+	SendRawTransaction(ctx context.Context, in *RawTransaction, opts ...grpc.CallOption) (*Ack, error)
+
+	ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error)
+
+	GetAddressHistory(ctx context.Context, in *GetAddressHistoryRequest, opts ...grpc.CallOption) (*GetAddressHistoryResponse, error)
+
+	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
 }
 
 type nodeServiceClient struct {
@@ -122,6 +140,66 @@ func (c *nodeServiceClient) GetContractState(ctx context.Context, in *GetContrac
 	return out, nil
 }
 
+func (c *nodeServiceClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransactionResponse)
+	err := c.cc.Invoke(ctx, NodeService_GetTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) GetSupply(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetSupplyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSupplyResponse)
+	err := c.cc.Invoke(ctx, NodeService_GetSupply_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) SendRawTransaction(ctx context.Context, in *RawTransaction, opts ...grpc.CallOption) (*Ack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ack)
+	err := c.cc.Invoke(ctx, NodeService_SendRawTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUnspentResponse)
+	err := c.cc.Invoke(ctx, NodeService_ListUnspent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) GetAddressHistory(ctx context.Context, in *GetAddressHistoryRequest, opts ...grpc.CallOption) (*GetAddressHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAddressHistoryResponse)
+	err := c.cc.Invoke(ctx, NodeService_GetAddressHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateResponse)
+	err := c.cc.Invoke(ctx, NodeService_Generate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 type NodeServiceServer interface {
 	SendTransaction(context.Context, *Transaction) (*Ack, error)
 
@@ -136,6 +214,18 @@ type NodeServiceServer interface {
 	FindSpendableUTXOs(context.Context, *FindSpendableUTXOsRequest) (*FindSpendableUTXOsResponse, error)
 
 	GetContractState(context.Context, *GetContractStateRequest) (*GetContractStateResponse, error)
+
+	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
+
+	GetSupply(context.Context, *EmptyRequest) (*GetSupplyResponse, error)
+
+	SendRawTransaction(context.Context, *RawTransaction) (*Ack, error)
+
+	ListUnspent(context.Context, *ListUnspentRequest) (*ListUnspentResponse, error)
+
+	GetAddressHistory(context.Context, *GetAddressHistoryRequest) (*GetAddressHistoryResponse, error)
+
+	Generate(context.Context, *GenerateRequest) (*GenerateResponse, error)
 	mustEmbedUnimplementedNodeServiceServer()
 }
 
@@ -162,6 +252,24 @@ func (UnimplementedNodeServiceServer) FindSpendableUTXOs(context.Context, *FindS
 func (UnimplementedNodeServiceServer) GetContractState(context.Context, *GetContractStateRequest) (*GetContractStateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetContractState not implemented")
 }
+func (UnimplementedNodeServiceServer) GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedNodeServiceServer) GetSupply(context.Context, *EmptyRequest) (*GetSupplyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupply not implemented")
+}
+func (UnimplementedNodeServiceServer) SendRawTransaction(context.Context, *RawTransaction) (*Ack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendRawTransaction not implemented")
+}
+func (UnimplementedNodeServiceServer) ListUnspent(context.Context, *ListUnspentRequest) (*ListUnspentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUnspent not implemented")
+}
+func (UnimplementedNodeServiceServer) GetAddressHistory(context.Context, *GetAddressHistoryRequest) (*GetAddressHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressHistory not implemented")
+}
+func (UnimplementedNodeServiceServer) Generate(context.Context, *GenerateRequest) (*GenerateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Generate not implemented")
+}
 func (UnimplementedNodeServiceServer) mustEmbedUnimplementedNodeServiceServer() {}
 func (UnimplementedNodeServiceServer) testEmbeddedByValue()                     {}
 
@@ -295,6 +403,114 @@ func _NodeService_GetContractState_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_GetSupply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetSupply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_GetSupply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetSupply(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_SendRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RawTransaction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).SendRawTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_SendRawTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).SendRawTransaction(ctx, req.(*RawTransaction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_ListUnspent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnspentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).ListUnspent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_ListUnspent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).ListUnspent(ctx, req.(*ListUnspentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_GetAddressHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAddressHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetAddressHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_GetAddressHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetAddressHistory(ctx, req.(*GetAddressHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_Generate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).Generate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_Generate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).Generate(ctx, req.(*GenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var NodeService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.NodeService",
 	HandlerType: (*NodeServiceServer)(nil),
@@ -323,6 +539,30 @@ var NodeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetContractState",
 			Handler:    _NodeService_GetContractState_Handler,
 		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _NodeService_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetSupply",
+			Handler:    _NodeService_GetSupply_Handler,
+		},
+		{
+			MethodName: "SendRawTransaction",
+			Handler:    _NodeService_SendRawTransaction_Handler,
+		},
+		{
+			MethodName: "ListUnspent",
+			Handler:    _NodeService_ListUnspent_Handler,
+		},
+		{
+			MethodName: "GetAddressHistory",
+			Handler:    _NodeService_GetAddressHistory_Handler,
+		},
+		{
+			MethodName: "Generate",
+			Handler:    _NodeService_Generate_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -18,24 +18,59 @@ var File_proto_public_proto protoreflect.FileDescriptor
 
 const file_proto_public_proto_rawDesc = "" +
 	"\n" +
-	"\x12proto/public.proto\x12\x05proto\x1a\x16proto/blockchain.proto2\xb7\x02\n" +
+	"\x12proto/public.proto\x12\x05proto\x1a\x16proto/blockchain.proto2\xf8\b\n" +
 	"\rPublicService\x12A\n" +
 	"\n" +
 	"GetBalance\x12\x18.proto.GetBalanceRequest\x1a\x19.proto.GetBalanceResponse\x12S\n" +
 	"\x10GetContractState\x12\x1e.proto.GetContractStateRequest\x1a\x1f.proto.GetContractStateResponse\x123\n" +
 	"\x11SubmitTransaction\x12\x12.proto.Transaction\x1a\n" +
+	".proto.Ack\x129\n" +
+	"\x14SubmitRawTransaction\x12\x15.proto.RawTransaction\x1a\n" +
 	".proto.Ack\x12Y\n" +
-	"\x12FindSpendableUTXOs\x12 .proto.FindSpendableUTXOsRequest\x1a!.proto.FindSpendableUTXOsResponseB\tZ\a./protob\x06proto3"
+	"\x12FindSpendableUTXOs\x12 .proto.FindSpendableUTXOsRequest\x1a!.proto.FindSpendableUTXOsResponse\x12:\n" +
+	"\tGetSupply\x12\x13.proto.EmptyRequest\x1a\x18.proto.GetSupplyResponse\x12D\n" +
+	"\vListUnspent\x12\x19.proto.ListUnspentRequest\x1a\x1a.proto.ListUnspentResponse\x12V\n" +
+	"\x11GetAddressHistory\x12\x1f.proto.GetAddressHistoryRequest\x1a .proto.GetAddressHistoryResponse\x12@\n" +
+	"\fGetChainInfo\x12\x13.proto.EmptyRequest\x1a\x1b.proto.GetChainInfoResponse\x12;\n" +
+	"\bGetBlock\x12\x16.proto.GetBlockRequest\x1a\x17.proto.GetBlockResponse\x12A\n" +
+	"\n" +
+	"ListBlocks\x12\x18.proto.ListBlocksRequest\x1a\x19.proto.ListBlocksResponse\x12M\n" +
+	"\x0eGetTransaction\x12\x1c.proto.GetTransactionRequest\x1a\x1d.proto.GetTransactionResponse\x12<\n" +
+	"\n" +
+	"GetMempool\x12\x13.proto.EmptyRequest\x1a\x19.proto.GetMempoolResponse\x12@\n" +
+	"\x12SubscribeNewBlocks\x12\x13.proto.EmptyRequest\x1a\x13.proto.BlockSummary0\x01\x12I\n" +
+	"\x10SubscribeAddress\x12\x1e.proto.SubscribeAddressRequest\x1a\x13.proto.AddressEvent0\x01\x12N\n" +
+	"\x10WatchTransaction\x12\x1e.proto.WatchTransactionRequest\x1a\x18.proto.TransactionStatus0\x01B\tZ\a./protob\x06proto3"
 
 var file_proto_public_proto_goTypes = []any{
 	(*GetBalanceRequest)(nil),
 	(*GetContractStateRequest)(nil),
 	(*Transaction)(nil),
+	(*RawTransaction)(nil),
 	(*FindSpendableUTXOsRequest)(nil),
+	(*EmptyRequest)(nil),
+	(*ListUnspentRequest)(nil),
+	(*GetAddressHistoryRequest)(nil),
+	(*GetBlockRequest)(nil),
+	(*ListBlocksRequest)(nil),
+	(*GetTransactionRequest)(nil),
+	(*SubscribeAddressRequest)(nil),
+	(*WatchTransactionRequest)(nil),
 	(*GetBalanceResponse)(nil),
 	(*GetContractStateResponse)(nil),
 	(*Ack)(nil),
 	(*FindSpendableUTXOsResponse)(nil),
+	(*GetSupplyResponse)(nil),
+	(*ListUnspentResponse)(nil),
+	(*GetAddressHistoryResponse)(nil),
+	(*GetChainInfoResponse)(nil),
+	(*GetBlockResponse)(nil),
+	(*ListBlocksResponse)(nil),
+	(*GetTransactionResponse)(nil),
+	(*GetMempoolResponse)(nil),
+	(*BlockSummary)(nil),
+	(*AddressEvent)(nil),
+	(*TransactionStatus)(nil),
 }
 var file_proto_public_proto_depIdxs = []int32{
 	0,
@@ -46,7 +81,31 @@ var file_proto_public_proto_depIdxs = []int32{
 	5,
 	6,
 	7,
-	4,
+	5,
+	8,
+	9,
+	10,
+	5,
+	5,
+	11,
+	12,
+	13,
+	14,
+	15,
+	15,
+	16,
+	17,
+	18,
+	19,
+	20,
+	21,
+	22,
+	23,
+	24,
+	25,
+	26,
+	27,
+	16,
 	0,
 	0,
 	0,
@@ -11,10 +11,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PublicService_GetBalance_FullMethodName         = "/proto.PublicService/GetBalance"
-	PublicService_GetContractState_FullMethodName   = "/proto.PublicService/GetContractState"
-	PublicService_SubmitTransaction_FullMethodName  = "/proto.PublicService/SubmitTransaction"
-	PublicService_FindSpendableUTXOs_FullMethodName = "/proto.PublicService/FindSpendableUTXOs"
+	PublicService_GetBalance_FullMethodName           = "/proto.PublicService/GetBalance"
+	PublicService_GetContractState_FullMethodName     = "/proto.PublicService/GetContractState"
+	PublicService_SubmitTransaction_FullMethodName    = "/proto.PublicService/SubmitTransaction"
+	PublicService_SubmitRawTransaction_FullMethodName = "/proto.PublicService/SubmitRawTransaction"
+	PublicService_FindSpendableUTXOs_FullMethodName   = "/proto.PublicService/FindSpendableUTXOs"
+	PublicService_GetSupply_FullMethodName            = "/proto.PublicService/GetSupply"
+	PublicService_ListUnspent_FullMethodName          = "/proto.PublicService/ListUnspent"
+	PublicService_GetAddressHistory_FullMethodName    = "/proto.PublicService/GetAddressHistory"
+	PublicService_GetChainInfo_FullMethodName         = "/proto.PublicService/GetChainInfo"
+	PublicService_GetBlock_FullMethodName             = "/proto.PublicService/GetBlock"
+	PublicService_ListBlocks_FullMethodName           = "/proto.PublicService/ListBlocks"
+	PublicService_GetTransaction_FullMethodName       = "/proto.PublicService/GetTransaction"
+	PublicService_GetMempool_FullMethodName           = "/proto.PublicService/GetMempool"
+	PublicService_SubscribeNewBlocks_FullMethodName   = "/proto.PublicService/SubscribeNewBlocks"
+	PublicService_SubscribeAddress_FullMethodName     = "/proto.PublicService/SubscribeAddress"
+	PublicService_WatchTransaction_FullMethodName     = "/proto.PublicService/WatchTransaction"
 )
 type PublicServiceClient interface {
 	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
@@ -23,7 +35,31 @@ type PublicServiceClient interface {
 
 	SubmitTransaction(ctx context.Context, in *Transaction, opts ...grpc.CallOption) (*Ack, error)
 
+	SubmitRawTransaction(ctx context.Context, in *RawTransaction, opts ...grpc.CallOption) (*Ack, error)
+
 	FindSpendableUTXOs(ctx context.Context, in *FindSpendableUTXOsRequest, opts ...grpc.CallOption) (*FindSpendableUTXOsResponse, error)
+
+	GetSupply(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetSupplyResponse, error)
+
+	ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error)
+
+	GetAddressHistory(ctx context.Context, in *GetAddressHistoryRequest, opts ...grpc.CallOption) (*GetAddressHistoryResponse, error)
+
+	GetChainInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetChainInfoResponse, error)
+
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
+
+	ListBlocks(ctx context.Context, in *ListBlocksRequest, opts ...grpc.CallOption) (*ListBlocksResponse, error)
+
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+
+	GetMempool(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetMempoolResponse, error)
+
+	SubscribeNewBlocks(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockSummary], error)
+
+	SubscribeAddress(ctx context.Context, in *SubscribeAddressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AddressEvent], error)
+
+	WatchTransaction(ctx context.Context, in *WatchTransactionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TransactionStatus], error)
 }
 
 type publicServiceClient struct {
@@ -64,6 +100,16 @@ func (c *publicServiceClient) SubmitTransaction(ctx context.Context, in *Transac
 	return out, nil
 }
 
+func (c *publicServiceClient) SubmitRawTransaction(ctx context.Context, in *RawTransaction, opts ...grpc.CallOption) (*Ack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ack)
+	err := c.cc.Invoke(ctx, PublicService_SubmitRawTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *publicServiceClient) FindSpendableUTXOs(ctx context.Context, in *FindSpendableUTXOsRequest, opts ...grpc.CallOption) (*FindSpendableUTXOsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(FindSpendableUTXOsResponse)
@@ -74,6 +120,140 @@ func (c *publicServiceClient) FindSpendableUTXOs(ctx context.Context, in *FindSp
 	return out, nil
 }
 
+func (c *publicServiceClient) GetSupply(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetSupplyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSupplyResponse)
+	err := c.cc.Invoke(ctx, PublicService_GetSupply_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicServiceClient) ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUnspentResponse)
+	err := c.cc.Invoke(ctx, PublicService_ListUnspent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicServiceClient) GetAddressHistory(ctx context.Context, in *GetAddressHistoryRequest, opts ...grpc.CallOption) (*GetAddressHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAddressHistoryResponse)
+	err := c.cc.Invoke(ctx, PublicService_GetAddressHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicServiceClient) GetChainInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetChainInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChainInfoResponse)
+	err := c.cc.Invoke(ctx, PublicService_GetChainInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicServiceClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBlockResponse)
+	err := c.cc.Invoke(ctx, PublicService_GetBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicServiceClient) ListBlocks(ctx context.Context, in *ListBlocksRequest, opts ...grpc.CallOption) (*ListBlocksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBlocksResponse)
+	err := c.cc.Invoke(ctx, PublicService_ListBlocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicServiceClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransactionResponse)
+	err := c.cc.Invoke(ctx, PublicService_GetTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicServiceClient) GetMempool(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetMempoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMempoolResponse)
+	err := c.cc.Invoke(ctx, PublicService_GetMempool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicServiceClient) SubscribeNewBlocks(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PublicService_ServiceDesc.Streams[0], PublicService_SubscribeNewBlocks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EmptyRequest, BlockSummary]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PublicService_SubscribeNewBlocksClient = grpc.ServerStreamingClient[BlockSummary]
+
+func (c *publicServiceClient) SubscribeAddress(ctx context.Context, in *SubscribeAddressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AddressEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PublicService_ServiceDesc.Streams[1], PublicService_SubscribeAddress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeAddressRequest, AddressEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PublicService_SubscribeAddressClient = grpc.ServerStreamingClient[AddressEvent]
+
+func (c *publicServiceClient) WatchTransaction(ctx context.Context, in *WatchTransactionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TransactionStatus], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PublicService_ServiceDesc.Streams[2], PublicService_WatchTransaction_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchTransactionRequest, TransactionStatus]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PublicService_WatchTransactionClient = grpc.ServerStreamingClient[TransactionStatus]
+
 type PublicServiceServer interface {
 	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
 
@@ -81,7 +261,31 @@ type PublicServiceServer interface {
 
 	SubmitTransaction(context.Context, *Transaction) (*Ack, error)
 
+	SubmitRawTransaction(context.Context, *RawTransaction) (*Ack, error)
+
 	FindSpendableUTXOs(context.Context, *FindSpendableUTXOsRequest) (*FindSpendableUTXOsResponse, error)
+
+	GetSupply(context.Context, *EmptyRequest) (*GetSupplyResponse, error)
+
+	ListUnspent(context.Context, *ListUnspentRequest) (*ListUnspentResponse, error)
+
+	GetAddressHistory(context.Context, *GetAddressHistoryRequest) (*GetAddressHistoryResponse, error)
+
+	GetChainInfo(context.Context, *EmptyRequest) (*GetChainInfoResponse, error)
+
+	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
+
+	ListBlocks(context.Context, *ListBlocksRequest) (*ListBlocksResponse, error)
+
+	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
+
+	GetMempool(context.Context, *EmptyRequest) (*GetMempoolResponse, error)
+
+	SubscribeNewBlocks(*EmptyRequest, grpc.ServerStreamingServer[BlockSummary]) error
+
+	SubscribeAddress(*SubscribeAddressRequest, grpc.ServerStreamingServer[AddressEvent]) error
+
+	WatchTransaction(*WatchTransactionRequest, grpc.ServerStreamingServer[TransactionStatus]) error
 	mustEmbedUnimplementedPublicServiceServer()
 }
 
@@ -96,9 +300,45 @@ func (UnimplementedPublicServiceServer) GetContractState(context.Context, *GetCo
 func (UnimplementedPublicServiceServer) SubmitTransaction(context.Context, *Transaction) (*Ack, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitTransaction not implemented")
 }
+func (UnimplementedPublicServiceServer) SubmitRawTransaction(context.Context, *RawTransaction) (*Ack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitRawTransaction not implemented")
+}
 func (UnimplementedPublicServiceServer) FindSpendableUTXOs(context.Context, *FindSpendableUTXOsRequest) (*FindSpendableUTXOsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindSpendableUTXOs not implemented")
 }
+func (UnimplementedPublicServiceServer) GetSupply(context.Context, *EmptyRequest) (*GetSupplyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupply not implemented")
+}
+func (UnimplementedPublicServiceServer) ListUnspent(context.Context, *ListUnspentRequest) (*ListUnspentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUnspent not implemented")
+}
+func (UnimplementedPublicServiceServer) GetAddressHistory(context.Context, *GetAddressHistoryRequest) (*GetAddressHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressHistory not implemented")
+}
+func (UnimplementedPublicServiceServer) GetChainInfo(context.Context, *EmptyRequest) (*GetChainInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChainInfo not implemented")
+}
+func (UnimplementedPublicServiceServer) GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedPublicServiceServer) ListBlocks(context.Context, *ListBlocksRequest) (*ListBlocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlocks not implemented")
+}
+func (UnimplementedPublicServiceServer) GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedPublicServiceServer) GetMempool(context.Context, *EmptyRequest) (*GetMempoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMempool not implemented")
+}
+func (UnimplementedPublicServiceServer) SubscribeNewBlocks(*EmptyRequest, grpc.ServerStreamingServer[BlockSummary]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeNewBlocks not implemented")
+}
+func (UnimplementedPublicServiceServer) SubscribeAddress(*SubscribeAddressRequest, grpc.ServerStreamingServer[AddressEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeAddress not implemented")
+}
+func (UnimplementedPublicServiceServer) WatchTransaction(*WatchTransactionRequest, grpc.ServerStreamingServer[TransactionStatus]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTransaction not implemented")
+}
 func (UnimplementedPublicServiceServer) mustEmbedUnimplementedPublicServiceServer() {}
 func (UnimplementedPublicServiceServer) testEmbeddedByValue()                       {}
 
@@ -168,6 +408,24 @@ func _PublicService_SubmitTransaction_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PublicService_SubmitRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RawTransaction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServiceServer).SubmitRawTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicService_SubmitRawTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServiceServer).SubmitRawTransaction(ctx, req.(*RawTransaction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PublicService_FindSpendableUTXOs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FindSpendableUTXOsRequest)
 	if err := dec(in); err != nil {
@@ -186,6 +444,180 @@ func _PublicService_FindSpendableUTXOs_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PublicService_GetSupply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServiceServer).GetSupply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicService_GetSupply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServiceServer).GetSupply(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicService_ListUnspent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnspentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServiceServer).ListUnspent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicService_ListUnspent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServiceServer).ListUnspent(ctx, req.(*ListUnspentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicService_GetAddressHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAddressHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServiceServer).GetAddressHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicService_GetAddressHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServiceServer).GetAddressHistory(ctx, req.(*GetAddressHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicService_GetChainInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServiceServer).GetChainInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicService_GetChainInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServiceServer).GetChainInfo(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicService_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServiceServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicService_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServiceServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicService_ListBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServiceServer).ListBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicService_ListBlocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServiceServer).ListBlocks(ctx, req.(*ListBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServiceServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicService_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicService_GetMempool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServiceServer).GetMempool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicService_GetMempool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServiceServer).GetMempool(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicService_SubscribeNewBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EmptyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PublicServiceServer).SubscribeNewBlocks(m, &grpc.GenericServerStream[EmptyRequest, BlockSummary]{ServerStream: stream})
+}
+
+type PublicService_SubscribeNewBlocksServer = grpc.ServerStreamingServer[BlockSummary]
+
+func _PublicService_SubscribeAddress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeAddressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PublicServiceServer).SubscribeAddress(m, &grpc.GenericServerStream[SubscribeAddressRequest, AddressEvent]{ServerStream: stream})
+}
+
+type PublicService_SubscribeAddressServer = grpc.ServerStreamingServer[AddressEvent]
+
+func _PublicService_WatchTransaction_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTransactionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PublicServiceServer).WatchTransaction(m, &grpc.GenericServerStream[WatchTransactionRequest, TransactionStatus]{ServerStream: stream})
+}
+
+type PublicService_WatchTransactionServer = grpc.ServerStreamingServer[TransactionStatus]
+
 var PublicService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.PublicService",
 	HandlerType: (*PublicServiceServer)(nil),
@@ -202,11 +634,63 @@ var PublicService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitTransaction",
 			Handler:    _PublicService_SubmitTransaction_Handler,
 		},
+		{
+			MethodName: "SubmitRawTransaction",
+			Handler:    _PublicService_SubmitRawTransaction_Handler,
+		},
 		{
 			MethodName: "FindSpendableUTXOs",
 			Handler:    _PublicService_FindSpendableUTXOs_Handler,
 		},
+		{
+			MethodName: "GetSupply",
+			Handler:    _PublicService_GetSupply_Handler,
+		},
+		{
+			MethodName: "ListUnspent",
+			Handler:    _PublicService_ListUnspent_Handler,
+		},
+		{
+			MethodName: "GetAddressHistory",
+			Handler:    _PublicService_GetAddressHistory_Handler,
+		},
+		{
+			MethodName: "GetChainInfo",
+			Handler:    _PublicService_GetChainInfo_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _PublicService_GetBlock_Handler,
+		},
+		{
+			MethodName: "ListBlocks",
+			Handler:    _PublicService_ListBlocks_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _PublicService_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetMempool",
+			Handler:    _PublicService_GetMempool_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeNewBlocks",
+			Handler:       _PublicService_SubscribeNewBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeAddress",
+			Handler:       _PublicService_SubscribeAddress_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchTransaction",
+			Handler:       _PublicService_WatchTransaction_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/public.proto",
 }
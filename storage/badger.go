@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"github.com/dgraph-io/badger/v3"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+)
+
+type badgerStore struct {
+	db *badger.DB
+}
+
+func OpenBadger(path string, readOnly bool) (domain.ChainStore, error) {
+	opts := badger.DefaultOptions(path)
+	opts.WithValueLogFileSize(1024 * 1024)
+	opts.WithLogger(nil)
+	opts.ReadOnly = readOnly
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &chainStore{kv: &badgerStore{db: db}}, nil
+}
+
+func (s *badgerStore) get(key []byte) ([]byte, error) {
+	var value []byte
+	err := s.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err == badger.ErrKeyNotFound {
+			return domain.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	return value, err
+}
+
+func (s *badgerStore) iterate(prefix []byte, reverse bool, fn func(key, value []byte) bool) error {
+	return s.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		opts.Reverse = reverse
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		seek := prefix
+		if reverse {
+			seek = append(append([]byte{}, prefix...), 0xff)
+		}
+		for it.Seek(seek); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if !fn(item.KeyCopy(nil), value) {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+func (s *badgerStore) update(fn func(w kvWriter) error) error {
+	return s.db.Update(func(txn *badger.Txn) error {
+		return fn(badgerWriter{txn})
+	})
+}
+
+func (s *badgerStore) close() error {
+	return s.db.Close()
+}
+
+type badgerWriter struct {
+	txn *badger.Txn
+}
+
+func (w badgerWriter) set(key, value []byte) error {
+	return w.txn.Set(key, value)
+}
+
+func (w badgerWriter) delete(key []byte) error {
+	return w.txn.Delete(key)
+}
+
+func (w badgerWriter) deletePrefix(prefix []byte) error {
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	opts.Prefix = prefix
+	it := w.txn.NewIterator(opts)
+	defer it.Close()
+
+	var keys [][]byte
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		keys = append(keys, it.Item().KeyCopy(nil))
+	}
+	for _, key := range keys {
+		if err := w.txn.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
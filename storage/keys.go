@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+)
+
+const (
+	lastHashKey         = "lh"
+	utxoPrefix          = "utxo-"
+	contractStatePrefix = "contract-state-"
+	contractCodePrefix  = "contract-code-"
+	metaPrefix          = "meta-"
+	txIndexPrefix       = "txindex-"
+	addrIndexPrefix     = "addrindex-"
+	undoPrefix          = "undo-"
+)
+
+const contractAddressSize = sha256.Size
+
+var errInvalidRecord = errors.New("bản ghi trong CSDL không hợp lệ")
+
+func prefixed(prefix string, parts ...[]byte) []byte {
+	key := []byte(prefix)
+	for _, part := range parts {
+		key = append(key, part...)
+	}
+	return key
+}
+
+func undoKey(hash []byte) []byte {
+	return prefixed(undoPrefix, hash)
+}
+
+func utxoKey(txID []byte) []byte {
+	return prefixed(utxoPrefix, txID)
+}
+
+func contractStateKey(address, key []byte) []byte {
+	return prefixed(contractStatePrefix, address, key)
+}
+
+func contractCodeKey(address []byte) []byte {
+	return prefixed(contractCodePrefix, address)
+}
+
+func metaKey(key string) []byte {
+	return prefixed(metaPrefix, []byte(key))
+}
+
+func txIndexKey(txID []byte) []byte {
+	return prefixed(txIndexPrefix, txID)
+}
+
+func addrIndexPrefixKey(pubKeyHash []byte) []byte {
+	return prefixed(addrIndexPrefix, pubKeyHash)
+}
+
+func addrIndexKey(pubKeyHash []byte, height int64, position int) []byte {
+	key := addrIndexPrefixKey(pubKeyHash)
+	key = binary.BigEndian.AppendUint64(key, uint64(height))
+	return binary.BigEndian.AppendUint32(key, uint32(position))
+}
+
+func encodeTxLocation(blockHash []byte, position int) []byte {
+	return binary.BigEndian.AppendUint32(append([]byte{}, blockHash...), uint32(position))
+}
+
+func decodeTxLocation(value []byte) ([]byte, int, error) {
+	if len(value) < 4 {
+		return nil, 0, errInvalidRecord
+	}
+	return value[:len(value)-4], int(binary.BigEndian.Uint32(value[len(value)-4:])), nil
+}
+
+func encodeAddressEntry(entry domain.AddressHistoryEntry) []byte {
+	value := make([]byte, 0, len(entry.TxID)+9)
+	value = append(value, entry.TxID...)
+	value = append(value, byte(entry.Direction))
+	return binary.BigEndian.AppendUint64(value, uint64(entry.Amount))
+}
+
+func decodeAddressEntry(suffix, value []byte) (domain.AddressHistoryEntry, error) {
+	if len(value) < 9 || len(suffix) < 12 {
+		return domain.AddressHistoryEntry{}, errors.New("bản ghi chỉ mục địa chỉ không hợp lệ")
+	}
+	return domain.AddressHistoryEntry{
+		TxID:      value[:len(value)-9],
+		Direction: domain.HistoryDirection(value[len(value)-9]),
+		Amount:    int64(binary.BigEndian.Uint64(value[len(value)-8:])),
+		Height:    int64(binary.BigEndian.Uint64(suffix[:8])),
+		Position:  int(binary.BigEndian.Uint32(suffix[8:12])),
+	}, nil
+}
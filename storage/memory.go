@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+)
+
+type memoryStore struct {
+	mu   sync.RWMutex
+	data map[string][]byte
+}
+
+func NewMemory() domain.ChainStore {
+	return &chainStore{kv: &memoryStore{data: make(map[string][]byte)}}
+}
+
+func (s *memoryStore) get(key []byte) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.data[string(key)]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return bytes.Clone(value), nil
+}
+
+func (s *memoryStore) iterate(prefix []byte, reverse bool, fn func(key, value []byte) bool) error {
+	s.mu.RLock()
+	var keys []string
+	for key := range s.data {
+		if strings.HasPrefix(key, string(prefix)) {
+			keys = append(keys, key)
+		}
+	}
+	values := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		values[key] = bytes.Clone(s.data[key])
+	}
+	s.mu.RUnlock()
+
+	sort.Strings(keys)
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	}
+	for _, key := range keys {
+		if !fn([]byte(key), values[key]) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) update(fn func(w kvWriter) error) error {
+	w := &memoryWriter{}
+	if err := fn(w); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, op := range w.ops {
+		op(s.data)
+	}
+	return nil
+}
+
+func (s *memoryStore) close() error {
+	return nil
+}
+
+type memoryWriter struct {
+	ops []func(data map[string][]byte)
+}
+
+func (w *memoryWriter) set(key, value []byte) error {
+	key, value = bytes.Clone(key), bytes.Clone(value)
+	w.ops = append(w.ops, func(data map[string][]byte) {
+		data[string(key)] = value
+	})
+	return nil
+}
+
+func (w *memoryWriter) delete(key []byte) error {
+	key = bytes.Clone(key)
+	w.ops = append(w.ops, func(data map[string][]byte) {
+		delete(data, string(key))
+	})
+	return nil
+}
+
+func (w *memoryWriter) deletePrefix(prefix []byte) error {
+	prefix = bytes.Clone(prefix)
+	w.ops = append(w.ops, func(data map[string][]byte) {
+		for key := range data {
+			if strings.HasPrefix(key, string(prefix)) {
+				delete(data, key)
+			}
+		}
+	})
+	return nil
+}
@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"github.com/khoahotran/gochain-ledger/domain"
+)
+
+type kvStore interface {
+	get(key []byte) ([]byte, error)
+	iterate(prefix []byte, reverse bool, fn func(key, value []byte) bool) error
+	update(fn func(w kvWriter) error) error
+	close() error
+}
+
+type kvWriter interface {
+	set(key, value []byte) error
+	delete(key []byte) error
+	deletePrefix(prefix []byte) error
+}
+
+type chainStore struct {
+	kv kvStore
+}
+
+func (s *chainStore) Block(hash []byte) (*domain.Block, error) {
+	value, err := s.kv.get(hash)
+	if err != nil {
+		return nil, err
+	}
+	return domain.DeserializeBlock(value)
+}
+
+func (s *chainStore) LastHash() ([]byte, error) {
+	return s.kv.get([]byte(lastHashKey))
+}
+
+func (s *chainStore) TxLocation(txID []byte) ([]byte, int, error) {
+	value, err := s.kv.get(txIndexKey(txID))
+	if err != nil {
+		return nil, 0, err
+	}
+	return decodeTxLocation(value)
+}
+
+func (s *chainStore) AddressIndex(pubKeyHash []byte, fn func(entry domain.AddressHistoryEntry) bool) error {
+	prefix := addrIndexPrefixKey(pubKeyHash)
+	var decodeErr error
+	err := s.kv.iterate(prefix, true, func(key, value []byte) bool {
+		entry, err := decodeAddressEntry(key[len(prefix):], value)
+		if err != nil {
+			decodeErr = err
+			return false
+		}
+		return fn(entry)
+	})
+	if err != nil {
+		return err
+	}
+	return decodeErr
+}
+
+func (s *chainStore) BlockUndo(hash []byte) (*domain.BlockUndo, error) {
+	value, err := s.kv.get(undoKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	return domain.DeserializeBlockUndo(value)
+}
+
+func (s *chainStore) UTXO(txID []byte) (*domain.UTXOEntry, error) {
+	value, err := s.kv.get(utxoKey(txID))
+	if err != nil {
+		return nil, err
+	}
+	return domain.DeserializeUTXOEntry(value)
+}
+
+func (s *chainStore) ForEachUTXO(fn func(txID []byte, entry *domain.UTXOEntry) bool) error {
+	var decodeErr error
+	err := s.kv.iterate([]byte(utxoPrefix), false, func(key, value []byte) bool {
+		entry, err := domain.DeserializeUTXOEntry(value)
+		if err != nil {
+			decodeErr = err
+			return false
+		}
+		return fn(key[len(utxoPrefix):], entry)
+	})
+	if err != nil {
+		return err
+	}
+	return decodeErr
+}
+
+func (s *chainStore) ContractState(address, key []byte) ([]byte, error) {
+	return s.kv.get(contractStateKey(address, key))
+}
+
+func (s *chainStore) ContractCode(address []byte) ([]byte, error) {
+	return s.kv.get(contractCodeKey(address))
+}
+
+func (s *chainStore) ForEachContractState(fn func(address, key, value []byte) bool) error {
+	var decodeErr error
+	err := s.kv.iterate([]byte(contractStatePrefix), false, func(key, value []byte) bool {
+		suffix := key[len(contractStatePrefix):]
+		if len(suffix) < contractAddressSize {
+			decodeErr = errInvalidRecord
+			return false
+		}
+		return fn(suffix[:contractAddressSize], suffix[contractAddressSize:], value)
+	})
+	if err != nil {
+		return err
+	}
+	return decodeErr
+}
+
+func (s *chainStore) ForEachContractCode(fn func(address, code []byte) bool) error {
+	return s.kv.iterate([]byte(contractCodePrefix), false, func(key, value []byte) bool {
+		return fn(key[len(contractCodePrefix):], value)
+	})
+}
+
+func (s *chainStore) Meta(key string) ([]byte, error) {
+	return s.kv.get(metaKey(key))
+}
+
+func (s *chainStore) Update(fn func(w domain.ChainWriter) error) error {
+	return s.kv.update(func(w kvWriter) error {
+		return fn(chainWriter{w})
+	})
+}
+
+func (s *chainStore) Close() error {
+	return s.kv.close()
+}
+
+type chainWriter struct {
+	kv kvWriter
+}
+
+func (w chainWriter) PutBlock(block *domain.Block) error {
+	return w.kv.set(block.Hash, block.Serialize())
+}
+
+func (w chainWriter) SetLastHash(hash []byte) error {
+	return w.kv.set([]byte(lastHashKey), hash)
+}
+
+func (w chainWriter) PutBlockUndo(hash []byte, undo *domain.BlockUndo) error {
+	return w.kv.set(undoKey(hash), undo.Serialize())
+}
+
+func (w chainWriter) DeleteBlockUndo(hash []byte) error {
+	return w.kv.delete(undoKey(hash))
+}
+
+func (w chainWriter) PutTxLocation(txID, blockHash []byte, position int) error {
+	return w.kv.set(txIndexKey(txID), encodeTxLocation(blockHash, position))
+}
+
+func (w chainWriter) PutAddressIndex(pubKeyHash []byte, entry domain.AddressHistoryEntry) error {
+	return w.kv.set(addrIndexKey(pubKeyHash, entry.Height, entry.Position), encodeAddressEntry(entry))
+}
+
+func (w chainWriter) DeleteTxLocation(txID []byte) error {
+	return w.kv.delete(txIndexKey(txID))
+}
+
+func (w chainWriter) DeleteAddressIndex(pubKeyHash []byte, height int64, position int) error {
+	return w.kv.delete(addrIndexKey(pubKeyHash, height, position))
+}
+
+func (w chainWriter) ClearIndex() error {
+	if err := w.kv.deletePrefix([]byte(txIndexPrefix)); err != nil {
+		return err
+	}
+	return w.kv.deletePrefix([]byte(addrIndexPrefix))
+}
+
+func (w chainWriter) PutUTXO(txID []byte, entry *domain.UTXOEntry) error {
+	return w.kv.set(utxoKey(txID), entry.Serialize())
+}
+
+func (w chainWriter) DeleteUTXO(txID []byte) error {
+	return w.kv.delete(utxoKey(txID))
+}
+
+func (w chainWriter) ClearUTXOs() error {
+	return w.kv.deletePrefix([]byte(utxoPrefix))
+}
+
+func (w chainWriter) SetContractState(address, key, value []byte) error {
+	return w.kv.set(contractStateKey(address, key), value)
+}
+
+func (w chainWriter) DeleteContractState(address, key []byte) error {
+	return w.kv.delete(contractStateKey(address, key))
+}
+
+func (w chainWriter) SetContractCode(address, code []byte) error {
+	return w.kv.set(contractCodeKey(address), code)
+}
+
+func (w chainWriter) DeleteContractCode(address []byte) error {
+	return w.kv.delete(contractCodeKey(address))
+}
+
+func (w chainWriter) ClearContracts() error {
+	if err := w.kv.deletePrefix([]byte(contractStatePrefix)); err != nil {
+		return err
+	}
+	return w.kv.deletePrefix([]byte(contractCodePrefix))
+}
+
+func (w chainWriter) SetMeta(key string, value []byte) error {
+	return w.kv.set(metaKey(key), value)
+}
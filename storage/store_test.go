@@ -0,0 +1,317 @@
+package storage_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/khoahotran/gochain-ledger/domain"
+	"github.com/khoahotran/gochain-ledger/storage"
+)
+
+var backends = []struct {
+	name string
+	open func(t *testing.T) domain.ChainStore
+}{
+	{"memory", func(t *testing.T) domain.ChainStore { return storage.NewMemory() }},
+	{"badger", func(t *testing.T) domain.ChainStore {
+		store, err := storage.OpenBadger(t.TempDir(), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return store
+	}},
+}
+
+func forEachBackend(t *testing.T, test func(t *testing.T, store domain.ChainStore)) {
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			store := backend.open(t)
+			defer store.Close()
+			test(t, store)
+		})
+	}
+}
+
+func hash(parts ...any) []byte {
+	sum := sha256.Sum256([]byte(fmt.Sprint(parts...)))
+	return sum[:]
+}
+
+func update(t *testing.T, store domain.ChainStore, fn func(w domain.ChainWriter) error) {
+	t.Helper()
+	if err := store.Update(fn); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testBlock(height int64) *domain.Block {
+	coinbase := &domain.Transaction{
+		Vin:  []domain.TxInput{{VoutIndex: -1, PublicKey: []byte(fmt.Sprintf("Reward-%d", height))}},
+		Vout: []domain.TxOutput{{Value: 100, PubKeyHash: hash("miner")}},
+	}
+	coinbase.SetID()
+	return &domain.Block{
+		Timestamp:     1735689600 + height,
+		PrevBlockHash: hash("block", height-1),
+		Hash:          hash("block", height),
+		Transactions:  []*domain.Transaction{coinbase},
+		Height:        height,
+	}
+}
+
+func TestChainStoreMissingKeys(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store domain.ChainStore) {
+		if _, err := store.LastHash(); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("LastHash: %v", err)
+		}
+		if _, err := store.Block(hash("nope")); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Block: %v", err)
+		}
+		if _, _, err := store.TxLocation(hash("nope")); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("TxLocation: %v", err)
+		}
+		if _, err := store.UTXO(hash("nope")); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("UTXO: %v", err)
+		}
+		if _, err := store.ContractState(hash("nope"), []byte("k")); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("ContractState: %v", err)
+		}
+		if _, err := store.ContractCode(hash("nope")); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("ContractCode: %v", err)
+		}
+		if _, err := store.Meta("nope"); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Meta: %v", err)
+		}
+	})
+}
+
+func TestChainStoreRoundTrip(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store domain.ChainStore) {
+		block := testBlock(1)
+		entry := &domain.UTXOEntry{Height: 1, Coinbase: true, Outputs: map[int]domain.TxOutput{0: block.Transactions[0].Vout[0]}}
+		contract := hash("contract")
+
+		update(t, store, func(w domain.ChainWriter) error {
+			for _, err := range []error{
+				w.PutBlock(block),
+				w.SetLastHash(block.Hash),
+				w.PutTxLocation(block.Transactions[0].ID, block.Hash, 0),
+				w.PutUTXO(block.Transactions[0].ID, entry),
+				w.SetContractCode(contract, []byte("code")),
+				w.SetContractState(contract, []byte("k"), []byte("v")),
+				w.SetMeta("m", []byte{1, 2}),
+			} {
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+		got, err := store.Block(block.Hash)
+		if err != nil || !bytes.Equal(got.Serialize(), block.Serialize()) {
+			t.Errorf("Block: %v", err)
+		}
+		if last, err := store.LastHash(); err != nil || !bytes.Equal(last, block.Hash) {
+			t.Errorf("LastHash = %x, %v", last, err)
+		}
+		if blockHash, pos, err := store.TxLocation(block.Transactions[0].ID); err != nil || !bytes.Equal(blockHash, block.Hash) || pos != 0 {
+			t.Errorf("TxLocation = %x %d, %v", blockHash, pos, err)
+		}
+		if utxo, err := store.UTXO(block.Transactions[0].ID); err != nil || !bytes.Equal(utxo.Serialize(), entry.Serialize()) {
+			t.Errorf("UTXO: %v", err)
+		}
+		if code, err := store.ContractCode(contract); err != nil || string(code) != "code" {
+			t.Errorf("ContractCode = %q, %v", code, err)
+		}
+		if value, err := store.ContractState(contract, []byte("k")); err != nil || string(value) != "v" {
+			t.Errorf("ContractState = %q, %v", value, err)
+		}
+		if value, err := store.Meta("m"); err != nil || !bytes.Equal(value, []byte{1, 2}) {
+			t.Errorf("Meta = %x, %v", value, err)
+		}
+	})
+}
+
+func TestChainStoreUpdateIsAtomic(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store domain.ChainStore) {
+		update(t, store, func(w domain.ChainWriter) error {
+			return w.SetMeta("kept", []byte{1})
+		})
+
+		failure := errors.New("hủy")
+		err := store.Update(func(w domain.ChainWriter) error {
+			if err := w.PutBlock(testBlock(1)); err != nil {
+				return err
+			}
+			if err := w.SetMeta("kept", []byte{2}); err != nil {
+				return err
+			}
+			return failure
+		})
+		if !errors.Is(err, failure) {
+			t.Fatalf("Update trả về %v, muốn lỗi của fn", err)
+		}
+		if _, err := store.Block(testBlock(1).Hash); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("block của Update thất bại vẫn được ghi: %v", err)
+		}
+		if value, _ := store.Meta("kept"); !bytes.Equal(value, []byte{1}) {
+			t.Errorf("meta bị ghi đè bởi Update thất bại: %x", value)
+		}
+	})
+}
+
+func TestChainStoreAddressIndexNewestFirst(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store domain.ChainStore) {
+		alice, bob := hash("alice"), hash("bob")
+		update(t, store, func(w domain.ChainWriter) error {
+			for _, e := range []struct {
+				pkh      []byte
+				height   int64
+				position int
+			}{{alice, 1, 0}, {alice, 2, 1}, {alice, 2, 0}, {bob, 3, 0}, {alice, 10, 0}} {
+				entry := domain.AddressHistoryEntry{TxID: hash(e.height, e.position), Height: e.height, Position: e.position, Direction: domain.HistoryReceived, Amount: -e.height}
+				if err := w.PutAddressIndex(e.pkh, entry); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+		var got []string
+		err := store.AddressIndex(alice, func(entry domain.AddressHistoryEntry) bool {
+			if !bytes.Equal(entry.TxID, hash(entry.Height, entry.Position)) || entry.Amount != -entry.Height || entry.Direction != domain.HistoryReceived {
+				t.Errorf("mục chỉ mục sai: %+v", entry)
+			}
+			got = append(got, fmt.Sprintf("%d/%d", entry.Height, entry.Position))
+			return len(got) < 3
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "[10/0 2/1 2/0]"; fmt.Sprint(got) != want {
+			t.Errorf("thứ tự lịch sử = %v, muốn %s", got, want)
+		}
+	})
+}
+
+func TestChainStoreIterationAndClear(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store domain.ChainStore) {
+		a, b := hash("a"), hash("b")
+		block := testBlock(1)
+		update(t, store, func(w domain.ChainWriter) error {
+			for _, err := range []error{
+				w.PutBlock(block),
+				w.PutUTXO(hash("tx1"), &domain.UTXOEntry{Height: 1, Outputs: map[int]domain.TxOutput{0: {Value: 1}}}),
+				w.PutUTXO(hash("tx2"), &domain.UTXOEntry{Height: 2, Outputs: map[int]domain.TxOutput{1: {Value: 2}}}),
+				w.SetContractCode(a, []byte("code-a")),
+				w.SetContractCode(b, []byte("code-b")),
+				w.SetContractState(a, []byte("x"), []byte("1")),
+				w.SetContractState(b, []byte("y"), []byte("2")),
+				w.PutTxLocation(hash("tx1"), block.Hash, 0),
+				w.PutAddressIndex(a, domain.AddressHistoryEntry{TxID: hash("tx1"), Height: 1}),
+				w.SetMeta("m", []byte{1}),
+			} {
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+		utxos := make(map[string]int64)
+		if err := store.ForEachUTXO(func(txID []byte, entry *domain.UTXOEntry) bool {
+			utxos[string(txID)] = entry.Height
+			return true
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if len(utxos) != 2 || utxos[string(hash("tx1"))] != 1 || utxos[string(hash("tx2"))] != 2 {
+			t.Errorf("ForEachUTXO = %v", utxos)
+		}
+
+		state := make(map[string]string)
+		if err := store.ForEachContractState(func(address, key, value []byte) bool {
+			state[fmt.Sprintf("%x/%s", address, key)] = string(value)
+			return true
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if len(state) != 2 || state[fmt.Sprintf("%x/x", a)] != "1" || state[fmt.Sprintf("%x/y", b)] != "2" {
+			t.Errorf("ForEachContractState = %v", state)
+		}
+
+		codes := 0
+		if err := store.ForEachContractCode(func(address, code []byte) bool {
+			codes++
+			return false
+		}); err != nil || codes != 1 {
+			t.Errorf("ForEachContractCode dừng sớm: %d lần gọi, %v", codes, err)
+		}
+
+		update(t, store, func(w domain.ChainWriter) error {
+			if err := w.ClearUTXOs(); err != nil {
+				return err
+			}
+			if err := w.ClearContracts(); err != nil {
+				return err
+			}
+			return w.ClearIndex()
+		})
+		if _, err := store.UTXO(hash("tx1")); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("UTXO sau ClearUTXOs: %v", err)
+		}
+		if _, err := store.ContractCode(a); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("code sau ClearContracts: %v", err)
+		}
+		if _, err := store.ContractState(b, []byte("y")); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("state sau ClearContracts: %v", err)
+		}
+		if _, _, err := store.TxLocation(hash("tx1")); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("TxLocation sau ClearIndex: %v", err)
+		}
+		entries := 0
+		if err := store.AddressIndex(a, func(domain.AddressHistoryEntry) bool { entries++; return true }); err != nil || entries != 0 {
+			t.Errorf("AddressIndex sau ClearIndex: %d mục, %v", entries, err)
+		}
+		if _, err := store.Block(block.Hash); err != nil {
+			t.Errorf("Clear* xoá cả block: %v", err)
+		}
+		if _, err := store.Meta("m"); err != nil {
+			t.Errorf("Clear* xoá cả meta: %v", err)
+		}
+	})
+}
+
+func TestBadgerPersistsAcrossReopen(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.OpenBadger(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := testBlock(7)
+	update(t, store, func(w domain.ChainWriter) error {
+		if err := w.PutBlock(block); err != nil {
+			return err
+		}
+		return w.SetLastHash(block.Hash)
+	})
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = storage.OpenBadger(dir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if last, err := store.LastHash(); err != nil || !bytes.Equal(last, block.Hash) {
+		t.Fatalf("LastHash sau khi mở lại = %x, %v", last, err)
+	}
+	if got, err := store.Block(block.Hash); err != nil || got.Height != 7 {
+		t.Fatalf("Block sau khi mở lại: %v", err)
+	}
+}
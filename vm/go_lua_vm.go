@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/khoahotran/gochain-ledger/domain"
 	lua "github.com/yuin/gopher-lua"
 )
 
@@ -15,8 +14,13 @@ type VM struct {
 
 type ContextKey string
 
+type StateStore interface {
+	GetContractState(contractAddress []byte, key []byte) ([]byte, error)
+	SetContractState(contractAddress []byte, key []byte, value []byte) error
+}
+
 const (
-	ctxBlockchainKey      ContextKey = "blockchain"
+	ctxStateKey           ContextKey = "state"
 	ctxContractAddressKey ContextKey = "contract_address"
 	ctxSenderAddressKey   ContextKey = "sender_address"
 )
@@ -37,11 +41,11 @@ func (v *VM) Close() {
 	v.L.Close()
 }
 
-func (v *VM) SetContext(bc *domain.Blockchain, contractAddress []byte, senderAddress []byte) {
+func (v *VM) SetContext(state StateStore, contractAddress []byte, senderAddress []byte) {
 
 	ctx := context.Background()
 
-	ctx = context.WithValue(ctx, ctxBlockchainKey, bc)
+	ctx = context.WithValue(ctx, ctxStateKey, state)
 	ctx = context.WithValue(ctx, ctxContractAddressKey, contractAddress)
 	ctx = context.WithValue(ctx, ctxSenderAddressKey, senderAddress)
 
@@ -63,10 +67,10 @@ func luaDbPut(L *lua.LState) int {
 	value := L.ToString(2)
 
 	ctx := L.Context()
-	bc := ctx.Value(ctxBlockchainKey).(*domain.Blockchain)
+	state := ctx.Value(ctxStateKey).(StateStore)
 	contractAddress := ctx.Value(ctxContractAddressKey).([]byte)
 
-	err := bc.SetContractState(contractAddress, []byte(key), []byte(value))
+	err := state.SetContractState(contractAddress, []byte(key), []byte(value))
 	if err != nil {
 		log.Printf("VM (db_put): Lỗi: %v", err)
 		L.Push(lua.LBool(false))
@@ -82,10 +86,10 @@ func luaDbGet(L *lua.LState) int {
 	key := L.ToString(1)
 
 	ctx := L.Context()
-	bc := ctx.Value(ctxBlockchainKey).(*domain.Blockchain)
+	state := ctx.Value(ctxStateKey).(StateStore)
 	contractAddress := ctx.Value(ctxContractAddressKey).([]byte)
 
-	value, err := bc.GetContractState(contractAddress, []byte(key))
+	value, err := state.GetContractState(contractAddress, []byte(key))
 	if err != nil {
 		log.Printf("VM (db_get): Lỗi: %v", err)
 		L.Push(lua.LNil)
@@ -3,13 +3,10 @@ package wallet
 import (
 	"crypto/aes"
 	"crypto/cipher"
-	"crypto/ecdsa"
-	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"io"
-	"math/big"
 	"os"
 	"path/filepath"
 
@@ -17,22 +14,28 @@ import (
 	"golang.org/x/crypto/scrypt"
 )
 
+var walletDir = "wallets"
+
 const (
-	walletDir    = "wallets"
 	scryptN      = 16384
 	scryptR      = 8
 	scryptP      = 1
 	scryptKeyLen = 32
 )
 
+func SetWalletDir(dir string) {
+	walletDir = dir
+}
+
 type WalletFile struct {
 	Address      string `json:"address"`
+	KeyType      string `json:"key_type,omitempty"`
 	PublicKey    []byte `json:"public_key"`
 	EncryptedKey []byte `json:"encrypted_key"`
 	Salt         []byte `json:"salt"`
 }
 
-func EncryptKey(privKey *ecdsa.PrivateKey, password string) ([]byte, []byte, error) {
+func EncryptKey(privKey domain.PrivateKey, password string) ([]byte, []byte, error) {
 
 	salt := make([]byte, 16)
 	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
@@ -59,13 +62,13 @@ func EncryptKey(privKey *ecdsa.PrivateKey, password string) ([]byte, []byte, err
 		return nil, nil, err
 	}
 
-	plaintext := privKey.D.Bytes()
+	plaintext := privKey.Bytes()
 	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
 
 	return ciphertext, salt, nil
 }
 
-func (wf *WalletFile) decryptKey(password string) (*ecdsa.PrivateKey, error) {
+func (wf *WalletFile) decryptKey(password string) (domain.PrivateKey, error) {
 
 	aesKey, err := scrypt.Key([]byte(password), wf.Salt, scryptN, scryptR, scryptP, scryptKeyLen)
 	if err != nil {
@@ -94,12 +97,19 @@ func (wf *WalletFile) decryptKey(password string) (*ecdsa.PrivateKey, error) {
 		return nil, fmt.Errorf("giải mã thất bại (sai mật khẩu?)")
 	}
 
-	privKey := new(ecdsa.PrivateKey)
-	privKey.D = new(big.Int).SetBytes(plaintext)
-	privKey.PublicKey.Curve = elliptic.P256()
-	privKey.PublicKey.X, privKey.PublicKey.Y = privKey.PublicKey.Curve.ScalarBaseMult(plaintext)
+	keyType := domain.KeyTypeP256
+	if wf.KeyType != "" {
+		keyType, err = domain.ParseKeyType(wf.KeyType)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	return privKey, nil
+	scheme, err := domain.SchemeFor(keyType)
+	if err != nil {
+		return nil, err
+	}
+	return scheme.PrivateKeyFromBytes(plaintext)
 }
 
 func (wf *WalletFile) Save() error {
@@ -147,7 +157,7 @@ func LoadAndDecrypt(address, password string) (*domain.Wallet, error) {
 	}
 
 	return &domain.Wallet{
-		PrivateKey: *privKey,
-		PublicKey:  wf.PublicKey,
+		PrivateKey: privKey,
+		PublicKey:  privKey.PublicKey(),
 	}, nil
 }